@@ -7,8 +7,6 @@ import (
 
 	"github.com/0chain/gosdk/core/common"
 
-	"github.com/0chain/gosdk/core/zcncrypto"
-	climodel "github.com/0chain/system_test/internal/cli/model"
 	"github.com/herumi/bls-go-binary/bls"
 	"gorm.io/gorm"
 )
@@ -57,6 +55,9 @@ type SdkWallet struct {
 	Mnemonics   string        `json:"mnemonics"`
 	Version     string        `json:"version"`
 	DateCreated string        `json:"date_created"`
+
+	PeerPublicKey string `json:"peer_public_key,omitempty"`
+	IsSplit       bool   `json:"is_split,omitempty"`
 }
 
 type SdkKeyPair struct {
@@ -82,62 +83,6 @@ func (w *SdkWallet) String() (string, error) {
 	return string(out), nil
 }
 
-func (w *Wallet) ToSdkWallet(mnemonics string) *SdkWallet {
-	return &SdkWallet{
-		ClientID:  w.Id,
-		ClientKey: w.PublicKey,
-		Keys: []*SdkKeyPair{{
-			PrivateKey: w.Keys.PrivateKey.SerializeToHexStr(),
-			PublicKey:  w.Keys.PublicKey.SerializeToHexStr(),
-		}},
-		Mnemonics: mnemonics,
-		Version:   w.Version,
-	}
-}
-
-func (w *SdkWallet) ToCliModelWalletFile() *climodel.WalletFile {
-	keys := make([]climodel.KeyPair, 0, len(w.Keys))
-	for _, key := range w.Keys {
-		keys = append(keys, climodel.KeyPair{
-			PublicKey:  key.PublicKey,
-			PrivateKey: key.PrivateKey,
-		})
-	}
-
-	return &climodel.WalletFile{
-		ClientID:    w.ClientID,
-		ClientKey:   w.ClientKey,
-		Keys:        keys,
-		Mnemonic:    w.Mnemonics,
-		Version:     w.Version,
-		DateCreated: w.DateCreated,
-	}
-}
-
-func (w *Wallet) ToZCNCryptoWallet(mnemonic string) *zcncrypto.Wallet {
-	if w == nil {
-		return nil
-	}
-
-	var keys []zcncrypto.KeyPair
-	keys = append(keys, zcncrypto.KeyPair{
-		PublicKey:  w.Keys.PublicKey.GetHexString(),
-		PrivateKey: w.Keys.PrivateKey.GetHexString(),
-	})
-	var dateCreated string
-	if w.CreationDate != nil {
-		dateCreated = time.Unix(int64(*w.CreationDate), 0).Format(time.RFC3339)
-	}
-	return &zcncrypto.Wallet{
-		ClientID:    w.Id,
-		ClientKey:   w.PublicKey,
-		Keys:        keys,
-		Mnemonic:    mnemonic,
-		Version:     w.Version,
-		DateCreated: dateCreated,
-	}
-}
-
 type TransactionData struct {
 	Name  string      `json:"name"`
 	Input interface{} `json:"input"`
@@ -330,7 +275,7 @@ type TransactionPutResponse struct {
 }
 
 type TransactionGetConfirmationRequest struct {
-	Hash string
+	Hash string `param:"hash"`
 }
 
 type TransactionGetConfirmationResponse struct {
@@ -357,7 +302,7 @@ type MerkleTreePath struct {
 }
 
 type ClientGetBalanceRequest struct {
-	ClientID string
+	ClientID string `param:"client_id"`
 }
 
 type ClientGetBalanceResponse struct {
@@ -368,16 +313,16 @@ type ClientGetBalanceResponse struct {
 }
 
 type QueryRequest struct {
-	Query string
+	Query string `param:"query,escape"`
 }
 
 type BlockRewardsRequest struct {
-	Start int64
-	End   int64
+	Start int64 `param:"start"`
+	End   int64 `param:"end"`
 }
 
 type GetAllChallengesForAllocationRequest struct {
-	AllocationID string
+	AllocationID string `param:"allocation_id"`
 }
 
 type QueryRewardsResponse struct {
@@ -387,7 +332,8 @@ type QueryRewardsResponse struct {
 }
 
 type SCStateGetRequest struct {
-	SCAddress, Key string
+	SCAddress string `form:"sc_address"`
+	Key       string `form:"key"`
 }
 
 type SCStateGetResponse struct {
@@ -418,7 +364,7 @@ type GetSharderStatsResponse struct {
 }
 
 type SCRestOpenChallengeRequest struct {
-	BlobberID string
+	BlobberID string `param:"blobber"`
 }
 
 type SCRestOpenChallengeResponse struct {
@@ -480,7 +426,7 @@ type SCRestGetAllocationBlobbersResponse struct {
 }
 
 type SCRestGetAllocationRequest struct {
-	AllocationID string
+	AllocationID string `param:"allocation"`
 }
 
 type SCRestGetAllocationBlobbersRequest struct {
@@ -542,7 +488,12 @@ type CancelAllocationRequest struct {
 }
 
 type SCRestGetBlobberRequest struct {
-	BlobberID string
+	BlobberID string `param:"blobber_id"`
+}
+
+type SCRestGetBlobbersRequest struct {
+	Active bool `param:"active,omitempty"`
+	Limit  int  `param:"limit,omitempty"`
 }
 
 type BlobberGetHashnodeRequest struct {
@@ -952,8 +903,8 @@ type BlobberDownloadFileResponse struct {
 }
 
 type SCRestGetStakePoolStatRequest struct {
-	ProviderType string
-	ProviderID   string
+	ProviderType string `param:"provider_type"`
+	ProviderID   string `param:"provider_id"`
 }
 
 type SCRestGetStakePoolStatResponse struct {
@@ -971,7 +922,7 @@ type SCRestGetStakePoolStatResponse struct {
 }
 
 type SCRestGetUserStakePoolStatRequest struct {
-	ClientId string
+	ClientId string `param:"client_id"`
 }
 
 type SCRestGetUserStakePoolStatResponse struct {
@@ -0,0 +1,78 @@
+// Package challenges follows storage challenges of allocations through the sharder REST API,
+// so tests can wait for the challenges they need instead of sleeping for a fixed time.
+package challenges
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const idLength = 64
+
+var ErrInvalidID = errors.New("invalid id")
+
+// Query selects challenges counted by count-challenges, zero fields are not part of the query
+type Query struct {
+	AllocationID string
+	BlobberID    string
+	// FromRound is the first round challenges were created in
+	FromRound int64
+	// ToRound is the round after the last one challenges were created in
+	ToRound int64
+}
+
+// Build returns the query condition, ids are validated so they cannot change the query
+func (q Query) Build() (string, error) {
+	var conditions []string
+	for _, condition := range []struct {
+		column, id string
+	}{
+		{"allocation_id", q.AllocationID},
+		{"blobber_id", q.BlobberID},
+	} {
+		if condition.id == "" {
+			continue
+		}
+		if err := validateID(condition.id); err != nil {
+			return "", fmt.Errorf("%s: %w", condition.column, err)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = '%s'", condition.column, condition.id))
+	}
+	if q.FromRound > 0 {
+		conditions = append(conditions, fmt.Sprintf("round_created_at >= %d", q.FromRound))
+	}
+	if q.ToRound > 0 {
+		conditions = append(conditions, fmt.Sprintf("round_created_at < %d", q.ToRound))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// Counts is the result of count-challenges
+type Counts struct {
+	Total  int64
+	Passed int64
+	Failed int64
+	Open   int64
+}
+
+func countsFromMap(counts map[string]int64) Counts {
+	return Counts{
+		Total:  counts["total"],
+		Passed: counts["passed"],
+		Failed: counts["failed"],
+		Open:   counts["open"],
+	}
+}
+
+// validateID accepts hashes used as ids of allocations and providers on chain
+func validateID(id string) error {
+	if len(id) != idLength {
+		return fmt.Errorf("%w %q: expected %d characters", ErrInvalidID, id, idLength)
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return fmt.Errorf("%w %q: %v", ErrInvalidID, id, err)
+	}
+	return nil
+}
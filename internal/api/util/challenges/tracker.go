@@ -0,0 +1,269 @@
+package challenges
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
+)
+
+// Contains statuses of challenges
+const (
+	Open    Status = "open"
+	Passed  Status = "passed"
+	Failed  Status = "failed"
+	Expired Status = "expired"
+)
+
+// respondedExpired is the responded value of challenges the chain expired
+const respondedExpired = 2
+
+type Status string
+
+// Challenge is the last observed state of a challenge
+type Challenge struct {
+	ID             string
+	AllocationID   string
+	BlobberID      string
+	Status         Status
+	RoundCreatedAt int64
+	RoundResponded int64
+	// Transitions lists statuses in the order they were observed
+	Transitions []Transition
+}
+
+// Transition is a change of the challenge status observed in a round
+type Transition struct {
+	Status Status
+	Round  int64
+}
+
+// Latency returns rounds between creation and response of the challenge, it is zero until the challenge is responded
+func (c *Challenge) Latency() int64 {
+	if c.Status != Passed && c.Status != Failed {
+		return 0
+	}
+	return c.RoundResponded - c.RoundCreatedAt
+}
+
+// BlobberStats summarizes challenges of a blobber
+type BlobberStats struct {
+	Total   int
+	Open    int
+	Passed  int
+	Failed  int
+	Expired int
+	// Latency is the distribution of rounds it took the blobber to respond
+	Latency LatencyStats
+}
+
+// LatencyStats contains response latency percentiles in rounds
+type LatencyStats struct {
+	Min int64
+	P50 int64
+	P90 int64
+	Max int64
+}
+
+// Finished returns number of challenges which are no longer open
+func (s BlobberStats) Finished() int {
+	return s.Passed + s.Failed + s.Expired
+}
+
+// PassRate returns fraction of finished challenges which passed
+func (s BlobberStats) PassRate() float64 {
+	if s.Finished() == 0 {
+		return 0
+	}
+	return float64(s.Passed) / float64(s.Finished())
+}
+
+// Tracker polls challenges of allocations and follows each of them from creation to the result
+type Tracker struct {
+	client           *client.APIClient
+	allocations      []string
+	completionRounds int64
+
+	polled     bool
+	round      int64
+	challenges map[string]*Challenge
+}
+
+// NewTracker tracks challenges of the allocations. Challenges open for more than completionRounds are
+// expired, zero completionRounds leaves expiring to the chain.
+func NewTracker(apiClient *client.APIClient, completionRounds int64, allocationIDs ...string) *Tracker {
+	return &Tracker{
+		client:           apiClient,
+		allocations:      allocationIDs,
+		completionRounds: completionRounds,
+		challenges:       make(map[string]*Challenge),
+	}
+}
+
+// Round returns the latest finalized round of the last poll
+func (tr *Tracker) Round() int64 {
+	return tr.round
+}
+
+// Poll reads challenges of tracked allocations, challenges are read once per finalized round
+func (tr *Tracker) Poll(t *test.SystemTest) error {
+	round, err := tr.client.LatestFinalizedRound(t)(context.Background())
+	if err != nil {
+		return err
+	}
+	if tr.polled && round == tr.round {
+		return nil
+	}
+
+	for _, allocationID := range tr.allocations {
+		challenges, _, err := tr.client.V1SCRestGetAllChallengesForAllocation(t, allocationID, client.HttpOkStatus)
+		if err != nil {
+			return fmt.Errorf("challenges of allocation %s: %w", allocationID, err)
+		}
+		for _, challenge := range challenges {
+			tr.observe(challenge, round)
+		}
+	}
+	tr.polled = true
+	tr.round = round
+	return nil
+}
+
+func (tr *Tracker) observe(observed *model.Challenge, round int64) {
+	challenge, ok := tr.challenges[observed.ChallengeID]
+	if !ok {
+		challenge = &Challenge{
+			ID:             observed.ChallengeID,
+			AllocationID:   observed.AllocationID,
+			BlobberID:      observed.BlobberID,
+			RoundCreatedAt: observed.RoundCreatedAt,
+		}
+		tr.challenges[observed.ChallengeID] = challenge
+	}
+	challenge.RoundResponded = observed.RoundResponded
+
+	status := Open
+	switch {
+	case observed.Responded == respondedExpired:
+		status = Expired
+	case observed.Responded != 0 && observed.Passed:
+		status = Passed
+	case observed.Responded != 0:
+		status = Failed
+	case tr.completionRounds > 0 && round-observed.RoundCreatedAt > tr.completionRounds:
+		status = Expired
+	}
+	if status != challenge.Status {
+		challenge.Status = status
+		challenge.Transitions = append(challenge.Transitions, Transition{Status: status, Round: round})
+	}
+}
+
+// Challenges returns tracked challenges in the order they were created
+func (tr *Tracker) Challenges() []*Challenge {
+	result := make([]*Challenge, 0, len(tr.challenges))
+	for _, challenge := range tr.challenges {
+		result = append(result, challenge)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RoundCreatedAt != result[j].RoundCreatedAt {
+			return result[i].RoundCreatedAt < result[j].RoundCreatedAt
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// Stats returns statistics of tracked challenges by blobber
+func (tr *Tracker) Stats() map[string]BlobberStats {
+	stats := make(map[string]BlobberStats)
+	latencies := make(map[string][]int64)
+	for _, challenge := range tr.challenges {
+		s := stats[challenge.BlobberID]
+		s.Total++
+		switch challenge.Status {
+		case Open:
+			s.Open++
+		case Passed:
+			s.Passed++
+		case Failed:
+			s.Failed++
+		case Expired:
+			s.Expired++
+		}
+		if challenge.Status == Passed || challenge.Status == Failed {
+			latencies[challenge.BlobberID] = append(latencies[challenge.BlobberID], challenge.Latency())
+		}
+		stats[challenge.BlobberID] = s
+	}
+
+	for blobberID, values := range latencies {
+		s := stats[blobberID]
+		s.Latency = latencyStats(values)
+		stats[blobberID] = s
+	}
+	return stats
+}
+
+// Wait polls challenges until done reports the statistics are sufficient
+func (tr *Tracker) Wait(t *test.SystemTest, config wait.Config, done func(map[string]BlobberStats) bool) (map[string]BlobberStats, error) {
+	if config.Description == "" {
+		config.Description = fmt.Sprintf("challenges of %d allocations", len(tr.allocations))
+	}
+	return wait.Poll(context.Background(), t, config, func(context.Context) (map[string]BlobberStats, bool, error) {
+		if err := tr.Poll(t); err != nil {
+			return nil, false, err
+		}
+		stats := tr.Stats()
+		return stats, done(stats), nil
+	})
+}
+
+// Backlog returns open challenges of the blobber in all allocations, not only in the tracked ones
+func (tr *Tracker) Backlog(t *test.SystemTest, blobberID string) (int, error) {
+	response, _, err := tr.client.V1SCRestOpenChallenge(t, model.SCRestOpenChallengeRequest{BlobberID: blobberID}, client.HttpOkStatus)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, client.ErrGetFromResource
+	}
+	return len(response.Challenges), nil
+}
+
+// Count counts challenges selected by query in the event database of sharders
+func (tr *Tracker) Count(t *test.SystemTest, query Query) (Counts, error) {
+	condition, err := query.Build()
+	if err != nil {
+		return Counts{}, err
+	}
+	counts, _, err := tr.client.V1QueryChallengesCount(t, model.QueryRequest{Query: condition}, client.HttpOkStatus)
+	if err != nil {
+		return Counts{}, err
+	}
+	return countsFromMap(counts), nil
+}
+
+// latencyStats returns nearest rank percentiles of the latencies
+func latencyStats(values []int64) LatencyStats {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+	percentile := func(p int) int64 {
+		rank := (p*len(values) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		return values[rank-1]
+	}
+	return LatencyStats{
+		Min: values[0],
+		P50: percentile(50),
+		P90: percentile(90),
+		Max: values[len(values)-1],
+	}
+}
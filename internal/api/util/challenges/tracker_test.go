@@ -0,0 +1,138 @@
+package challenges_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/challenges"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	allocationID      = strings.Repeat("a", 64)
+	otherAllocationID = strings.Repeat("b", 64)
+)
+
+func TestTracker(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	network := mocknet.NewDefault()
+	defer network.Close()
+	blobber1, blobber2 := network.Blobbers[0].ID, network.Blobbers[1].ID
+
+	network.Update(func(s *mocknet.State) {
+		s.Round = 100
+		s.Challenges[allocationID] = []*model.Challenge{
+			{ChallengeID: "c1", AllocationID: allocationID, BlobberID: blobber1, RoundCreatedAt: 90},
+			{ChallengeID: "c2", AllocationID: allocationID, BlobberID: blobber1, RoundCreatedAt: 95},
+			{ChallengeID: "c3", AllocationID: allocationID, BlobberID: blobber2, RoundCreatedAt: 60},
+		}
+		s.Challenges[otherAllocationID] = []*model.Challenge{
+			{ChallengeID: "c4", AllocationID: otherAllocationID, BlobberID: blobber1, RoundCreatedAt: 99},
+		}
+	})
+
+	tracker := challenges.NewTracker(client.NewAPIClient(network.URL()), 30, allocationID)
+	require.NoError(t, tracker.Poll(t))
+	require.EqualValues(t, 100, tracker.Round())
+
+	tracked := tracker.Challenges()
+	require.Len(t, tracked, 3, "challenges of allocations which are not tracked should be ignored")
+	require.Equal(t, []string{"c3", "c1", "c2"}, []string{tracked[0].ID, tracked[1].ID, tracked[2].ID})
+	require.Equal(t, challenges.Expired, tracked[0].Status, "challenge open for more than completion rounds should expire")
+	require.Equal(t, challenges.Open, tracked[1].Status)
+
+	network.Update(func(s *mocknet.State) {
+		s.Challenges[allocationID][0].Responded = 1
+		s.Challenges[allocationID][0].Passed = true
+		s.Challenges[allocationID][0].RoundResponded = 93
+	})
+	require.NoError(t, tracker.Poll(t))
+	require.Equal(t, challenges.Open, tracker.Challenges()[1].Status, "challenges should not be read again in the same round")
+
+	network.Update(func(s *mocknet.State) {
+		s.Round = 101
+		s.Challenges[allocationID][1].Responded = 1
+		s.Challenges[allocationID][1].RoundResponded = 101
+	})
+	require.NoError(t, tracker.Poll(t))
+
+	tracked = tracker.Challenges()
+	require.Equal(t, []challenges.Transition{
+		{Status: challenges.Open, Round: 100},
+		{Status: challenges.Passed, Round: 101},
+	}, tracked[1].Transitions)
+	require.EqualValues(t, 3, tracked[1].Latency())
+	require.Equal(t, challenges.Failed, tracked[2].Status)
+	require.EqualValues(t, 6, tracked[2].Latency())
+
+	stats := tracker.Stats()
+	require.Equal(t, challenges.BlobberStats{
+		Total:   2,
+		Passed:  1,
+		Failed:  1,
+		Latency: challenges.LatencyStats{Min: 3, P50: 3, P90: 6, Max: 6},
+	}, stats[blobber1])
+	require.Equal(t, challenges.BlobberStats{Total: 1, Expired: 1}, stats[blobber2])
+	require.Equal(t, 0.5, stats[blobber1].PassRate())
+	require.Zero(t, stats[blobber2].PassRate())
+
+	t.RunSequentially("Backlog should count open challenges of all allocations", func(t *test.SystemTest) {
+		backlog, err := tracker.Backlog(t, blobber1)
+		require.NoError(t, err)
+		require.Equal(t, 1, backlog)
+	})
+
+	t.RunSequentially("Count should select challenges by query", func(t *test.SystemTest) {
+		counts, err := tracker.Count(t, challenges.Query{BlobberID: blobber1, FromRound: 95})
+		require.NoError(t, err)
+		require.Equal(t, challenges.Counts{Total: 2, Failed: 1, Open: 1}, counts)
+
+		counts, err = tracker.Count(t, challenges.Query{AllocationID: allocationID, ToRound: 95})
+		require.NoError(t, err)
+		require.Equal(t, challenges.Counts{Total: 2, Passed: 1, Open: 1}, counts)
+	})
+
+	t.RunSequentially("Wait should poll until challenges are finished", func(t *test.SystemTest) {
+		tracker := challenges.NewTracker(client.NewAPIClient(network.URL()), 0, otherAllocationID)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			network.Update(func(s *mocknet.State) {
+				s.Round = 102
+				s.Challenges[otherAllocationID][0].Responded = 1
+				s.Challenges[otherAllocationID][0].Passed = true
+				s.Challenges[otherAllocationID][0].RoundResponded = 102
+			})
+		}()
+
+		stats, err := tracker.Wait(t, wait.Config{Timeout: 5 * time.Second, Backoff: wait.Constant(10 * time.Millisecond)},
+			func(stats map[string]challenges.BlobberStats) bool {
+				return stats[blobber1].Finished() == 1
+			})
+		require.NoError(t, err)
+		require.Equal(t, 1, stats[blobber1].Passed)
+	})
+}
+
+func TestQuery(t *testing.T) {
+	query, err := challenges.Query{AllocationID: allocationID, BlobberID: otherAllocationID, FromRound: 10, ToRound: 20}.Build()
+	require.NoError(t, err)
+	require.Equal(t, "allocation_id = '"+allocationID+"' AND blobber_id = '"+otherAllocationID+
+		"' AND round_created_at >= 10 AND round_created_at < 20", query)
+
+	query, err = challenges.Query{}.Build()
+	require.NoError(t, err)
+	require.Empty(t, query)
+
+	_, err = challenges.Query{BlobberID: strings.Repeat("a", 63) + "'"}.Build()
+	require.ErrorIs(t, err, challenges.ErrInvalidID)
+
+	_, err = challenges.Query{AllocationID: "abc"}.Build()
+	require.ErrorIs(t, err, challenges.ErrInvalidID)
+}
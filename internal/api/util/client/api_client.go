@@ -1,15 +1,17 @@
 package client
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
@@ -98,11 +100,27 @@ var (
 type APIClient struct {
 	BaseHttpClient
 	model.HealthyServiceProviders
+
+	// NodeTimeout limits every single request to a miner, sharder or blobber, zero means no limit
+	NodeTimeout time.Duration
+
+	// Quorum is the number of responses with the required status code after which
+	// the rest of requests are cancelled, zero means waiting for every service provider
+	Quorum int
+
+	// mu guards HealthyServiceProviders, which are updated by HealthMonitor
+	mu sync.RWMutex
+
+	// knownServiceProviders contains every node of the network, including unhealthy ones
+	knownServiceProviders model.HealthyServiceProviders
+
+	nonces *NonceManager
 }
 
 func NewAPIClient(networkEntrypoint string) *APIClient {
 	apiClient := &APIClient{}
 	apiClient.HttpClient = resty.New()
+	apiClient.nonces = newNonceManager(apiClient)
 
 	if err := apiClient.selectHealthyServiceProviders(networkEntrypoint); err != nil {
 		log.Fatalln(err)
@@ -114,43 +132,51 @@ func NewAPIClient(networkEntrypoint string) *APIClient {
 func (c *APIClient) getHealthyNodes(nodes []string, serviceProviderType int) ([]string, error) {
 	var result []string
 	for _, node := range nodes {
-		urlBuilder := NewURLBuilder()
-		if err := urlBuilder.MustShiftParse(node); err != nil {
-			return nil, err
-		}
-
-		r := c.HttpClient.R()
-		var formattedURL string
-		switch serviceProviderType {
-		case MinerServiceProvider:
-			formattedURL = urlBuilder.SetPath(ChainGetStats).String()
-		case SharderServiceProvider:
-			formattedURL = urlBuilder.SetPath(ChainGetStats).String()
-		case BlobberServiceProvider:
-			formattedURL = urlBuilder.SetPath(BlobberGetStats).String()
-			// /_stats requires username-password as it is an admin API.
-			r.SetBasicAuth("admin", "password")
-		}
-
-		healthResponse, err := r.Get(formattedURL)
-		if err == nil && healthResponse.IsSuccess() {
+		err := c.probeNode(context.Background(), node, serviceProviderType)
+		if err == nil {
 			log.Printf("%s is UP!", node)
 			result = append(result, node)
 			continue
 		}
-
-		status := healthResponse.StatusCode()
-		response := healthResponse.Body()
-		if err != nil {
-			log.Printf("Read error %s for blobber %s.", err.Error(), node)
-			continue
+		if errors.Is(err, ErrInvalidNodeURL) {
+			return nil, err
 		}
 
-		log.Printf("%s is DOWN! Status: %d, Message: %s", node, status, string(response))
+		log.Printf("%s is DOWN! %v", node, err)
 	}
 	return result, nil
 }
 
+// probeNode returns nil when the node answers its health check endpoint successfully
+func (c *APIClient) probeNode(ctx context.Context, node string, serviceProviderType int) error {
+	urlBuilder := NewURLBuilder()
+	if err := urlBuilder.MustShiftParse(node); err != nil {
+		return errors.Join(ErrInvalidNodeURL, err)
+	}
+
+	r := c.HttpClient.R().SetContext(ctx)
+	var formattedURL string
+	switch serviceProviderType {
+	case MinerServiceProvider:
+		formattedURL = urlBuilder.SetPath(ChainGetStats).String()
+	case SharderServiceProvider:
+		formattedURL = urlBuilder.SetPath(ChainGetStats).String()
+	case BlobberServiceProvider:
+		formattedURL = urlBuilder.SetPath(BlobberGetStats).String()
+		// /_stats requires username-password as it is an admin API.
+		r.SetBasicAuth("admin", "password")
+	}
+
+	healthResponse, err := r.Get(formattedURL)
+	if err != nil {
+		return fmt.Errorf("read error: %w", err)
+	}
+	if !healthResponse.IsSuccess() {
+		return fmt.Errorf("status: %d, message: %s", healthResponse.StatusCode(), string(healthResponse.Body()))
+	}
+	return nil
+}
+
 func (c *APIClient) getHealthyMiners(miners []string) ([]string, error) {
 	return c.getHealthyNodes(miners, MinerServiceProvider)
 }
@@ -240,51 +266,185 @@ func (c *APIClient) selectHealthyServiceProviders(networkEntrypoint string) erro
 	}
 
 	c.HealthyServiceProviders.Blobbers = healthyBlobbers
+	c.knownServiceProviders = *networkServiceProviders
+
+	return nil
+}
 
+// serviceProviders returns currently healthy nodes of the given type, safe to call while HealthMonitor is running
+func (c *APIClient) serviceProviders(serviceProviderType int) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch serviceProviderType {
+	case MinerServiceProvider:
+		return c.HealthyServiceProviders.Miners
+	case SharderServiceProvider:
+		return c.HealthyServiceProviders.Sharders
+	case BlobberServiceProvider:
+		return c.HealthyServiceProviders.Blobbers
+	}
 	return nil
 }
 
+// HealthyNodes returns a copy of currently healthy nodes of the given type.
+// It must be used instead of HealthyServiceProviders fields while HealthMonitor is running.
+func (c *APIClient) HealthyNodes(serviceProviderType int) []string {
+	return append([]string(nil), c.serviceProviders(serviceProviderType)...)
+}
+
+func (c *APIClient) setServiceProviders(serviceProviderType int, nodes []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch serviceProviderType {
+	case MinerServiceProvider:
+		c.HealthyServiceProviders.Miners = nodes
+	case SharderServiceProvider:
+		c.HealthyServiceProviders.Sharders = nodes
+	case BlobberServiceProvider:
+		c.HealthyServiceProviders.Blobbers = nodes
+	}
+}
+
+// executeForGivenServiceProviders sends the request to every given service provider concurrently.
+// Each request is limited by NodeTimeout, and the rest of requests are cancelled as soon as
+// Quorum responses with the required status code have arrived.
 func (c *APIClient) executeForGivenServiceProviders(
+	ctx context.Context,
 	t *test.SystemTest,
 	urlBuilder *URLBuilder,
 	executionRequest *model.ExecutionRequest,
 	method int,
 	serviceProviders []string,
 ) (*resty.Response, error) {
-	var (
-		resp                                *resty.Response
-		respErrors                          []error
-		expectedExecutionResponseCounter    int
-		notExpectedExecutionResponseCounter int
-	)
+	result, err := c.executeWithConsensus(ctx, t, urlBuilder, executionRequest, method, serviceProviders)
+	if err != nil {
+		return nil, err
+	}
 
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	err = result.Err()
+	if !result.Reached() {
+		return nil, err
+	}
+
+	response := result.Response()
+	if response == nil {
+		return nil, err
+	}
+	return response.resp, err
+}
+
+// executeWithConsensus works like executeForGivenServiceProviders, but returns response of every node.
+// ExecutionRequest.Dst is set from the first agreeing node.
+func (c *APIClient) executeWithConsensus(
+	ctx context.Context,
+	t *test.SystemTest,
+	urlBuilder *URLBuilder,
+	executionRequest *model.ExecutionRequest,
+	method int,
+	serviceProviders []string,
+) (*ConsensusResult, error) {
+	return c.executeForEachServiceProvider(ctx, t, urlBuilder, executionRequest, method, serviceProviders, c.Quorum)
+}
+
+// executeForEachServiceProvider sends the request to every given service provider concurrently,
+// zero quorum waits for every one of them
+func (c *APIClient) executeForEachServiceProvider(
+	ctx context.Context,
+	t *test.SystemTest,
+	urlBuilder *URLBuilder,
+	executionRequest *model.ExecutionRequest,
+	method int,
+	serviceProviders []string,
+	quorum int,
+) (*ConsensusResult, error) {
+	formattedURLs := make([]string, 0, len(serviceProviders))
 	for _, serviceProvider := range serviceProviders {
 		if err := urlBuilder.MustShiftParse(serviceProvider); err != nil {
 			return nil, err
 		}
-		formattedURL := urlBuilder.String()
+		formattedURLs = append(formattedURLs, urlBuilder.String())
+	}
 
-		newResp, err := c.executeForServiceProvider(t, formattedURL, *executionRequest, method)
-		if err != nil {
-			respErrors = append(respErrors, err)
-			continue
-		}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make(chan *NodeResponse, len(serviceProviders))
+	for i := range serviceProviders {
+		go func(serviceProvider, formattedURL string) {
+			nodeCtx := ctx
+			if c.NodeTimeout > 0 {
+				var nodeCancel context.CancelFunc
+				nodeCtx, nodeCancel = context.WithTimeout(ctx, c.NodeTimeout)
+				defer nodeCancel()
+			}
 
-		if newResp.StatusCode() == executionRequest.RequiredStatusCode {
-			expectedExecutionResponseCounter++
-			resp = newResp
-		} else {
-			t.Logf("Miner %s. Response: %s", serviceProvider, string(newResp.Body()))
-			respErrors = append(respErrors, errors.New(fmt.Sprintf("Miner %s. Response: %s", serviceProvider, string(newResp.Body()))))
-			notExpectedExecutionResponseCounter++
+			// every node decodes into its own destination, so responses do not race
+			nodeRequest := *executionRequest
+			nodeRequest.Dst = newDst(executionRequest.Dst)
+
+			start := time.Now()
+			resp, err := c.executeForServiceProviderWithContext(nodeCtx, t, formattedURL, nodeRequest, method)
+
+			response := &NodeResponse{
+				URL:     serviceProvider,
+				Latency: time.Since(start),
+				Decoded: nodeRequest.Dst,
+				Err:     err,
+				resp:    resp,
+			}
+			if resp != nil {
+				response.StatusCode = resp.StatusCode()
+				response.Body = resp.Body()
+			}
+			responses <- response
+		}(serviceProviders[i], formattedURLs[i])
+	}
+
+	result := &ConsensusResult{RequiredStatusCode: executionRequest.RequiredStatusCode}
+	var expected int
+
+	// all responses are drained, so no request outlives the test which started it
+	for range serviceProviders {
+		response := <-responses
+
+		switch {
+		case errors.Is(response.Err, context.Canceled):
+			continue
+		case result.expected(response):
+			expected++
+			if quorum > 0 && expected >= quorum {
+				cancel()
+			}
+		case response.Err == nil:
+			t.Logf("Node %s. Response: %s", response.URL, string(response.Body))
 		}
+		result.Responses = append(result.Responses, response)
 	}
 
-	if notExpectedExecutionResponseCounter > expectedExecutionResponseCounter {
-		return nil, errors.Join(ErrExecutionConsensus, selectMostFrequentError(respErrors))
+	var dst interface{}
+	if response := result.Response(); response != nil {
+		dst = response.Decoded
+	} else if len(result.Responses) > 0 {
+		dst = result.Responses[len(result.Responses)-1].Decoded
+	}
+	if dst != nil {
+		reflect.ValueOf(executionRequest.Dst).Elem().Set(reflect.ValueOf(dst).Elem())
 	}
 
-	return resp, selectMostFrequentError(respErrors)
+	return result, nil
+}
+
+func newDst(dst interface{}) interface{} {
+	if dst == nil {
+		return nil
+	}
+	return reflect.New(reflect.TypeOf(dst).Elem()).Interface()
 }
 
 func (c *APIClient) executeForAllServiceProviders(
@@ -294,34 +454,31 @@ func (c *APIClient) executeForAllServiceProviders(
 	method,
 	serviceProviderType int,
 ) (*resty.Response, error) {
-	var serviceProviders []string
-
-	switch serviceProviderType {
-	case MinerServiceProvider:
-		serviceProviders = c.HealthyServiceProviders.Miners
-	case SharderServiceProvider:
-		serviceProviders = c.HealthyServiceProviders.Sharders
-	case BlobberServiceProvider:
-		serviceProviders = c.HealthyServiceProviders.Blobbers
-	}
-
-	return c.executeForGivenServiceProviders(t, urlBuilder, executionRequest, method, serviceProviders)
+	return c.executeForAllServiceProvidersWithContext(context.Background(), t, urlBuilder, executionRequest, method, serviceProviderType)
 }
 
-func selectMostFrequentError(respErrors []error) error {
-	frequencyCounters := make(map[error]int)
-	var maxMatch int
-	var result error
-
-	for _, error := range respErrors {
-		frequencyCounters[error]++
-		if frequencyCounters[error] > maxMatch {
-			maxMatch = frequencyCounters[error]
-			result = error
+// executeForAllServiceProvidersWithContext works like executeForAllServiceProviders, requests are cancelled with ctx
+func (c *APIClient) executeForAllServiceProvidersWithContext(
+	ctx context.Context,
+	t *test.SystemTest,
+	urlBuilder *URLBuilder,
+	executionRequest *model.ExecutionRequest,
+	method,
+	serviceProviderType int,
+) (*resty.Response, error) {
+	serviceProviders := c.serviceProviders(serviceProviderType)
+	if len(serviceProviders) == 0 {
+		switch serviceProviderType {
+		case MinerServiceProvider:
+			return nil, ErrNoMinersHealthy
+		case SharderServiceProvider:
+			return nil, ErrNoShadersHealthy
+		case BlobberServiceProvider:
+			return nil, ErrNoBlobbersHealthy
 		}
 	}
 
-	return result
+	return c.executeForGivenServiceProviders(ctx, t, urlBuilder, executionRequest, method, serviceProviders)
 }
 
 func (c *APIClient) V1ClientPut(t *test.SystemTest, clientPutRequest model.Wallet, requiredStatusCode int) (*model.Wallet, *resty.Response, error) { //nolint
@@ -360,110 +517,25 @@ func (c *APIClient) V1TransactionPutWithNonceAndServiceProviders(
 	internalTransactionPutRequest model.InternalTransactionPutRequest,
 	requiredStatusCode, withNonce int, withProviders []string, options ...float64,
 ) (*model.TransactionPutResponse, *resty.Response, error) { //nolint
-	var (
-		transactionPutResponse *model.TransactionPutResponse
-		resp                   *resty.Response
-		err                    error
-	)
-
-	for retry := 0; retry < 3; retry++ {
-		var data []byte
-		data, err = json.Marshal(internalTransactionPutRequest.TransactionData)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		transactionPutRequest := model.TransactionPutRequest{
-			ClientId:         internalTransactionPutRequest.Wallet.Id,
-			PublicKey:        internalTransactionPutRequest.Wallet.PublicKey,
-			ToClientId:       internalTransactionPutRequest.ToClientID,
-			TransactionNonce: internalTransactionPutRequest.Wallet.Nonce + 1,
-			TxnOutputHash:    TxOutput,
-			TransactionValue: *TxValue,
-			TransactionType:  internalTransactionPutRequest.TxnType,
-			TransactionFee:   int64(TxFee),
-			TransactionData:  string(data),
-			CreationDate:     time.Now().Unix(),
-			Version:          TxVersion,
-		}
-
-		if withNonce != 0 {
-			transactionPutRequest.TransactionNonce = withNonce
-		}
-
-		if len(options) == 0 {
-			if internalTransactionPutRequest.TransactionData.Name == "pour" {
-				transactionPutRequest.TransactionFee = 0
-			} else {
-				fee := estimateTxnFee(t, c, &transactionPutRequest)
-				transactionPutRequest.TransactionFee = fee
-			}
-		} else {
-			transactionPutRequest.TransactionFee = int64(options[0] * 1e10)
-		}
-
-		if internalTransactionPutRequest.Value != nil {
-			transactionPutRequest.TransactionValue = *internalTransactionPutRequest.Value
-		}
-
-		transactionPutRequest.Hash = crypto.Sha3256([]byte(fmt.Sprintf("%d:%d:%s:%s:%d:%s",
-			transactionPutRequest.CreationDate,
-			transactionPutRequest.TransactionNonce,
-			transactionPutRequest.ClientId,
-			transactionPutRequest.ToClientId,
-			transactionPutRequest.TransactionValue,
-			crypto.Sha3256([]byte(transactionPutRequest.TransactionData)))))
-
-		crypto.SignTransaction(t, &transactionPutRequest, internalTransactionPutRequest.Wallet.Keys)
-
-		serviceProviders := c.HealthyServiceProviders.Miners
-		if withProviders != nil {
-			serviceProviders = withProviders
-		}
-
-		resp, err = c.executeForGivenServiceProviders(
-			t,
-			NewURLBuilder().SetPath(TransactionPut),
-			&model.ExecutionRequest{
-				Body:               transactionPutRequest,
-				Dst:                &transactionPutResponse,
-				RequiredStatusCode: requiredStatusCode,
-			},
-			HttpPOSTMethod,
-			serviceProviders)
-
-		transactionPutResponse.Request = transactionPutRequest
-
-		if err != nil && strings.Contains(err.Error(), "invalid transaction nonce") {
-			c.RefreshNonce(t, internalTransactionPutRequest.Wallet, 200)
-			continue
-		}
-
-		break
+	transaction := c.NewTransaction(internalTransactionPutRequest.Wallet).
+		Nonce(withNonce).
+		Miners(withProviders...)
+
+	if internalTransactionPutRequest.TxnType == SCTxType {
+		transaction.SmartContract(internalTransactionPutRequest.ToClientID, internalTransactionPutRequest.TransactionData)
+	} else {
+		transaction.To(internalTransactionPutRequest.ToClientID)
 	}
-	return transactionPutResponse, resp, err
-}
 
-func estimateTxnFee(t *test.SystemTest, c *APIClient, transactionPutRequest *model.TransactionPutRequest) int64 {
-	urlBuilder := NewURLBuilder().SetPath(TransactionFeeGet)
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Body:               transactionPutRequest,
-			RequiredStatusCode: 200,
-		},
-		HttpPOSTMethod,
-		MinerServiceProvider)
+	if internalTransactionPutRequest.Value != nil {
+		transaction.Value(*internalTransactionPutRequest.Value)
+	}
 
-	var fee = struct {
-		Fee int64 `json:"fee"`
-	}{}
-	require.Nil(t, err)
+	if len(options) > 0 {
+		transaction.Fee(FixedFee(int64(options[0] * 1e10)))
+	}
 
-	err = json.Unmarshal(resp.Body(), &fee)
-	require.NoError(t, err)
-	return fee.Fee
+	return transaction.Submit(t, requiredStatusCode)
 }
 
 func (c *APIClient) V1TransactionGetConfirmation(
@@ -471,14 +543,25 @@ func (c *APIClient) V1TransactionGetConfirmation(
 	transactionGetConfirmationRequest model.TransactionGetConfirmationRequest,
 	requiredStatusCode int,
 ) (*model.TransactionGetConfirmationResponse, *resty.Response, error) { //nolint
+	return Call(c, t, TransactionGetConfirmationEndpoint, transactionGetConfirmationRequest, requiredStatusCode)
+}
 
+// V1TransactionGetConfirmationWithConsensus returns transaction confirmation together with the report
+// of every sharder, so a sharder disagreeing with the rest of the network can be identified
+func (c *APIClient) V1TransactionGetConfirmationWithConsensus(
+	t *test.SystemTest,
+	transactionGetConfirmationRequest model.TransactionGetConfirmationRequest,
+	requiredStatusCode int,
+) (*model.TransactionGetConfirmationResponse, *ConsensusResult, error) { //nolint
 	var transactionGetConfirmationResponse *model.TransactionGetConfirmationResponse
 
-	urlBuilder := NewURLBuilder().
-		SetPath(TransactionGetConfirmation).
-		AddParams("hash", transactionGetConfirmationRequest.Hash)
+	urlBuilder, _, err := TransactionGetConfirmationEndpoint.NewURLBuilder(transactionGetConfirmationRequest)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	resp, err := c.executeForAllServiceProviders(
+	result, err := c.executeWithConsensus(
+		context.Background(),
 		t,
 		urlBuilder,
 		&model.ExecutionRequest{
@@ -486,17 +569,30 @@ func (c *APIClient) V1TransactionGetConfirmation(
 			RequiredStatusCode: requiredStatusCode,
 		},
 		HttpGETMethod,
-		SharderServiceProvider)
+		c.serviceProviders(SharderServiceProvider))
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return transactionGetConfirmationResponse, resp, err
+	return transactionGetConfirmationResponse, result, result.Err()
 }
 
 func (c *APIClient) V1ClientGetBalance(t *test.SystemTest, clientGetBalanceRequest model.ClientGetBalanceRequest, requiredStatusCode int) (*model.ClientGetBalanceResponse, *resty.Response, error) { //nolint
+	return Call(c, t, ClientGetBalanceEndpoint, clientGetBalanceRequest, requiredStatusCode)
+}
+
+// V1ClientGetBalanceWithConsensus returns balance together with the report of every sharder,
+// so a sharder disagreeing with the rest of the network can be identified
+func (c *APIClient) V1ClientGetBalanceWithConsensus(t *test.SystemTest, clientGetBalanceRequest model.ClientGetBalanceRequest, requiredStatusCode int) (*model.ClientGetBalanceResponse, *ConsensusResult, error) { //nolint
 	var clientGetBalanceResponse *model.ClientGetBalanceResponse
 
-	urlBuilder := NewURLBuilder().SetPath(ClientGetBalance).AddParams("client_id", clientGetBalanceRequest.ClientID)
+	urlBuilder, _, err := ClientGetBalanceEndpoint.NewURLBuilder(clientGetBalanceRequest)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	resp, err := c.executeForAllServiceProviders(
+	result, err := c.executeWithConsensus(
+		context.Background(),
 		t,
 		urlBuilder,
 		&model.ExecutionRequest{
@@ -504,135 +600,52 @@ func (c *APIClient) V1ClientGetBalance(t *test.SystemTest, clientGetBalanceReque
 			RequiredStatusCode: requiredStatusCode,
 		},
 		HttpGETMethod,
-		SharderServiceProvider)
+		c.serviceProviders(SharderServiceProvider))
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return clientGetBalanceResponse, resp, err
+	return clientGetBalanceResponse, result, result.Err()
 }
 
 func (c *APIClient) V1SCRestGetAllMiners(t *test.SystemTest, requiredStatusCode int) ([]*model.SCRestGetMinerSharderResponse, *resty.Response, error) {
-	var scRestGetMinersResponse *model.SCRestGetMinersShardersResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(GetMiners).
-		SetPathVariable("sc_address", MinerSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetMinersResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider,
-	)
+	scRestGetMinersResponse, resp, err := Call(c, t, SCRestGetMinersEndpoint, NoRequest{}, requiredStatusCode)
+	if scRestGetMinersResponse == nil {
+		return nil, resp, err
+	}
 	return scRestGetMinersResponse.Nodes, resp, err
 }
 
 func (c *APIClient) V1SCRestGetAllSharders(t *test.SystemTest, requiredStatusCode int) ([]*model.SCRestGetMinerSharderResponse, *resty.Response, error) {
-	var scRestGetShardersResponse *model.SCRestGetMinersShardersResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(GetSharders).
-		SetPathVariable("sc_address", MinerSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetShardersResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider,
-	)
+	scRestGetShardersResponse, resp, err := Call(c, t, SCRestGetShardersEndpoint, NoRequest{}, requiredStatusCode)
+	if scRestGetShardersResponse == nil {
+		return nil, resp, err
+	}
 	return scRestGetShardersResponse.Nodes, resp, err
 }
 
 func (c *APIClient) V1SCRestGetAllBlobbers(t *test.SystemTest, requiredStatusCode int) ([]*model.SCRestGetBlobberResponse, *resty.Response, error) {
-	var scRestGetBlobbersResponse *model.SCRestGetBlobbersResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(GetBlobbers).
-		SetPathVariable("sc_address", StorageSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetBlobbersResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider,
-	)
+	scRestGetBlobbersResponse, resp, err := Call(c, t, SCRestGetBlobbersEndpoint, model.SCRestGetBlobbersRequest{}, requiredStatusCode)
+	if scRestGetBlobbersResponse == nil {
+		return nil, resp, err
+	}
 	return scRestGetBlobbersResponse.Nodes, resp, err
 }
 
 func (c *APIClient) V1SCRestGetAllValidators(t *test.SystemTest, requiredStatusCode int) ([]*model.SCRestGetValidatorResponse, *resty.Response, error) {
-	var scRestGetValidatorsResponse []*model.SCRestGetValidatorResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(GetValidators).
-		SetPathVariable("sc_address", StorageSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetValidatorsResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider,
-	)
-	return scRestGetValidatorsResponse, resp, err
+	return Call(c, t, SCRestGetValidatorsEndpoint, NoRequest{}, requiredStatusCode)
 }
 
 func (c *APIClient) V1SCRestGetFirstBlobbers(t *test.SystemTest, blobbersCount, requiredStatusCode int) ([]*model.SCRestGetBlobberResponse, *resty.Response, error) {
-	var scRestGetBlobbersResponse *model.SCRestGetBlobbersResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(GetBlobbers).
-		SetPathVariable("sc_address", StorageSmartContractAddress).
-		AddParams("active", "true").
-		AddParams("limit", "10")
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetBlobbersResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider,
-	)
-	if len(scRestGetBlobbersResponse.Nodes) < blobbersCount {
+	scRestGetBlobbersResponse, resp, err := Call(c, t, SCRestGetBlobbersEndpoint, model.SCRestGetBlobbersRequest{Active: true, Limit: 10}, requiredStatusCode)
+	if scRestGetBlobbersResponse == nil || len(scRestGetBlobbersResponse.Nodes) < blobbersCount {
 		return nil, resp, errors.New("not enough blobbers")
 	}
 	return scRestGetBlobbersResponse.Nodes[:blobbersCount], resp, err
 }
 
 func (c *APIClient) V1SCRestGetBlobber(t *test.SystemTest, scRestGetBlobberRequest model.SCRestGetBlobberRequest, requiredStatusCode int) (*model.SCRestGetBlobberResponse, *resty.Response, error) {
-	var scRestGetBlobberResponse *model.SCRestGetBlobberResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(SCRestGetBlobbers).
-		SetPathVariable("sc_address", StorageSmartContractAddress).
-		AddParams("blobber_id", scRestGetBlobberRequest.BlobberID)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetBlobberResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return scRestGetBlobberResponse, resp, err
+	return Call(c, t, SCRestGetBlobberEndpoint, scRestGetBlobberRequest, requiredStatusCode)
 }
 
 func (c *APIClient) V1BlobberGetHashNodeRoot(t *test.SystemTest, blobberGetHashnodeRequest *model.BlobberGetHashnodeRequest, requiredStatusCode int) (*model.BlobberGetHashnodeResponse, *resty.Response, error) {
@@ -661,24 +674,7 @@ func (c *APIClient) V1BlobberGetHashNodeRoot(t *test.SystemTest, blobberGetHashn
 }
 
 func (c *APIClient) V1SCRestGetAllocation(t *test.SystemTest, scRestGetAllocationRequest model.SCRestGetAllocationRequest, requiredStatusCode int) (*model.SCRestGetAllocationResponse, *resty.Response, error) { //nolint
-	var scRestGetAllocationResponse *model.SCRestGetAllocationResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(SCRestGetAllocation).
-		SetPathVariable("sc_address", StorageSmartContractAddress).
-		AddParams("allocation", scRestGetAllocationRequest.AllocationID)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetAllocationResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return scRestGetAllocationResponse, resp, err
+	return Call(c, t, SCRestGetAllocationEndpoint, scRestGetAllocationRequest, requiredStatusCode)
 }
 
 func (c *APIClient) V1SCRestGetAllocationBlobbers(t *test.SystemTest, scRestGetAllocationBlobbersRequest *model.SCRestGetAllocationBlobbersRequest, requiredStatusCode int) (*model.SCRestGetAllocationBlobbersResponse, *resty.Response, error) { //nolint
@@ -744,85 +740,19 @@ func (c *APIClient) V1SCRestGetFreeAllocationBlobbers(t *test.SystemTest, scRest
 }
 
 func (c *APIClient) V1SCRestOpenChallenge(t *test.SystemTest, scRestOpenChallengeRequest model.SCRestOpenChallengeRequest, requiredStatusCode int) (*model.SCRestOpenChallengeResponse, *resty.Response, error) { //nolint
-	var scRestOpenChallengeResponse *model.SCRestOpenChallengeResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(SCRestGetOpenChallenges).
-		SetPathVariable("sc_address", StorageSmartContractAddress).
-		AddParams("blobber", scRestOpenChallengeRequest.BlobberID)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestOpenChallengeResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return scRestOpenChallengeResponse, resp, err
+	return Call(c, t, SCRestOpenChallengeEndpoint, scRestOpenChallengeRequest, requiredStatusCode)
 }
 
 func (c *APIClient) V1MinerGetStats(t *test.SystemTest, requiredStatusCode int) (*model.GetMinerStatsResponse, *resty.Response, error) { //nolint
-	var getMinerStatsResponse *model.GetMinerStatsResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(MinerGetStatus)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &getMinerStatsResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		MinerServiceProvider)
-
-	return getMinerStatsResponse, resp, err
+	return Call(c, t, MinerGetStatsEndpoint, NoRequest{}, requiredStatusCode)
 }
 
 func (c *APIClient) V1SharderGetStats(t *test.SystemTest, requiredStatusCode int) (*model.GetSharderStatsResponse, *resty.Response, error) { //nolint
-	var getSharderStatusResponse *model.GetSharderStatsResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(SharderGetStatus)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &getSharderStatusResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return getSharderStatusResponse, resp, err
+	return Call(c, t, SharderGetStatsEndpoint, NoRequest{}, requiredStatusCode)
 }
 
 func (c *APIClient) V1SharderGetSCState(t *test.SystemTest, scStateGetRequest model.SCStateGetRequest, requiredStatusCode int) (*model.SCStateGetResponse, *resty.Response, error) { //nolint
-	var scStateGetResponse *model.SCStateGetResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(SCStateGet)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			FormData: map[string]string{
-				"sc_address": scStateGetRequest.SCAddress,
-				"key":        scStateGetRequest.Key,
-			},
-			Dst:                &scStateGetResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpPOSTMethod,
-		SharderServiceProvider)
-
-	return scStateGetResponse, resp, err
+	return Call(c, t, SharderGetSCStateEndpoint, scStateGetRequest, requiredStatusCode)
 }
 
 func (c *APIClient) CreateWalletForMnemonic(t *test.SystemTest, mnemonic string) *model.Wallet {
@@ -839,16 +769,14 @@ func (c *APIClient) CreateWalletForMnemonic(t *test.SystemTest, mnemonic string)
 }
 
 func (c *APIClient) CreateWalletForMnemonicWithoutAssertion(t *test.SystemTest, mnemonic string) (*model.Wallet, error) {
-	keyPair := crypto.GenerateKeys(t, mnemonic)
-	publicKeyBytes, err := hex.DecodeString(keyPair.PublicKey.SerializeToHexStr())
+	clientId, keyPair, err := crypto.DefaultKeyStore.FromMnemonic(mnemonic)
 	if err != nil {
 		return nil, err
 	}
 
-	clientId := crypto.Sha3256(publicKeyBytes)
-	createdWallet := model.Wallet{Id: clientId, PublicKey: keyPair.PublicKey.SerializeToHexStr(), Keys: keyPair}
+	createdWallet := model.Wallet{Id: clientId, PublicKey: keyPair.PublicKey.SerializeToHexStr(), Keys: keyPair, Mnemonics: mnemonic}
 
-	return &createdWallet, err
+	return &createdWallet, nil
 }
 
 func (c *APIClient) CreateAllocation(t *test.SystemTest,
@@ -905,8 +833,6 @@ func (c *APIClient) CreateAllocationWithLockValue(t *test.SystemTest,
 		return createAllocationTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return createAllocationTransactionPutResponse.Entity.Hash
 }
 
@@ -982,8 +908,6 @@ func (c *APIClient) RegisterBlobber(t *test.SystemTest,
 		return false
 	})
 
-	wallet.IncNonce()
-
 	return registerBlobberTransactionPutResponse.Entity.Hash
 }
 
@@ -1035,7 +959,6 @@ func (c *APIClient) KillBlobber(t *test.SystemTest,
 		return killBlobberTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
 	return killBlobberTransactionPutResponse.Entity.Hash
 }
 
@@ -1084,8 +1007,6 @@ func (c *APIClient) CreateFreeAllocation(t *test.SystemTest,
 		return createAllocationTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return createAllocationTransactionPutResponse.Entity.Hash
 }
 
@@ -1137,7 +1058,6 @@ func (c *APIClient) UpdateAllocation(
 		return updateAllocationTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
 }
 
 func (c *APIClient) AddFreeStorageAssigner(
@@ -1189,7 +1109,6 @@ func (c *APIClient) AddFreeStorageAssigner(
 		return freeAllocationTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
 }
 
 func (c *APIClient) UpdateAllocationBlobbers(t *test.SystemTest, wallet *model.Wallet, newBlobberID, oldBlobberID, allocationID string, requiredTransactionStatus int) {
@@ -1238,7 +1157,6 @@ func (c *APIClient) UpdateAllocationBlobbers(t *test.SystemTest, wallet *model.W
 		return updateAllocationTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
 }
 
 func (c *APIClient) CancelAllocation(
@@ -1289,8 +1207,6 @@ func (c *APIClient) CancelAllocation(
 		return cancelAllocationTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return cancelAllocationTransactionPutResponse.Request.Hash
 }
 
@@ -1394,7 +1310,7 @@ func (c *APIClient) GetWalletBalance(t *test.SystemTest, wallet *model.Wallet, r
 
 func (c *APIClient) RefreshNonce(t *test.SystemTest, wallet *model.Wallet, requiredStatusCode int) {
 	wBalance := c.GetWalletBalance(t, wallet, requiredStatusCode)
-	wallet.Nonce = int(wBalance.Nonce)
+	c.nonces.Update(wallet, int(wBalance.Nonce))
 }
 
 func (c *APIClient) GetRewardsByQuery(t *test.SystemTest, query string, requiredStatusCode int) *model.QueryRewardsResponse {
@@ -1538,7 +1454,6 @@ func (c *APIClient) UpdateBlobber(t *test.SystemTest, wallet *model.Wallet, scRe
 		return updateBlobberTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
 }
 
 // CreateStakePoolWrapper does not provide deep test of used components
@@ -1592,8 +1507,6 @@ func (c *APIClient) CreateStakePool(t *test.SystemTest, wallet *model.Wallet, pr
 		return createStakePoolTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return createStakePoolTransactionGetConfirmationResponse.Hash
 }
 
@@ -1642,8 +1555,6 @@ func (c *APIClient) UnlockStakePool(t *test.SystemTest, wallet *model.Wallet, pr
 		return unlockStakePoolTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return unlockStakePoolTransactionGetConfirmationResponse.Hash
 }
 
@@ -1693,8 +1604,6 @@ func (c *APIClient) CreateMinerStakePool(t *test.SystemTest, wallet *model.Walle
 		return createStakePoolTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return createStakePoolTransactionGetConfirmationResponse.Hash
 }
 
@@ -1743,8 +1652,6 @@ func (c *APIClient) UnlockMinerStakePool(t *test.SystemTest, wallet *model.Walle
 		return unlockStakePoolTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return unlockStakePoolTransactionGetConfirmationResponse.Hash
 }
 
@@ -1793,52 +1700,15 @@ func (c *APIClient) CreateWritePool(t *test.SystemTest, wallet *model.Wallet, al
 		return createWritePoolTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return createWritePoolTransactionGetConfirmationResponse.Hash
 }
 
 func (c *APIClient) V1SCRestGetStakePoolStat(t *test.SystemTest, scRestGetStakePoolStatRequest model.SCRestGetStakePoolStatRequest, requiredStatusCode int) (*model.SCRestGetStakePoolStatResponse, *resty.Response, error) { //nolint
-	var scRestGetStakePoolStatResponse *model.SCRestGetStakePoolStatResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(GetStakePoolStat).
-		SetPathVariable("sc_address", StorageSmartContractAddress).
-		AddParams("provider_id", scRestGetStakePoolStatRequest.ProviderID).
-		AddParams("provider_type", scRestGetStakePoolStatRequest.ProviderType)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetStakePoolStatResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return scRestGetStakePoolStatResponse, resp, err
+	return Call(c, t, SCRestGetStakePoolStatEndpoint, scRestGetStakePoolStatRequest, requiredStatusCode)
 }
 
 func (c *APIClient) V1SCRestGetUserStakePoolStat(t *test.SystemTest, scRestGetUserStakePoolStatRequest model.SCRestGetUserStakePoolStatRequest, requiredStatusCode int) (*model.SCRestGetUserStakePoolStatResponse, *resty.Response, error) { //nolint
-	var scRestGetUserStakePoolStatResponse *model.SCRestGetUserStakePoolStatResponse
-
-	urlBuilder := NewURLBuilder().
-		SetPath(getUserStakePoolStat).
-		SetPathVariable("sc_address", StorageSmartContractAddress).
-		AddParams("client_id", scRestGetUserStakePoolStatRequest.ClientId)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetUserStakePoolStatResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return scRestGetUserStakePoolStatResponse, resp, err
+	return Call(c, t, SCRestGetUserStakePoolStatEndpoint, scRestGetUserStakePoolStatRequest, requiredStatusCode)
 }
 
 func (c *APIClient) GetStakePoolStat(t *test.SystemTest, providerID, providerType string) *model.SCRestGetStakePoolStatResponse {
@@ -1896,8 +1766,6 @@ func (c *APIClient) CollectRewards(t *test.SystemTest, wallet *model.Wallet, pro
 		return collectRewardTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
-
 	return collectRewardTransactionGetConfirmationResponse, collectRewardTransactionGetConfirmationResponse.Transaction.TransactionFee
 }
 
@@ -1983,21 +1851,7 @@ func (c *APIClient) V1BlockGetLatestFinalizedMagicBlock(t *test.SystemTest, hash
 func (c *APIClient) V1BlockGetLatestFinalizedBlock(t *test.SystemTest, requiredStatusCode int) (*model.LatestFinalizedBlock, *resty.Response, error) {
 	t.Log("Get latest finalized block")
 
-	var latestFinalizedBlock *model.LatestFinalizedBlock
-
-	urlBuilder := NewURLBuilder().SetPath(GetLatestFinalizedBlock)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			RequiredStatusCode: requiredStatusCode,
-			Dst:                &latestFinalizedBlock,
-		},
-		HttpPOSTMethod,
-		SharderServiceProvider)
-
-	return latestFinalizedBlock, resp, err
+	return Call(c, t, LatestFinalizedBlockEndpoint, NoRequest{}, requiredStatusCode)
 }
 
 func (c *APIClient) GetLatestFinalizedBlock(t *test.SystemTest, requiredStatusCode int) *model.LatestFinalizedBlock {
@@ -2010,6 +1864,30 @@ func (c *APIClient) GetLatestFinalizedBlock(t *test.SystemTest, requiredStatusCo
 	return latestFinalizedBlock
 }
 
+// LatestFinalizedRound returns latest finalized round, it is used as source of round based waits
+func (c *APIClient) LatestFinalizedRound(t *test.SystemTest) func(ctx context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		latestFinalizedBlock, _, err := CallWithContext(ctx, c, t, LatestFinalizedBlockEndpoint, NoRequest{}, HttpOkStatus)
+		if err != nil {
+			return 0, err
+		}
+		if latestFinalizedBlock == nil {
+			return 0, ErrGetFromResource
+		}
+		return latestFinalizedBlock.Round, nil
+	}
+}
+
+// WaitForRound waits until the given round is finalized and returns the latest finalized round
+func (c *APIClient) WaitForRound(t *test.SystemTest, round int64, timeout time.Duration) (int64, error) {
+	return wait.UntilRound(context.Background(), t, wait.Config{Timeout: timeout}, round, c.LatestFinalizedRound(t))
+}
+
+// WaitForRounds waits until the given number of rounds is finalized after the current one
+func (c *APIClient) WaitForRounds(t *test.SystemTest, rounds int64, timeout time.Duration) (int64, error) {
+	return wait.ForRounds(context.Background(), t, wait.Config{Timeout: timeout}, rounds, c.LatestFinalizedRound(t))
+}
+
 func (c *APIClient) V1BlobberObjectTree(t *test.SystemTest, blobberObjectTreeRequest *model.BlobberObjectTreeRequest, requiredStatusCode int) (*model.BlobberObjectTreePathResponse, *resty.Response, error) {
 	var blobberObjectTreePathResponse *model.BlobberObjectTreePathResponse
 
@@ -2034,94 +1912,25 @@ func (c *APIClient) V1BlobberObjectTree(t *test.SystemTest, blobberObjectTreeReq
 }
 
 func (c *APIClient) V1QueryChallengesCount(t *test.SystemTest, queryRequest model.QueryRequest, requiredStatusCode int) (map[string]int64, *resty.Response, error) {
-	var queryResponse map[string]int64
-
-	urlBuilder := NewURLBuilder().SetPath(QueryChallengesCount).AddParams("query", url.QueryEscape(queryRequest.Query)).SetPathVariable("sc_address", StorageSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &queryResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return queryResponse, resp, err
+	return Call(c, t, QueryChallengesCountEndpoint, queryRequest, requiredStatusCode)
 }
 
 func (c *APIClient) V1QueryRewards(t *test.SystemTest, queryRewardsRequest model.QueryRequest, requiredStatusCode int) (*model.QueryRewardsResponse, *resty.Response, error) {
-	var queryRewardsResponse *model.QueryRewardsResponse
-
-	urlBuilder := NewURLBuilder().SetPath(QueryRewards).AddParams("query", url.QueryEscape(queryRewardsRequest.Query)).SetPathVariable("sc_address", StorageSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &queryRewardsResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return queryRewardsResponse, resp, err
+	return Call(c, t, QueryRewardsEndpoint, queryRewardsRequest, requiredStatusCode)
 }
 
 func (c *APIClient) V1QueryDelegateRewards(t *test.SystemTest, queryRewardsRequest model.QueryRequest, requiredStatusCode int) (map[string]int64, *resty.Response, error) {
-	var queryRewardsResponse map[string]int64
-
-	urlBuilder := NewURLBuilder().SetPath(QueryDelegateRewards).AddParams("query", url.QueryEscape(queryRewardsRequest.Query)).SetPathVariable("sc_address", StorageSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &queryRewardsResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return queryRewardsResponse, resp, err
+	return Call(c, t, QueryDelegateRewardsEndpoint, queryRewardsRequest, requiredStatusCode)
 }
 
 func (c *APIClient) V1BlobberPartitionSelectionFrequency(t *test.SystemTest, request model.BlockRewardsRequest, requiredStatusCode int) (map[string]int64, *resty.Response, error) {
-	var result map[string]int64
-
-	urlBuilder := NewURLBuilder().SetPath(BlobberPartitionSelectionFrequency).AddParams("start", strconv.FormatInt(request.Start, 10)).AddParams("end", strconv.FormatInt(request.End, 10)).SetPathVariable("sc_address", StorageSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &result,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return result, resp, err
+	return Call(c, t, BlobberPartitionSelectionFrequencyEndpoint, request, requiredStatusCode)
 }
 
 func (c *APIClient) V1PartitionSizeFrequency(t *test.SystemTest, request model.BlockRewardsRequest, requiredStatusCode int) (map[float64]float64, *resty.Response, error) {
-	var response map[string]int
+	response, resp, err := Call(c, t, PartitionSizeFrequencyEndpoint, request, requiredStatusCode)
 
 	result := make(map[float64]float64)
-
-	urlBuilder := NewURLBuilder().SetPath(PartitionSizeFrequency).AddParams("start", strconv.FormatInt(request.Start, 10)).AddParams("end", strconv.FormatInt(request.End, 10)).SetPathVariable("sc_address", StorageSmartContractAddress).SetPathVariable("sc_address", StorageSmartContractAddress)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &response,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
 	for size, frequency := range response {
 		sizeInFloat, _ := strconv.ParseFloat(size, 64)
 		result[sizeInFloat] = float64(frequency)
@@ -2131,24 +1940,7 @@ func (c *APIClient) V1PartitionSizeFrequency(t *test.SystemTest, request model.B
 }
 
 func (c *APIClient) V1SCRestGetAllChallengesForAllocation(t *test.SystemTest, allocationID string, requiredStatusCode int) ([]*model.Challenge, *resty.Response, error) { //nolint
-	var scRestGetAllChallengesForAllocationResponse []*model.Challenge
-
-	urlBuilder := NewURLBuilder().
-		SetPath(GetAllChallenges).
-		SetPathVariable("sc_address", StorageSmartContractAddress).
-		AddParams("allocation_id", allocationID)
-
-	resp, err := c.executeForAllServiceProviders(
-		t,
-		urlBuilder,
-		&model.ExecutionRequest{
-			Dst:                &scRestGetAllChallengesForAllocationResponse,
-			RequiredStatusCode: requiredStatusCode,
-		},
-		HttpGETMethod,
-		SharderServiceProvider)
-
-	return scRestGetAllChallengesForAllocationResponse, resp, err
+	return Call(c, t, SCRestGetAllChallengesEndpoint, model.GetAllChallengesForAllocationRequest{AllocationID: allocationID}, requiredStatusCode)
 }
 
 //----------------------------------------------------------
@@ -2159,7 +1951,7 @@ func (c *APIClient) BurnZcn(t *test.SystemTest, wallet *model.Wallet, address st
 	t.Log("Burn ZCN")
 
 	walletBalance := c.GetWalletBalance(t, wallet, HttpOkStatus)
-	wallet.Nonce = int(walletBalance.Nonce)
+	c.nonces.Update(wallet, int(walletBalance.Nonce))
 
 	burnZcnTransactionPutResponse, resp, err := c.V1TransactionPut(
 		t,
@@ -2201,6 +1993,5 @@ func (c *APIClient) BurnZcn(t *test.SystemTest, wallet *model.Wallet, address st
 		return burnZcnTransactionGetConfirmationResponse.Status == requiredTransactionStatus
 	})
 
-	wallet.IncNonce()
 	return burnZcnTransactionGetConfirmationResponse.Hash
 }
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	resty "github.com/go-resty/resty/v2"
+)
+
+// NodeResponse contains outcome of a request sent to a single service provider
+type NodeResponse struct {
+	URL        string
+	StatusCode int
+	Latency    time.Duration
+	Body       []byte
+
+	// Decoded contains body decoded into a value of the same type as ExecutionRequest.Dst
+	Decoded interface{}
+
+	// Err is set when the request itself or decoding of the body failed
+	Err error
+
+	resp *resty.Response
+}
+
+// NodeError describes a service provider which disagreed with the rest of the network
+type NodeError struct {
+	URL        string
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (e *NodeError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("Node %s. Error: %v", e.URL, e.Err)
+	}
+	return fmt.Sprintf("Node %s. Status: %d. Response: %s", e.URL, e.StatusCode, e.Body)
+}
+
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
+
+// ConsensusError lists service providers which agreed and disagreed on a request.
+// It matches ErrExecutionConsensus when most of the nodes did not respond with the required status code.
+type ConsensusError struct {
+	Reached     bool
+	Agreeing    []string
+	Disagreeing []*NodeError
+}
+
+func (e *ConsensusError) Error() string {
+	messages := make([]string, 0, len(e.Disagreeing)+1)
+	if !e.Reached {
+		messages = append(messages, ErrExecutionConsensus.Error())
+	}
+	for _, nodeErr := range e.Disagreeing {
+		messages = append(messages, nodeErr.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e *ConsensusError) Unwrap() []error {
+	result := make([]error, 0, len(e.Disagreeing)+1)
+	if !e.Reached {
+		result = append(result, ErrExecutionConsensus)
+	}
+	for _, nodeErr := range e.Disagreeing {
+		result = append(result, nodeErr)
+	}
+	return result
+}
+
+// ConsensusResult contains responses of every service provider a request was sent to,
+// in the order of their arrival
+type ConsensusResult struct {
+	RequiredStatusCode int
+	Responses          []*NodeResponse
+}
+
+func (r *ConsensusResult) expected(response *NodeResponse) bool {
+	return response.Err == nil && response.StatusCode == r.RequiredStatusCode
+}
+
+// majorityBody returns the most frequent body among responses with the required status code,
+// the earliest one wins ties
+func (r *ConsensusResult) majorityBody() string {
+	var (
+		order    []string
+		counters = make(map[string]int)
+	)
+	for _, response := range r.Responses {
+		if !r.expected(response) {
+			continue
+		}
+		body := normalizeBody(response.Body)
+		if _, ok := counters[body]; !ok {
+			order = append(order, body)
+		}
+		counters[body]++
+	}
+
+	var result string
+	for _, body := range order {
+		if counters[body] > counters[result] {
+			result = body
+		}
+	}
+	return result
+}
+
+// Agreeing returns nodes which responded with the required status code and the majority body
+func (r *ConsensusResult) Agreeing() []*NodeResponse {
+	majority := r.majorityBody()
+
+	var result []*NodeResponse
+	for _, response := range r.Responses {
+		if r.expected(response) && normalizeBody(response.Body) == majority {
+			result = append(result, response)
+		}
+	}
+	return result
+}
+
+// Disagreeing returns nodes which failed, responded with unexpected status code or a body
+// different from the majority
+func (r *ConsensusResult) Disagreeing() []*NodeResponse {
+	majority := r.majorityBody()
+
+	var result []*NodeResponse
+	for _, response := range r.Responses {
+		if !r.expected(response) || normalizeBody(response.Body) != majority {
+			result = append(result, response)
+		}
+	}
+	return result
+}
+
+// Diverged reports whether any node disagreed with the majority
+func (r *ConsensusResult) Diverged() bool {
+	return len(r.Disagreeing()) > 0
+}
+
+// Reached reports whether the required status code was returned at least as often as any other one
+func (r *ConsensusResult) Reached() bool {
+	var expected, notExpected int
+	for _, response := range r.Responses {
+		switch {
+		case response.Err != nil:
+		case response.StatusCode == r.RequiredStatusCode:
+			expected++
+		default:
+			notExpected++
+		}
+	}
+	return notExpected <= expected
+}
+
+// Response returns the first agreeing response
+func (r *ConsensusResult) Response() *NodeResponse {
+	if agreeing := r.Agreeing(); len(agreeing) > 0 {
+		return agreeing[0]
+	}
+	return nil
+}
+
+// Err returns *ConsensusError when any node failed or responded with unexpected status code.
+// Nodes which only returned a different body are reported by Diverged, but are not an error.
+func (r *ConsensusResult) Err() error {
+	failed := false
+	for _, response := range r.Responses {
+		if !r.expected(response) {
+			failed = true
+			break
+		}
+	}
+	if !failed {
+		return nil
+	}
+
+	consensusErr := &ConsensusError{Reached: r.Reached()}
+	for _, response := range r.Agreeing() {
+		consensusErr.Agreeing = append(consensusErr.Agreeing, response.URL)
+	}
+	for _, response := range r.Disagreeing() {
+		consensusErr.Disagreeing = append(consensusErr.Disagreeing, &NodeError{
+			URL:        response.URL,
+			StatusCode: response.StatusCode,
+			Body:       string(response.Body),
+			Err:        response.Err,
+		})
+	}
+	return consensusErr
+}
+
+// normalizeBody makes JSON bodies comparable regardless of formatting
+func normalizeBody(body []byte) string {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, body); err != nil {
+		return string(bytes.TrimSpace(body))
+	}
+	return buffer.String()
+}
@@ -0,0 +1,108 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensus(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	network, apiClient, newWallet := newNetwork(t)
+
+	t.RunSequentially("Slow node should not stall requests when quorum is reached", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+
+		slow := network.Sharders[0]
+		slow.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		})
+		defer slow.Handle(client.ClientGetBalance, nil)
+
+		apiClient := client.NewAPIClient(network.URL())
+		apiClient.Quorum = 1
+
+		start := time.Now()
+		balance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
+		require.Equal(t, *tokenomics.IntToZCN(100), balance.Balance)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.RunSequentially("Slow node should time out without quorum", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+
+		slow := network.Sharders[0]
+		slow.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		})
+		defer slow.Handle(client.ClientGetBalance, nil)
+
+		apiClient := client.NewAPIClient(network.URL())
+		apiClient.NodeTimeout = 100 * time.Millisecond
+
+		_, resp, err := apiClient.V1ClientGetBalance(t, model.ClientGetBalanceRequest{ClientID: wallet.Id}, client.HttpOkStatus)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NotNil(t, resp)
+	})
+
+	t.RunSequentially("Requests should be cancelled with the caller context", func(t *test.SystemTest) {
+		slow := network.Sharders[0]
+		slow.Handle(client.SharderGetStatus, func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		})
+		defer slow.Handle(client.SharderGetStatus, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, _, err := client.CallWithContext(ctx, apiClient, t, client.SharderGetStatsEndpoint, client.NoRequest{}, client.HttpOkStatus)
+		require.Error(t, err)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.RunSequentially("Sharder disagreeing about balance should be reported", func(t *test.SystemTest) {
+		// majority needs an odd number of sharders
+		network := mocknet.New(mocknet.Config{Miners: 1, Sharders: 3, Blobbers: 6})
+		defer network.Close()
+		apiClient := client.NewAPIClient(network.URL())
+
+		wallet := apiClient.CreateWalletForMnemonic(t, crypto.GenerateMnemonics(t))
+		network.SetBalance(wallet.Id, *tokenomics.IntToZCN(100))
+
+		divergent := network.Sharders[1]
+		divergent.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(model.ClientGetBalanceResponse{Balance: 1})
+		})
+		defer divergent.Handle(client.ClientGetBalance, nil)
+
+		balance, result, err := apiClient.V1ClientGetBalanceWithConsensus(t, model.ClientGetBalanceRequest{ClientID: wallet.Id}, client.HttpOkStatus)
+		require.NoError(t, err)
+		require.NotNil(t, balance)
+		require.True(t, result.Diverged())
+		require.Len(t, result.Disagreeing(), 1)
+		require.Equal(t, divergent.URL(), result.Disagreeing()[0].URL)
+
+		divergent.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		_, _, err = apiClient.V1ClientGetBalanceWithConsensus(t, model.ClientGetBalanceRequest{ClientID: wallet.Id}, client.HttpOkStatus)
+		var consensusErr *client.ConsensusError
+		require.ErrorAs(t, err, &consensusErr)
+		require.True(t, consensusErr.Reached)
+		require.Len(t, consensusErr.Disagreeing, 1)
+		require.Equal(t, divergent.URL(), consensusErr.Disagreeing[0].URL)
+		require.Equal(t, http.StatusInternalServerError, consensusErr.Disagreeing[0].StatusCode)
+	})
+}
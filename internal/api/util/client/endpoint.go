@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/test"
+	resty "github.com/go-resty/resty/v2"
+)
+
+// NoRequest is used as request type of endpoints without parameters
+type NoRequest struct{}
+
+// Endpoint describes a chain REST endpoint.
+//
+// Fields of Req tagged with `param:"name"` are sent as query parameters and fields tagged
+// with `form:"name"` as form data. Tag option omitempty skips zero values, option escape
+// query-escapes the value before it is encoded, as some sharder handlers expect it.
+type Endpoint[Req, Resp any] struct {
+	Path string
+
+	// Method defaults to HttpGETMethod
+	Method              int
+	ServiceProviderType int
+
+	// SCAddress replaces :sc_address path variable when set
+	SCAddress string
+}
+
+// NewURLBuilder returns url builder with path and parameters of the given request
+func (e Endpoint[Req, Resp]) NewURLBuilder(request Req) (*URLBuilder, map[string]string, error) {
+	urlBuilder := NewURLBuilder().SetPath(e.Path)
+	if e.SCAddress != "" {
+		urlBuilder.SetPathVariable("sc_address", e.SCAddress)
+	}
+
+	formData := make(map[string]string)
+	err := walkTaggedFields(request, func(tag, name, value string) {
+		switch tag {
+		case "param":
+			urlBuilder.AddParams(name, value)
+		case "form":
+			formData[name] = value
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(formData) == 0 {
+		formData = nil
+	}
+
+	return urlBuilder, formData, nil
+}
+
+// URL returns full url of the endpoint on the given node, to be used outside of APIClient
+func (e Endpoint[Req, Resp]) URL(baseURL string, request Req) (string, error) {
+	urlBuilder, _, err := e.NewURLBuilder(request)
+	if err != nil {
+		return "", err
+	}
+	if err := urlBuilder.MustShiftParse(baseURL); err != nil {
+		return "", err
+	}
+	return urlBuilder.String(), nil
+}
+
+// Call executes the endpoint on every healthy service provider of its type
+func Call[Req, Resp any](c *APIClient, t *test.SystemTest, endpoint Endpoint[Req, Resp], request Req, requiredStatusCode int) (Resp, *resty.Response, error) {
+	return CallWithContext(context.Background(), c, t, endpoint, request, requiredStatusCode)
+}
+
+// CallWithContext works like Call, requests to service providers are cancelled with ctx
+func CallWithContext[Req, Resp any](ctx context.Context, c *APIClient, t *test.SystemTest, endpoint Endpoint[Req, Resp], request Req, requiredStatusCode int) (Resp, *resty.Response, error) {
+	var response Resp
+
+	urlBuilder, formData, err := endpoint.NewURLBuilder(request)
+	if err != nil {
+		return response, nil, err
+	}
+
+	method := endpoint.Method
+	if method == 0 {
+		method = HttpGETMethod
+	}
+
+	resp, err := c.executeForAllServiceProvidersWithContext(
+		ctx,
+		t,
+		urlBuilder,
+		&model.ExecutionRequest{
+			FormData:           formData,
+			Dst:                &response,
+			RequiredStatusCode: requiredStatusCode,
+		},
+		method,
+		endpoint.ServiceProviderType)
+
+	return response, resp, err
+}
+
+// walkTaggedFields calls f for every field of request tagged with param or form
+func walkTaggedFields(request interface{}, f func(tag, name, value string)) error {
+	value := reflect.ValueOf(request)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("request must be a struct, got %s", value.Kind())
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		for _, tag := range []string{"param", "form"} {
+			tagValue, ok := field.Tag.Lookup(tag)
+			if !ok {
+				continue
+			}
+
+			name, options, _ := strings.Cut(tagValue, ",")
+			fieldValue := value.Field(i)
+			if strings.Contains(options, "omitempty") && fieldValue.IsZero() {
+				continue
+			}
+
+			formatted := fmt.Sprint(fieldValue.Interface())
+			if strings.Contains(options, "escape") {
+				formatted = url.QueryEscape(formatted)
+			}
+			f(tag, name, formatted)
+		}
+	}
+	return nil
+}
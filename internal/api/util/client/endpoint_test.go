@@ -0,0 +1,37 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoint(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	network, apiClient, _ := newNetwork(t)
+
+	t.RunSequentially("Declared endpoints should send tagged query parameters and form data", func(t *test.SystemTest) {
+		query := "provider_id = 'blobber' AND reward_type = 1"
+		network.Update(func(s *mocknet.State) {
+			s.QueryRewards[query] = &model.QueryRewardsResponse{TotalReward: 42}
+			s.SetSCState(client.FaucetSmartContractAddress, "key", model.SCStateGetResponse{ID: "faucet"})
+		})
+
+		rewards, _, err := apiClient.V1QueryRewards(t, model.QueryRequest{Query: query}, client.HttpOkStatus)
+		require.NoError(t, err)
+		require.Equal(t, float64(42), rewards.TotalReward)
+
+		scState, _, err := client.Call(apiClient, t, client.SharderGetSCStateEndpoint,
+			model.SCStateGetRequest{SCAddress: client.FaucetSmartContractAddress, Key: "key"}, client.HttpOkStatus)
+		require.NoError(t, err)
+		require.Equal(t, "faucet", scState.ID)
+
+		url, err := client.SCRestGetBlobberEndpoint.URL(network.Sharders[0].URL(), model.SCRestGetBlobberRequest{BlobberID: "id"})
+		require.NoError(t, err)
+		require.Equal(t, network.Sharders[0].URL()+"/v1/screst/"+client.StorageSmartContractAddress+"/getBlobber?blobber_id=id", url)
+	})
+}
@@ -0,0 +1,108 @@
+package client
+
+import "github.com/0chain/system_test/internal/api/model"
+
+// Contains declarations of chain REST endpoints called with Call
+var (
+	LatestFinalizedBlockEndpoint = Endpoint[NoRequest, *model.LatestFinalizedBlock]{
+		Path:                GetLatestFinalizedBlock,
+		Method:              HttpPOSTMethod,
+		ServiceProviderType: SharderServiceProvider,
+	}
+	TransactionGetConfirmationEndpoint = Endpoint[model.TransactionGetConfirmationRequest, *model.TransactionGetConfirmationResponse]{
+		Path:                TransactionGetConfirmation,
+		ServiceProviderType: SharderServiceProvider,
+	}
+	ClientGetBalanceEndpoint = Endpoint[model.ClientGetBalanceRequest, *model.ClientGetBalanceResponse]{
+		Path:                ClientGetBalance,
+		ServiceProviderType: SharderServiceProvider,
+	}
+	MinerGetStatsEndpoint = Endpoint[NoRequest, *model.GetMinerStatsResponse]{
+		Path:                MinerGetStatus,
+		ServiceProviderType: MinerServiceProvider,
+	}
+	SharderGetStatsEndpoint = Endpoint[NoRequest, *model.GetSharderStatsResponse]{
+		Path:                SharderGetStatus,
+		ServiceProviderType: SharderServiceProvider,
+	}
+	SharderGetSCStateEndpoint = Endpoint[model.SCStateGetRequest, *model.SCStateGetResponse]{
+		Path:                SCStateGet,
+		Method:              HttpPOSTMethod,
+		ServiceProviderType: SharderServiceProvider,
+	}
+	SCRestGetMinersEndpoint = Endpoint[NoRequest, *model.SCRestGetMinersShardersResponse]{
+		Path:                GetMiners,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           MinerSmartContractAddress,
+	}
+	SCRestGetShardersEndpoint = Endpoint[NoRequest, *model.SCRestGetMinersShardersResponse]{
+		Path:                GetSharders,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           MinerSmartContractAddress,
+	}
+	SCRestGetBlobbersEndpoint = Endpoint[model.SCRestGetBlobbersRequest, *model.SCRestGetBlobbersResponse]{
+		Path:                GetBlobbers,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	SCRestGetValidatorsEndpoint = Endpoint[NoRequest, []*model.SCRestGetValidatorResponse]{
+		Path:                GetValidators,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	SCRestGetBlobberEndpoint = Endpoint[model.SCRestGetBlobberRequest, *model.SCRestGetBlobberResponse]{
+		Path:                SCRestGetBlobbers,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	SCRestGetAllocationEndpoint = Endpoint[model.SCRestGetAllocationRequest, *model.SCRestGetAllocationResponse]{
+		Path:                SCRestGetAllocation,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	SCRestOpenChallengeEndpoint = Endpoint[model.SCRestOpenChallengeRequest, *model.SCRestOpenChallengeResponse]{
+		Path:                SCRestGetOpenChallenges,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	SCRestGetStakePoolStatEndpoint = Endpoint[model.SCRestGetStakePoolStatRequest, *model.SCRestGetStakePoolStatResponse]{
+		Path:                GetStakePoolStat,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	SCRestGetUserStakePoolStatEndpoint = Endpoint[model.SCRestGetUserStakePoolStatRequest, *model.SCRestGetUserStakePoolStatResponse]{
+		Path:                getUserStakePoolStat,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	SCRestGetAllChallengesEndpoint = Endpoint[model.GetAllChallengesForAllocationRequest, []*model.Challenge]{
+		Path:                GetAllChallenges,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	QueryChallengesCountEndpoint = Endpoint[model.QueryRequest, map[string]int64]{
+		Path:                QueryChallengesCount,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	QueryRewardsEndpoint = Endpoint[model.QueryRequest, *model.QueryRewardsResponse]{
+		Path:                QueryRewards,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	QueryDelegateRewardsEndpoint = Endpoint[model.QueryRequest, map[string]int64]{
+		Path:                QueryDelegateRewards,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	BlobberPartitionSelectionFrequencyEndpoint = Endpoint[model.BlockRewardsRequest, map[string]int64]{
+		Path:                BlobberPartitionSelectionFrequency,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+	PartitionSizeFrequencyEndpoint = Endpoint[model.BlockRewardsRequest, map[string]int]{
+		Path:                PartitionSizeFrequency,
+		ServiceProviderType: SharderServiceProvider,
+		SCAddress:           StorageSmartContractAddress,
+	}
+)
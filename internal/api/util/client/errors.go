@@ -13,9 +13,19 @@ var (
 	ErrGetFromResource = errors.New("error happened during request")
 
 	ErrExecutionConsensus = errors.New("execution consensus is not reached")
+
+	ErrInvalidNodeURL = errors.New("invalid node url")
+
+	ErrTransactionNonce        = errors.New("transaction nonce is rejected after retries")
+	ErrTransactionNotConfirmed = errors.New("transactions are not confirmed in time")
+	ErrBatchWallet             = errors.New("batch transactions must be sent from the same wallet")
+
+	ErrStateNotPinned = errors.New("sharder state could not be read at a single round")
 )
 
 // Contains errors used for SDK client
 var (
 	ErrInitStorageSDK = errors.New("error happened during SDK storage initialization")
+	ErrSDKOperation   = errors.New("operation was not successful")
+	ErrBlobberDropped = errors.New("blobber was dropped from upload")
 )
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultHealthCheckTimeout limits a single health check when neither NodeTimeout nor interval is set
+const DefaultHealthCheckTimeout = 10 * time.Second
+
+// HealthTransition records a node being evicted from or readmitted to healthy service providers
+type HealthTransition struct {
+	URL                 string
+	ServiceProviderType int
+	Healthy             bool
+	Time                time.Time
+
+	// Reason contains the failed health check for evicted nodes
+	Reason string
+}
+
+// HealthMonitor re-probes every node of the network on an interval and updates
+// healthy service providers of the client, so dead nodes are not used by later tests
+type HealthMonitor struct {
+	client   *APIClient
+	interval time.Duration
+
+	mu          sync.Mutex
+	healthy     map[string]bool
+	transitions []HealthTransition
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// StartHealthMonitor starts probing nodes in background until HealthMonitor.Stop is called
+func (c *APIClient) StartHealthMonitor(interval time.Duration) *HealthMonitor {
+	m := c.NewHealthMonitor()
+	m.interval = interval
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	}()
+
+	return m
+}
+
+// NewHealthMonitor creates a monitor which probes nodes only when Check is called
+func (c *APIClient) NewHealthMonitor() *HealthMonitor {
+	m := &HealthMonitor{
+		client:  c,
+		healthy: make(map[string]bool),
+	}
+
+	for _, serviceProviderType := range []int{MinerServiceProvider, SharderServiceProvider, BlobberServiceProvider} {
+		for _, node := range c.serviceProviders(serviceProviderType) {
+			m.healthy[node] = true
+		}
+	}
+
+	return m
+}
+
+// Stop stops background probing and waits for the running check to finish
+func (m *HealthMonitor) Stop() {
+	if m.cancel == nil {
+		return
+	}
+	m.cancel()
+	<-m.done
+}
+
+// Check probes every known node once and evicts or readmits them
+func (m *HealthMonitor) Check() {
+	m.check(context.Background())
+}
+
+// Transitions returns every recorded health transition in order of occurrence
+func (m *HealthMonitor) Transitions() []HealthTransition {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]HealthTransition(nil), m.transitions...)
+}
+
+func (m *HealthMonitor) check(ctx context.Context) {
+	known := m.client.knownServiceProviders
+	for _, serviceProviderType := range []int{MinerServiceProvider, SharderServiceProvider, BlobberServiceProvider} {
+		var nodes []string
+		switch serviceProviderType {
+		case MinerServiceProvider:
+			nodes = known.Miners
+		case SharderServiceProvider:
+			nodes = known.Sharders
+		case BlobberServiceProvider:
+			nodes = known.Blobbers
+		}
+
+		healthyNodes := m.probe(ctx, nodes, serviceProviderType)
+		if ctx.Err() != nil {
+			return
+		}
+		m.client.setServiceProviders(serviceProviderType, healthyNodes)
+	}
+}
+
+// probe returns healthy nodes keeping their original order
+func (m *HealthMonitor) probe(ctx context.Context, nodes []string, serviceProviderType int) []string {
+	probeErrors := make([]error, len(nodes))
+
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node string) {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, m.probeTimeout())
+			defer cancel()
+			probeErrors[i] = m.client.probeNode(probeCtx, node, serviceProviderType)
+		}(i, node)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []string
+	for i, node := range nodes {
+		healthy := probeErrors[i] == nil
+		if healthy {
+			result = append(result, node)
+		}
+		if m.healthy[node] == healthy || ctx.Err() != nil {
+			continue
+		}
+
+		m.healthy[node] = healthy
+		transition := HealthTransition{
+			URL:                 node,
+			ServiceProviderType: serviceProviderType,
+			Healthy:             healthy,
+			Time:                time.Now(),
+		}
+		if healthy {
+			log.Printf("%s is UP again, readmitted", node)
+		} else {
+			transition.Reason = probeErrors[i].Error()
+			log.Printf("%s is DOWN, evicted: %s", node, transition.Reason)
+		}
+		m.transitions = append(m.transitions, transition)
+	}
+	return result
+}
+
+func (m *HealthMonitor) probeTimeout() time.Duration {
+	switch {
+	case m.client.NodeTimeout > 0:
+		return m.client.NodeTimeout
+	case m.interval > 0:
+		return m.interval
+	}
+	return DefaultHealthCheckTimeout
+}
@@ -0,0 +1,49 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthMonitor(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	network, _, newWallet := newNetwork(t)
+
+	t.RunSequentially("Health monitor should evict and readmit nodes", func(t *test.SystemTest) {
+		apiClient := client.NewAPIClient(network.URL())
+		monitor := apiClient.NewHealthMonitor()
+
+		down := network.Sharders[0]
+		down.SetDown(true)
+		monitor.Check()
+		require.NotContains(t, apiClient.HealthyNodes(client.SharderServiceProvider), down.URL())
+		require.Len(t, apiClient.HealthyNodes(client.SharderServiceProvider), len(network.Sharders)-1)
+
+		down.SetDown(false)
+		monitor.Check()
+		require.Equal(t, down.URL(), apiClient.HealthyNodes(client.SharderServiceProvider)[0])
+
+		transitions := monitor.Transitions()
+		require.Len(t, transitions, 2)
+		require.Equal(t, down.URL(), transitions[0].URL)
+		require.False(t, transitions[0].Healthy)
+		require.NotEmpty(t, transitions[0].Reason)
+		require.True(t, transitions[1].Healthy)
+	})
+
+	t.RunSequentially("Running health monitor should not race with requests", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+
+		apiClient := client.NewAPIClient(network.URL())
+		monitor := apiClient.StartHealthMonitor(10 * time.Millisecond)
+		defer monitor.Stop()
+
+		for i := 0; i < 10; i++ {
+			apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -32,27 +33,39 @@ type BaseHttpClient struct {
 }
 
 func (c *BaseHttpClient) executeForServiceProvider(t *test.SystemTest, url string, executionRequest model.ExecutionRequest, method int) (*resty.Response, error) { //nolint
+	return c.executeForServiceProviderWithContext(context.Background(), t, url, executionRequest, method)
+}
+
+// executeForServiceProviderWithContext performs the request bound to the given context. Transport
+// errors are returned, not reported as test failures, so pollers can retry them
+func (c *BaseHttpClient) executeForServiceProviderWithContext(ctx context.Context, t *test.SystemTest, url string, executionRequest model.ExecutionRequest, method int) (*resty.Response, error) { //nolint
 	var (
 		resp *resty.Response
 		err  error
 	)
 
+	r := c.HttpClient.R().SetContext(ctx)
+
 	switch method {
 	case HttpPUTMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetQueryParams(executionRequest.QueryParams).SetBody(executionRequest.Body).Put(url)
+		resp, err = r.SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetQueryParams(executionRequest.QueryParams).SetBody(executionRequest.Body).Put(url)
 	case HttpPOSTMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetBody(executionRequest.Body).Post(url)
+		resp, err = r.SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetBody(executionRequest.Body).Post(url)
 	case HttpFileUploadMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetFile(executionRequest.FileName, executionRequest.FilePath).Post(url)
+		resp, err = r.SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetFile(executionRequest.FileName, executionRequest.FilePath).Post(url)
 	case HttpGETMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetQueryParams(executionRequest.QueryParams).Get(url)
+		resp, err = r.SetHeaders(executionRequest.Headers).SetQueryParams(executionRequest.QueryParams).Get(url)
 	case HttpDELETEMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetQueryParams(executionRequest.QueryParams).SetBody(executionRequest.Body).Delete(url)
+		resp, err = r.SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetQueryParams(executionRequest.QueryParams).SetBody(executionRequest.Body).Delete(url)
 	}
 
 	if err != nil {
-		t.Errorf("%s error : %v", url, err)
-		return nil, fmt.Errorf("%s: %w", url, ErrGetFromResource)
+		if ctx.Err() != nil {
+			t.Logf("%s error : %v", url, err)
+			return nil, fmt.Errorf("%s: %w", url, ctx.Err())
+		}
+		t.Logf("%s error : %v", url, err)
+		return nil, fmt.Errorf("%w: %s: %w", ErrGetFromResource, url, err)
 	}
 
 	body := resp.Body()
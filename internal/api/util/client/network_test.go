@@ -0,0 +1,24 @@
+package client_test
+
+import (
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics"
+)
+
+// newNetwork starts a mock network for the test, newWallet registers wallets holding 100 ZCN on it
+func newNetwork(t *test.SystemTest) (*mocknet.Network, *client.APIClient, func(t *test.SystemTest) *model.Wallet) {
+	network := mocknet.NewDefault()
+	t.Cleanup(network.Close)
+
+	apiClient := client.NewAPIClient(network.URL())
+	newWallet := func(t *test.SystemTest) *model.Wallet {
+		wallet := apiClient.CreateWalletForMnemonic(t, crypto.GenerateMnemonics(t))
+		network.SetBalance(wallet.Id, *tokenomics.IntToZCN(100))
+		return wallet
+	}
+	return network, apiClient, newWallet
+}
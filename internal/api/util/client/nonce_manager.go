@@ -0,0 +1,171 @@
+package client
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// NonceManager hands out nonces of wallets shared by parallel tests.
+//
+// Nonce of a wallet is synced with the chain on first use and whenever miners reject
+// a reserved one. Nonces of transactions which were never accepted are released and
+// handed out again, so the wallet does not get stuck on a gap.
+type NonceManager struct {
+	client *APIClient
+
+	mu      sync.Mutex
+	wallets map[string]*walletNonces
+}
+
+type walletNonces struct {
+	synced bool
+
+	// last is the highest nonce handed out
+	last int
+
+	// released nonces are lower than last and are handed out first
+	released []int
+
+	// pending nonces are reserved and not yet accepted or released
+	pending map[int]struct{}
+
+	// accepted nonces are accepted by miners, but not yet reported by the chain
+	accepted map[int]struct{}
+}
+
+func newNonceManager(c *APIClient) *NonceManager {
+	return &NonceManager{
+		client:  c,
+		wallets: make(map[string]*walletNonces),
+	}
+}
+
+// Nonces returns nonce manager used by transaction builders of the client
+func (c *APIClient) Nonces() *NonceManager {
+	return c.nonces
+}
+
+func (m *NonceManager) wallet(walletID string) *walletNonces {
+	nonces, ok := m.wallets[walletID]
+	if !ok {
+		nonces = &walletNonces{
+			pending:  make(map[int]struct{}),
+			accepted: make(map[int]struct{}),
+		}
+		m.wallets[walletID] = nonces
+	}
+	return nonces
+}
+
+// Reserve returns the lowest nonce of the wallet which is not handed out yet
+func (m *NonceManager) Reserve(t *test.SystemTest, wallet *model.Wallet) (int, error) {
+	m.mu.Lock()
+	synced := m.wallet(wallet.Id).synced
+	m.mu.Unlock()
+
+	if !synced {
+		if err := m.Sync(t, wallet); err != nil {
+			return 0, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonces := m.wallet(wallet.Id)
+	var nonce int
+	if len(nonces.released) > 0 {
+		nonce, nonces.released = nonces.released[0], nonces.released[1:]
+	} else {
+		nonces.last++
+		nonce = nonces.last
+	}
+	nonces.pending[nonce] = struct{}{}
+
+	return nonce, nil
+}
+
+// Accept marks nonce as accepted by miners, wallet nonce is advanced to it
+func (m *NonceManager) Accept(wallet *model.Wallet, nonce int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonces := m.wallet(wallet.Id)
+	delete(nonces.pending, nonce)
+	nonces.accepted[nonce] = struct{}{}
+	if nonce > wallet.Nonce {
+		wallet.Nonce = nonce
+	}
+}
+
+// Release rolls back reservation of a transaction which was never accepted
+func (m *NonceManager) Release(wallet *model.Wallet, nonce int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonces := m.wallet(wallet.Id)
+	if _, ok := nonces.pending[nonce]; !ok {
+		return
+	}
+	delete(nonces.pending, nonce)
+
+	if nonce == nonces.last {
+		nonces.last--
+		for len(nonces.released) > 0 && nonces.released[len(nonces.released)-1] == nonces.last {
+			nonces.released = nonces.released[:len(nonces.released)-1]
+			nonces.last--
+		}
+		return
+	}
+	nonces.released = append(nonces.released, nonce)
+	sort.Ints(nonces.released)
+}
+
+// Sync fetches wallet nonce from /v1/client/get/balance. Nonces which are still pending or accepted
+// by miners are kept, so parallel transactions do not get the same nonce.
+func (m *NonceManager) Sync(t *test.SystemTest, wallet *model.Wallet) error {
+	balance, _, err := m.client.V1ClientGetBalance(t, model.ClientGetBalanceRequest{ClientID: wallet.Id}, HttpOkStatus)
+	if err != nil {
+		return err
+	}
+
+	m.Update(wallet, int(balance.Nonce))
+	return nil
+}
+
+// Update sets wallet nonce reported by the chain, e.g. in a balance fetched by the caller
+func (m *NonceManager) Update(wallet *model.Wallet, chainNonce int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonces := m.wallet(wallet.Id)
+	nonces.synced = true
+	wallet.Nonce = chainNonce
+
+	nonces.last = chainNonce
+	for nonce := range nonces.accepted {
+		if nonce <= chainNonce {
+			delete(nonces.accepted, nonce)
+		} else if nonce > nonces.last {
+			nonces.last = nonce
+		}
+	}
+	for nonce := range nonces.pending {
+		if nonce > nonces.last {
+			nonces.last = nonce
+		}
+	}
+
+	var released []int
+	for nonce := chainNonce + 1; nonce < nonces.last; nonce++ {
+		_, pending := nonces.pending[nonce]
+		_, accepted := nonces.accepted[nonce]
+		if !pending && !accepted {
+			released = append(released, nonce)
+		}
+	}
+	nonces.released = released
+}
@@ -0,0 +1,67 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManager(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	network, apiClient, newWallet := newNetwork(t)
+
+	t.RunSequentially("Released nonce should be handed out again", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+		nonces := apiClient.Nonces()
+
+		first, err := nonces.Reserve(t, wallet)
+		require.NoError(t, err)
+		second, err := nonces.Reserve(t, wallet)
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, []int{first, second})
+
+		nonces.Release(wallet, first)
+		nonce, err := nonces.Reserve(t, wallet)
+		require.NoError(t, err)
+		require.Equal(t, first, nonce)
+
+		nonces.Release(wallet, second)
+		nonces.Release(wallet, first)
+		nonce, err = nonces.Reserve(t, wallet)
+		require.NoError(t, err)
+		require.Equal(t, 1, nonce)
+		nonces.Release(wallet, nonce)
+	})
+
+	t.RunSequentially("Parallel transactions of a shared wallet should all be confirmed", func(t *test.SystemTest) {
+		sender, receiver := newWallet(t), newWallet(t)
+
+		const transactions = 10
+		hashes := make([]string, transactions)
+		errs := make(chan error, transactions)
+		for i := 0; i < transactions; i++ {
+			go func(i int) {
+				response, _, err := apiClient.NewTransaction(sender).To(receiver.Id).Submit(t, client.HttpOkStatus)
+				if err == nil {
+					hashes[i] = response.Request.Hash
+				}
+				errs <- err
+			}(i)
+		}
+		for i := 0; i < transactions; i++ {
+			require.NoError(t, <-errs)
+		}
+
+		_, err := apiClient.WaitForConfirmations(context.Background(), t, client.DefaultConfirmationTimeout, hashes...)
+		require.NoError(t, err)
+
+		balance, _ := network.Balance(receiver.Id)
+		require.Equal(t, *tokenomics.IntToZCN(100 + transactions), balance)
+		_, nonce := network.Balance(sender.Id)
+		require.Equal(t, int64(transactions), nonce)
+	})
+}
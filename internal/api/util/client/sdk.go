@@ -1,12 +1,12 @@
 package client
 
 import (
-	"bytes"
-	"crypto/rand"
 	"io"
-	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/0chain/gosdk/core/client"
 	"github.com/0chain/gosdk/zcncore"
@@ -18,6 +18,7 @@ import (
 	"github.com/0chain/system_test/internal/api/model"
 	"github.com/0chain/system_test/internal/api/util/config"
 	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/datagen"
 	"github.com/0chain/system_test/internal/api/util/test"
 	"github.com/stretchr/testify/require"
 )
@@ -29,40 +30,116 @@ type SDKClient struct {
 	wallet      *model.SdkWallet
 }
 
+// StatusCallback records statuses and collects metrics of files reported by SDK operations
 type StatusCallback struct {
 	wg       *sync.WaitGroup
 	isRepair bool
-	success  bool
-	err      error
+
+	// blobbers are ids of allocation blobbers, in order of upload masks
+	blobbers []string
+
+	mu            sync.Mutex
+	dispatched    time.Time
+	success       bool
+	err           error
+	statuses      []*OperationStatus
+	operations    map[operationKey]*OperationMetrics
+	order         []operationKey
+	errors        []*ErrorEvent
+	filesRepaired int
+	uploads       map[string]*uploadProgress
 }
 
 type MultiOperationOption func(alloc *sdk.Allocation)
 
 func (cb *StatusCallback) Started(allocationId, filePath string, op, totalBytes int) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 
+	metrics := cb.operation(allocationId, filePath, op)
+	if metrics.Started.IsZero() {
+		metrics.Started = time.Now()
+	}
+	metrics.TotalBytes = int64(totalBytes)
 }
 
 func (cb *StatusCallback) InProgress(allocationId, filePath string, op, completedBytes int, data []byte) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	metrics := cb.operation(allocationId, filePath, op)
+	if completedBytes > 0 && metrics.FirstByte.IsZero() {
+		metrics.FirstByte = time.Now()
+	}
+	if int64(completedBytes) > metrics.CompletedBytes {
+		metrics.CompletedBytes = int64(completedBytes)
+	}
 }
 
 func (cb *StatusCallback) RepairCompleted(filesRepaired int) {
+	cb.mu.Lock()
+	cb.filesRepaired += filesRepaired
 	if cb.err == nil {
 		cb.success = true
 	}
-	cb.wg.Done()
+	cb.mu.Unlock()
+	cb.done()
 }
 
 func (cb *StatusCallback) Completed(allocationId, filePath, filename, mimetype string, size, op int) {
+	cb.mu.Lock()
+	cb.statuses = append(cb.statuses, &OperationStatus{AllocationID: allocationId, FilePath: filePath, Op: op, Completed: true})
+	metrics := cb.operation(allocationId, filePath, op)
+	metrics.Finished = time.Now()
+	if int64(size) > metrics.CompletedBytes {
+		metrics.CompletedBytes = int64(size)
+	}
 	if !cb.isRepair {
 		cb.success = true
-		cb.wg.Done()
+	}
+	cb.mu.Unlock()
+
+	if !cb.isRepair {
+		cb.done()
 	}
 }
 
 func (cb *StatusCallback) Error(allocationID, filePath string, op int, err error) {
+	cb.mu.Lock()
+	cb.statuses = append(cb.statuses, &OperationStatus{AllocationID: allocationID, FilePath: filePath, Op: op, Err: err})
+	metrics := cb.operation(allocationID, filePath, op)
+	metrics.Finished = time.Now()
+	metrics.Failed = true
+	event := &ErrorEvent{Time: metrics.Finished, FilePath: filePath, Op: op}
+	if err != nil {
+		event.Err = err.Error()
+	}
+	cb.errors = append(cb.errors, event)
 	cb.success = false
 	cb.err = err
+	cb.mu.Unlock()
+
 	if !cb.isRepair {
+		cb.done()
+	}
+}
+
+// Statuses returns statuses of files reported so far
+func (cb *StatusCallback) Statuses() []*OperationStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return append([]*OperationStatus(nil), cb.statuses...)
+}
+
+func (cb *StatusCallback) result() (bool, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.success, cb.err
+}
+
+// done is skipped for callbacks which only record statuses, e.g. of uploads in a multi operation
+func (cb *StatusCallback) done() {
+	if cb.wg != nil {
 		cb.wg.Done()
 	}
 }
@@ -85,12 +162,22 @@ func NewSDKClient(blockWorker string) *SDKClient {
 func (c *SDKClient) SetWallet(t *test.SystemTest, wallet *model.Wallet) {
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
+
+	keys := wallet.Keys
+	if keys == nil {
+		var ok bool
+		keys, ok = crypto.DefaultKeyStore.Get(wallet.Id)
+		require.True(t, ok, "keys of wallet [%s] are not known", wallet.Id)
+	} else {
+		crypto.DefaultKeyStore.Put(wallet.Id, keys)
+	}
+
 	c.wallet = &model.SdkWallet{
 		ClientID:  wallet.Id,
 		ClientKey: wallet.PublicKey,
 		Keys: []*model.SdkKeyPair{{
-			PrivateKey: wallet.Keys.PrivateKey.SerializeToHexStr(),
-			PublicKey:  wallet.Keys.PublicKey.SerializeToHexStr(),
+			PrivateKey: keys.PrivateKey.SerializeToHexStr(),
+			PublicKey:  keys.PublicKey.SerializeToHexStr(),
 		}},
 		Mnemonics: wallet.Mnemonics,
 		Version:   wallet.Version,
@@ -147,123 +234,174 @@ func (c *SDKClient) DeleteFile(t *test.SystemTest, allocationID, fpath string) {
 	c.MultiOperation(t, allocationID, []sdk.OperationRequest{deleteOp})
 }
 
-func (c *SDKClient) DownloadFile(t *test.SystemTest, allocationID, remotepath, localpath string) {
+func (c *SDKClient) DownloadFile(t *test.SystemTest, allocationID, remotepath, localpath string) *TransferMetrics {
+	metrics, err := c.DownloadFileWithoutAssertion(t, allocationID, remotepath, localpath)
+	require.NoError(t, err)
+	return metrics
+}
+
+// DownloadFileWithoutAssertion downloads the file and returns *SDKError when the download fails
+func (c *SDKClient) DownloadFileWithoutAssertion(t *test.SystemTest, allocationID, remotepath, localpath string) (*TransferMetrics, error) {
 	t.Logf("Downloading file %s to %s from allocation %s", remotepath, localpath, allocationID)
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
 
 	sdkAllocation, err := sdk.GetAllocation(allocationID)
-	require.NoError(t, err)
+	if err != nil {
+		return nil, newSDKError("download", allocationID, nil, err, nil)
+	}
+
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
-	err = sdkAllocation.DownloadFile(localpath, "/"+remotepath, false, &StatusCallback{
-		wg: wg,
-	}, true)
-	require.NoError(t, err)
+	statusBar := newStatusCallback(wg, false)
+	statusBar.dispatch()
+	err = sdkAllocation.DownloadFile(localpath, "/"+remotepath, false, statusBar, true)
+	if err != nil {
+		return nil, newSDKError("download", allocationID, sdkAllocation, err, statusBar)
+	}
 	wg.Wait()
+
+	metrics := statusBar.Metrics()
+	t.AddMetrics("download", metrics)
+	if success, err := statusBar.result(); !success {
+		return metrics, newSDKError("download", allocationID, sdkAllocation, err, statusBar)
+	}
+	return metrics, nil
 }
 
-func (c *SDKClient) DownloadFileWithParam(t *test.SystemTest, alloc *sdk.Allocation, remotepath, localpath string, wg *sync.WaitGroup, isFinal bool) {
+// DownloadFileWithParam starts the download, metrics of the returned callback are complete once wg is done
+func (c *SDKClient) DownloadFileWithParam(t *test.SystemTest, alloc *sdk.Allocation, remotepath, localpath string, wg *sync.WaitGroup, isFinal bool) *StatusCallback {
 	wg.Add(1)
-	err := alloc.DownloadFile(localpath, remotepath, false, &StatusCallback{
-		wg: wg,
-	}, isFinal)
+	statusBar := newStatusCallback(wg, false)
+	statusBar.dispatch()
+	err := alloc.DownloadFile(localpath, remotepath, false, statusBar, isFinal)
 	require.NoError(t, err)
+	return statusBar
 }
 
 func (c *SDKClient) GetFileList(t *test.SystemTest, allocationID, path string) *sdk.ListResult {
-	sdkAllocation, err := sdk.GetAllocation(allocationID)
-	require.NoError(t, err)
-
-	fileList, err := sdkAllocation.ListDir(path)
+	fileList, err := c.GetFileListWithoutAssertion(t, allocationID, path)
 	require.NoError(t, err)
 
 	return fileList
 }
 
-func (c *SDKClient) Rollback(t *test.SystemTest, allocationID string) {
+// GetFileListWithoutAssertion lists the directory and returns *SDKError when listing fails
+func (c *SDKClient) GetFileListWithoutAssertion(t *test.SystemTest, allocationID, path string) (*sdk.ListResult, error) {
 	sdkAllocation, err := sdk.GetAllocation(allocationID)
+	if err != nil {
+		return nil, newSDKError("list", allocationID, nil, err, nil)
+	}
+
+	fileList, err := sdkAllocation.ListDir(path)
+	if err != nil {
+		return nil, newSDKError("list", allocationID, sdkAllocation, err, nil)
+	}
+	return fileList, nil
+}
+
+func (c *SDKClient) Rollback(t *test.SystemTest, allocationID string) {
+	err := c.RollbackWithoutAssertion(t, allocationID)
 	require.NoError(t, err)
+}
+
+// RollbackWithoutAssertion rolls the allocation back to the version committed by all blobbers and returns *SDKError
+// when it fails
+func (c *SDKClient) RollbackWithoutAssertion(t *test.SystemTest, allocationID string) error {
+	sdkAllocation, err := sdk.GetAllocation(allocationID)
+	if err != nil {
+		return newSDKError("rollback", allocationID, nil, err, nil)
+	}
 
 	status, err := sdkAllocation.GetCurrentVersion()
+	if err != nil {
+		return newSDKError("rollback", allocationID, sdkAllocation, err, nil)
+	}
+	if !status {
+		return newSDKError("rollback", allocationID, sdkAllocation, nil, nil)
+	}
+	return nil
+}
+
+func (c *SDKClient) MultiOperation(t *test.SystemTest, allocationID string, ops []sdk.OperationRequest, multiOps ...MultiOperationOption) *TransferMetrics {
+	metrics, err := c.MultiOperationWithoutAssertion(t, allocationID, ops, multiOps...)
 	require.NoError(t, err)
-	require.True(t, status)
+	return metrics
 }
 
-func (c *SDKClient) MultiOperation(t *test.SystemTest, allocationID string, ops []sdk.OperationRequest, multiOps ...MultiOperationOption) {
+// MultiOperationWithoutAssertion commits the operations and returns *SDKError when the commit fails,
+// statuses and metrics of uploaded files are collected by StatusCallback
+func (c *SDKClient) MultiOperationWithoutAssertion(t *test.SystemTest, allocationID string, ops []sdk.OperationRequest, multiOps ...MultiOperationOption) (*TransferMetrics, error) {
 	defer func() {
 		for i := 0; i < len(ops); i++ {
-			if ops[i].OperationType == constants.FileOperationInsert || ops[i].OperationType == constants.FileOperationUpdate {
-				if closer, ok := ops[i].FileReader.(io.Closer); ok {
-					_ = closer.Close()
-				}
-				_ = os.RemoveAll(ops[i].FileMeta.Path)
+			if closer, ok := ops[i].FileReader.(io.Closer); ok {
+				_ = closer.Close()
 			}
 		}
 	}()
 
 	sdkAllocation, err := sdk.GetAllocation(allocationID)
-	require.NoError(t, err)
+	if err != nil {
+		return nil, newSDKError("multi operation", allocationID, nil, err, nil)
+	}
 
 	for _, opt := range multiOps {
 		opt(sdkAllocation)
 	}
 
+	// callback is added to copies of the operations, so options of the caller are left as they are
+	statusBar := newStatusCallback(nil, false).withBlobbers(sdkAllocation)
+	ops = slices.Clone(ops)
+	for i := range ops {
+		if ops[i].OperationType == constants.FileOperationInsert || ops[i].OperationType == constants.FileOperationUpdate {
+			ops[i].Opts = append(slices.Clip(ops[i].Opts), sdk.WithStatusCallback(statusBar), statusBar.uploadOption(ops[i].FileMeta.RemotePath))
+		}
+	}
+
+	statusBar.dispatch()
 	err = sdkAllocation.DoMultiOperation(ops)
-	require.NoError(t, err)
+	metrics := statusBar.Metrics()
+	t.AddMetrics("multi_operation", metrics)
+	if err != nil {
+		return metrics, newSDKError("multi operation", allocationID, sdkAllocation, err, statusBar)
+	}
+	return metrics, nil
 }
 
+// AddUploadOperation uploads generated content of opts[0] bytes, 1024 by default. FileReader of the operation
+// is *datagen.Source, which verifies downloaded content.
 func (c *SDKClient) AddUploadOperation(t *test.SystemTest, path, format string, opts ...int64) sdk.OperationRequest {
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
 
-	tmpFile, err := os.CreateTemp("", "*"+format)
-	if err != nil {
-		require.NoError(t, err)
-	}
-
-	defer func(name string) {
-		_ = os.RemoveAll(name)
-	}(tmpFile.Name())
-
 	var actualSize int64 = 1024
 	if len(opts) > 0 {
 		actualSize = opts[0]
 	}
-
-	rawBuf := make([]byte, actualSize)
-	_, err = rand.Read(rawBuf)
-	if err != nil {
-		require.NoError(t, err)
-	} //nolint:gosec,revive
-
-	_, err = tmpFile.Write(rawBuf)
-	require.NoError(t, err)
-	_, err = tmpFile.Seek(0, 0)
-	require.NoError(t, err)
+	source := newDataSource(t, actualSize)
 
 	remoteName := filepath.Base(path)
 	remotePath := "/" + filepath.Join(filepath.Dir(path), filepath.Base(path))
 	if path == "" {
-		remoteName = filepath.Base(tmpFile.Name())
-		remotePath = "/" + filepath.Join("", filepath.Base(tmpFile.Name()))
+		remoteName = dataSourceName(source, format)
+		remotePath = "/" + filepath.Join("", remoteName)
 	}
 
 	fileMeta := sdk.FileMeta{
-		Path:       tmpFile.Name(),
+		Path:       remoteName,
 		ActualSize: actualSize,
 		RemoteName: remoteName,
 		RemotePath: remotePath,
 	}
 
-	t.Log("fileMeta", fileMeta)
+	t.Logf("Uploading file of size [%d] and seed [%d] to [%s]", fileMeta.ActualSize, source.Seed(), fileMeta.RemotePath)
 
 	homeDir, err := config.GetHomeDir()
 	require.NoError(t, err)
 
 	return sdk.OperationRequest{
 		OperationType: constants.FileOperationInsert,
-		FileReader:    tmpFile,
+		FileReader:    source,
 		FileMeta:      fileMeta,
 		Workdir:       homeDir,
 		RemotePath:    fileMeta.RemotePath,
@@ -275,33 +413,23 @@ func (c *SDKClient) AddUploadOperationForBigFile(t *test.SystemTest, allocationI
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
 
-	tmpFile, err := os.CreateTemp("", "*")
-	if err != nil {
-		require.NoError(t, err)
-	}
-
-	for i := 0; i < fileSize; i++ {
-		buf := make([]byte, 1024*1024*1024)
-		_, err = rand.Read(buf)
-		require.NoError(t, err)
-		_, err = tmpFile.Write(buf)
-		require.NoError(t, err)
-	}
-	_, err = tmpFile.Seek(0, 0)
-	require.NoError(t, err)
+	source := newDataSource(t, 1024*1024*1024*int64(fileSize))
+	remoteName := dataSourceName(source, "")
 	fileMeta := sdk.FileMeta{
-		Path:       tmpFile.Name(),
-		ActualSize: 1024 * 1024 * 1024 * int64(fileSize),
-		RemoteName: filepath.Base(tmpFile.Name()),
-		RemotePath: "/" + filepath.Join("", filepath.Base(tmpFile.Name())),
+		Path:       remoteName,
+		ActualSize: source.Size(),
+		RemoteName: remoteName,
+		RemotePath: "/" + filepath.Join("", remoteName),
 	}
 
+	t.Logf("Uploading file of size [%d] and seed [%d] to [%s]", fileMeta.ActualSize, source.Seed(), fileMeta.RemotePath)
+
 	homeDir, err := config.GetHomeDir()
 	require.NoError(t, err)
 
 	return sdk.OperationRequest{
 		OperationType: constants.FileOperationInsert,
-		FileReader:    tmpFile,
+		FileReader:    source,
 		FileMeta:      fileMeta,
 		Workdir:       homeDir,
 		RemotePath:    fileMeta.RemotePath,
@@ -330,31 +458,22 @@ func (c *SDKClient) AddUpdateOperation(t *test.SystemTest, remotePath, remoteNam
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
 
-	tmpFile, err := os.CreateTemp("", "*")
-	if err != nil {
-		require.NoError(t, err)
-	}
-
-	rawBuf := make([]byte, fileSize)
-	_, err = rand.Read(rawBuf)
-	if err != nil {
-		require.NoError(t, err)
-	} //nolint:gosec,revive
+	source := newDataSource(t, fileSize)
 	fileMeta := sdk.FileMeta{
-		Path:       tmpFile.Name(),
+		Path:       remoteName,
 		ActualSize: fileSize,
 		RemotePath: remotePath,
 		RemoteName: remoteName,
 	}
 
-	buf := bytes.NewBuffer(rawBuf)
+	t.Logf("Updating file [%s] with size [%d] and seed [%d]", fileMeta.RemotePath, fileMeta.ActualSize, source.Seed())
 
 	homeDir, err := config.GetHomeDir()
 	require.NoError(t, err)
 
 	return sdk.OperationRequest{
 		OperationType: constants.FileOperationUpdate,
-		FileReader:    buf,
+		FileReader:    source,
 		FileMeta:      fileMeta,
 		Workdir:       homeDir,
 	}
@@ -380,34 +499,21 @@ func (c *SDKClient) AddUploadOperationWithPath(t *test.SystemTest, allocationID,
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
 
-	tmpFile, err := os.CreateTemp("", "*")
-	if err != nil {
-		require.NoError(t, err)
-	}
-
-	const fileSize int64 = 1024
-
-	rawBuf := make([]byte, fileSize)
-	_, err = rand.Read(rawBuf)
-	if err != nil {
-		require.NoError(t, err)
-	} //nolint:gosec,revive
-
+	source := newDataSource(t, 1024)
+	remoteName := dataSourceName(source, "")
 	fileMeta := sdk.FileMeta{
-		Path:       tmpFile.Name(),
-		ActualSize: fileSize,
-		RemoteName: filepath.Base(tmpFile.Name()),
-		RemotePath: remotePath + filepath.Join("", filepath.Base(tmpFile.Name())),
+		Path:       remoteName,
+		ActualSize: source.Size(),
+		RemoteName: remoteName,
+		RemotePath: remotePath + filepath.Join("", remoteName),
 	}
 
-	buf := bytes.NewBuffer(rawBuf)
-
 	homeDir, err := config.GetHomeDir()
 	require.NoError(t, err)
 
 	return sdk.OperationRequest{
 		OperationType: constants.FileOperationInsert,
-		FileReader:    buf,
+		FileReader:    source,
 		FileMeta:      fileMeta,
 		Workdir:       homeDir,
 	}
@@ -420,20 +526,35 @@ func (c *SDKClient) AddCreateDirOperation(t *test.SystemTest, allocationID, remo
 	}
 }
 
-func (c *SDKClient) RepairAllocation(t *test.SystemTest, allocationID string) {
-	sdkAllocation, err := sdk.GetAllocation(allocationID)
+func (c *SDKClient) RepairAllocation(t *test.SystemTest, allocationID string) *TransferMetrics {
+	metrics, err := c.RepairAllocationWithoutAssertion(t, allocationID)
 	require.NoError(t, err)
+	return metrics
+}
+
+// RepairAllocationWithoutAssertion repairs the allocation and returns *SDKError when any file is not repaired
+func (c *SDKClient) RepairAllocationWithoutAssertion(t *test.SystemTest, allocationID string) (*TransferMetrics, error) {
+	sdkAllocation, err := sdk.GetAllocation(allocationID)
+	if err != nil {
+		return nil, newSDKError("repair", allocationID, nil, err, nil)
+	}
 
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
-	statusBar := &StatusCallback{
-		wg:       wg,
-		isRepair: true,
-	}
+	statusBar := newStatusCallback(wg, true)
+	statusBar.dispatch()
 	err = sdkAllocation.RepairAlloc(statusBar)
-	require.NoError(t, err)
+	if err != nil {
+		return nil, newSDKError("repair", allocationID, sdkAllocation, err, statusBar)
+	}
 	wg.Wait()
-	require.True(t, statusBar.success)
+
+	metrics := statusBar.Metrics()
+	t.AddMetrics("repair", metrics)
+	if success, err := statusBar.result(); !success {
+		return metrics, newSDKError("repair", allocationID, sdkAllocation, err, statusBar)
+	}
+	return metrics, nil
 }
 
 func WithRepair(blobbers []*blockchain.StorageNode) MultiOperationOption {
@@ -445,3 +566,14 @@ func WithRepair(blobbers []*blockchain.StorageNode) MultiOperationOption {
 		alloc.Blobbers = blobbers
 	}
 }
+
+func newDataSource(t *test.SystemTest, size int64) *datagen.Source {
+	source, err := datagen.NewRandom(size)
+	require.NoError(t, err)
+	return source
+}
+
+// dataSourceName names uploaded file after the seed of its content
+func dataSourceName(source *datagen.Source, format string) string {
+	return strconv.FormatUint(source.Seed(), 10) + format
+}
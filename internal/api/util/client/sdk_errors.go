@@ -0,0 +1,107 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	thrown "github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+)
+
+// OperationStatus is the outcome of a file reported to StatusCallback
+type OperationStatus struct {
+	AllocationID string
+	FilePath     string
+	Op           int
+	Completed    bool
+	Err          error
+}
+
+// BlobberStatus tells whether a blobber of the allocation failed in the operation. gosdk reports status
+// of blobbers only for uploads, blobbers of other operations are never failed.
+type BlobberStatus struct {
+	ID  string
+	URL string
+	Err error
+}
+
+func (s *BlobberStatus) Failed() bool {
+	return s.Err != nil
+}
+
+// SDKError is returned by SDKClient operations without assertion. It unwraps to the gosdk error,
+// or to ErrSDKOperation when the operation reported failure without one.
+type SDKError struct {
+	Operation    string
+	AllocationID string
+
+	// Code is code of gosdk error, e.g. consensus_not_met
+	Code string
+	Err  error
+
+	Statuses []*OperationStatus
+	Blobbers []*BlobberStatus
+}
+
+func newSDKError(operation, allocationID string, allocation *sdk.Allocation, err error, statusBar *StatusCallback) *SDKError {
+	if err == nil {
+		err = ErrSDKOperation
+	}
+
+	sdkErr := &SDKError{
+		Operation:    operation,
+		AllocationID: allocationID,
+		Err:          err,
+	}
+	var gosdkErr *thrown.Error
+	if errors.As(err, &gosdkErr) {
+		sdkErr.Code = gosdkErr.Code
+	}
+
+	var blobberErrs map[string]error
+	if statusBar != nil {
+		sdkErr.Statuses = statusBar.Statuses()
+		blobberErrs = statusBar.BlobberErrors()
+	}
+
+	if allocation != nil {
+		for _, blobber := range allocation.Blobbers {
+			sdkErr.Blobbers = append(sdkErr.Blobbers, &BlobberStatus{
+				ID:  blobber.ID,
+				URL: blobber.Baseurl,
+				Err: blobberErrs[blobber.ID],
+			})
+		}
+	}
+	return sdkErr
+}
+
+func (e *SDKError) Error() string {
+	return fmt.Sprintf("%s of allocation [%s] failed: %v", e.Operation, e.AllocationID, e.Err)
+}
+
+func (e *SDKError) Unwrap() error {
+	return e.Err
+}
+
+// FailedBlobbers returns blobbers which failed in the operation
+func (e *SDKError) FailedBlobbers() []*BlobberStatus {
+	var failed []*BlobberStatus
+	for _, blobber := range e.Blobbers {
+		if blobber.Failed() {
+			failed = append(failed, blobber)
+		}
+	}
+	return failed
+}
+
+// FailedFiles returns statuses of files which were not completed
+func (e *SDKError) FailedFiles() []*OperationStatus {
+	var failed []*OperationStatus
+	for _, status := range e.Statuses {
+		if !status.Completed {
+			failed = append(failed, status)
+		}
+	}
+	return failed
+}
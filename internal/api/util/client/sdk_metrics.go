@@ -0,0 +1,154 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// TransferMetrics is collected by StatusCallback from progress reported by SDK operations
+type TransferMetrics struct {
+	Operations []*OperationMetrics `json:"operations"`
+
+	// BlobberBytes are bytes sent to every blobber, gosdk reports them for uploads only
+	BlobberBytes map[string]int64 `json:"blobber_bytes,omitempty"`
+
+	FilesRepaired int           `json:"files_repaired"`
+	Errors        []*ErrorEvent `json:"errors,omitempty"`
+}
+
+// OperationMetrics contains progress of a single file of an SDK operation
+type OperationMetrics struct {
+	AllocationID string `json:"allocation_id"`
+	FilePath     string `json:"file_path"`
+	Op           int    `json:"op"`
+
+	TotalBytes     int64 `json:"total_bytes"`
+	CompletedBytes int64 `json:"completed_bytes"`
+
+	// Started is set once the operation is dispatched to gosdk
+	Started   time.Time `json:"started"`
+	FirstByte time.Time `json:"first_byte"`
+	Finished  time.Time `json:"finished"`
+	Failed    bool      `json:"failed"`
+}
+
+// TimeToFirstByte is zero when no progress was reported
+func (m *OperationMetrics) TimeToFirstByte() time.Duration {
+	if m.Started.IsZero() || m.FirstByte.IsZero() {
+		return 0
+	}
+	return m.FirstByte.Sub(m.Started)
+}
+
+// Duration is zero while the operation is running
+func (m *OperationMetrics) Duration() time.Duration {
+	if m.Started.IsZero() || m.Finished.IsZero() {
+		return 0
+	}
+	return m.Finished.Sub(m.Started)
+}
+
+// Throughput returns completed bytes per second of a finished operation
+func (m *OperationMetrics) Throughput() float64 {
+	duration := m.Duration()
+	if duration <= 0 {
+		return 0
+	}
+	return float64(m.CompletedBytes) / duration.Seconds()
+}
+
+// ErrorEvent is an error reported for a file, in order of arrival
+type ErrorEvent struct {
+	Time     time.Time `json:"time"`
+	FilePath string    `json:"file_path"`
+	Op       int       `json:"op"`
+	Err      string    `json:"error"`
+}
+
+// Operation returns metrics of the file, or nil when the file was not reported
+func (m *TransferMetrics) Operation(filePath string, op int) *OperationMetrics {
+	for _, operation := range m.Operations {
+		if operation.FilePath == filePath && operation.Op == op {
+			return operation
+		}
+	}
+	return nil
+}
+
+// CompletedBytes sums completed bytes of every operation
+func (m *TransferMetrics) CompletedBytes() int64 {
+	var total int64
+	for _, operation := range m.Operations {
+		total += operation.CompletedBytes
+	}
+	return total
+}
+
+// newStatusCallback creates callback which is done once per file, or once the repair is completed
+func newStatusCallback(wg *sync.WaitGroup, isRepair bool) *StatusCallback {
+	return &StatusCallback{
+		wg:       wg,
+		isRepair: isRepair,
+	}
+}
+
+// operation returns metrics of the file, the caller holds cb.mu
+func (cb *StatusCallback) operation(allocationID, filePath string, op int) *OperationMetrics {
+	key := operationKey{filePath: filePath, op: op}
+	if metrics, ok := cb.operations[key]; ok {
+		return metrics
+	}
+
+	if cb.operations == nil {
+		cb.operations = make(map[operationKey]*OperationMetrics)
+	}
+	metrics := &OperationMetrics{
+		AllocationID: allocationID,
+		FilePath:     filePath,
+		Op:           op,
+		Started:      cb.dispatched,
+	}
+	cb.operations[key] = metrics
+	cb.order = append(cb.order, key)
+	return metrics
+}
+
+// dispatch marks the time the operations of the callback are handed to gosdk
+func (cb *StatusCallback) dispatch() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.dispatched = time.Now()
+}
+
+// Metrics returns a copy of metrics collected so far
+func (cb *StatusCallback) Metrics() *TransferMetrics {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	metrics := &TransferMetrics{
+		FilesRepaired: cb.filesRepaired,
+		Errors:        append([]*ErrorEvent(nil), cb.errors...),
+	}
+	for _, key := range cb.order {
+		operation := *cb.operations[key]
+		metrics.Operations = append(metrics.Operations, &operation)
+	}
+
+	for _, upload := range cb.uploads {
+		for pos, status := range upload.blobbers {
+			if status == nil || pos >= len(cb.blobbers) {
+				continue
+			}
+			if metrics.BlobberBytes == nil {
+				metrics.BlobberBytes = make(map[string]int64, len(cb.blobbers))
+			}
+			metrics.BlobberBytes[cb.blobbers[pos]] += status.UploadLength
+		}
+	}
+	return metrics
+}
+
+type operationKey struct {
+	filePath string
+	op       int
+}
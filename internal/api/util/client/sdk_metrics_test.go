@@ -0,0 +1,38 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferMetrics(t *testing.T) {
+	allocation := &sdk.Allocation{Blobbers: []*blockchain.StorageNode{{ID: "blobber0"}, {ID: "blobber1"}}}
+	cb := newStatusCallback(nil, false).withBlobbers(allocation)
+	cb.dispatch()
+
+	blobbers := []*sdk.UploadBlobberStatus{{}, {}}
+	(&uploadProgressStorer{cb: cb, filePath: "/first.txt"}).Save(sdk.UploadProgress{Blobbers: blobbers})
+	// gosdk counts bytes in the statuses it passed to Save
+	blobbers[0].UploadLength = 512
+	blobbers[1].UploadLength = 256
+	cb.Completed("allocation", "/first.txt", "first.txt", "", 1024, 0)
+
+	cb.InProgress("allocation", "/second.txt", 0, 100, nil)
+	cb.Completed("allocation", "/second.txt", "second.txt", "", 200, 0)
+
+	metrics := cb.Metrics()
+	require.Equal(t, map[string]int64{"blobber0": 512, "blobber1": 256}, metrics.BlobberBytes)
+
+	first := metrics.Operation("/first.txt", 0)
+	require.False(t, first.Started.IsZero())
+	require.Zero(t, first.TimeToFirstByte(), "time to first byte should be unknown without progress")
+	require.EqualValues(t, 1024, first.CompletedBytes)
+
+	second := metrics.Operation("/second.txt", 0)
+	require.Equal(t, first.Started, second.Started, "operations should start when they are dispatched")
+	require.False(t, second.FirstByte.IsZero())
+	require.LessOrEqual(t, second.TimeToFirstByte(), second.Duration())
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// uploadProgress is progress of a single upload reported by gosdk
+type uploadProgress struct {
+	// blobbers are shared with gosdk, which counts bytes sent to every blobber in them
+	blobbers []*sdk.UploadBlobberStatus
+
+	// mask has a bit of every blobber which took every chunk so far, in order of allocation blobbers
+	mask   zboxutil.Uint128
+	masked bool
+}
+
+// uploadProgressStorer passes progress of an upload to StatusCallback. Progress is not stored,
+// so uploads are not resumed from an earlier run.
+type uploadProgressStorer struct {
+	cb       *StatusCallback
+	filePath string
+}
+
+func (s *uploadProgressStorer) Load(string) *sdk.UploadProgress {
+	return nil
+}
+
+func (s *uploadProgressStorer) Save(progress sdk.UploadProgress) {
+	s.cb.mu.Lock()
+	defer s.cb.mu.Unlock()
+	s.cb.upload(s.filePath).blobbers = progress.Blobbers
+}
+
+// Update is called by gosdk once a chunk is accepted, possibly out of order. Blobbers are only
+// removed from the mask, so masks are intersected.
+func (s *uploadProgressStorer) Update(_ string, _ int, mask zboxutil.Uint128) {
+	s.cb.mu.Lock()
+	defer s.cb.mu.Unlock()
+
+	upload := s.cb.upload(s.filePath)
+	if upload.masked {
+		mask = upload.mask.And(mask)
+	}
+	upload.mask = mask
+	upload.masked = true
+}
+
+func (s *uploadProgressStorer) Remove(string) error {
+	return nil
+}
+
+// withBlobbers makes the callback record status and bytes of every blobber of the allocation in uploads
+func (cb *StatusCallback) withBlobbers(allocation *sdk.Allocation) *StatusCallback {
+	for _, blobber := range allocation.Blobbers {
+		cb.blobbers = append(cb.blobbers, blobber.ID)
+	}
+	return cb
+}
+
+// uploadOption returns option which reports progress of the upload of the file to the callback
+func (cb *StatusCallback) uploadOption(filePath string) sdk.ChunkedUploadOption {
+	return sdk.WithProgressStorer(&uploadProgressStorer{cb: cb, filePath: filePath})
+}
+
+// upload returns progress of the upload of the file, the caller holds cb.mu
+func (cb *StatusCallback) upload(filePath string) *uploadProgress {
+	if upload, ok := cb.uploads[filePath]; ok {
+		return upload
+	}
+	if cb.uploads == nil {
+		cb.uploads = make(map[string]*uploadProgress)
+	}
+	upload := &uploadProgress{}
+	cb.uploads[filePath] = upload
+	return upload
+}
+
+// BlobberErrors returns errors of blobbers which were dropped from an upload, keyed by blobber id
+func (cb *StatusCallback) BlobberErrors() map[string]error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	filePaths := make([]string, 0, len(cb.uploads))
+	for filePath := range cb.uploads {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	errs := make(map[string]error)
+	for _, filePath := range filePaths {
+		upload := cb.uploads[filePath]
+		if !upload.masked {
+			continue
+		}
+		for pos, blobberID := range cb.blobbers {
+			if _, ok := errs[blobberID]; ok {
+				continue
+			}
+			if upload.mask.And(zboxutil.NewUint128(1).Lsh(uint64(pos))).Equals64(0) {
+				errs[blobberID] = fmt.Errorf("%w: %s", ErrBlobberDropped, filePath)
+			}
+		}
+	}
+	return errs
+}
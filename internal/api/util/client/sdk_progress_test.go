@@ -0,0 +1,36 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadProgress(t *testing.T) {
+	allocation := &sdk.Allocation{Blobbers: []*blockchain.StorageNode{{ID: "blobber0"}, {ID: "blobber1"}, {ID: "blobber2"}}}
+	cb := newStatusCallback(nil, false).withBlobbers(allocation)
+
+	storer := &uploadProgressStorer{cb: cb, filePath: "/file.txt"}
+	require.Nil(t, storer.Load("id"))
+	storer.Save(sdk.UploadProgress{Blobbers: []*sdk.UploadBlobberStatus{{}, {}, {}}})
+	require.Empty(t, cb.BlobberErrors(), "blobbers should not fail before a chunk is accepted")
+
+	// chunks are reported out of order, blobber1 is dropped after the first chunk
+	storer.Update("id", 2, zboxutil.NewUint128(0b101))
+	storer.Update("id", 1, zboxutil.NewUint128(0b111))
+	require.NoError(t, storer.Remove("id"))
+
+	errs := cb.BlobberErrors()
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs["blobber1"], ErrBlobberDropped)
+	require.ErrorContains(t, errs["blobber1"], "/file.txt")
+
+	sdkErr := newSDKError("multi operation", "allocation", allocation, nil, cb)
+	require.ErrorIs(t, sdkErr, ErrSDKOperation)
+	require.Len(t, sdkErr.Blobbers, 3)
+	require.Len(t, sdkErr.FailedBlobbers(), 1)
+	require.Equal(t, "blobber1", sdkErr.FailedBlobbers()[0].ID)
+}
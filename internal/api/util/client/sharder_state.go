@@ -0,0 +1,356 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// Contains limits of reading sharder state pinned to a single round
+const (
+	stateReadAttempts = 5
+	stateReadBackoff  = 200 * time.Millisecond
+)
+
+// FieldDiff describes a single JSON field which differs from the reference sharder
+type FieldDiff struct {
+	// Path is a dot separated path to the field, array indexes are written as [i]
+	Path     string
+	Expected interface{}
+	Actual   interface{}
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: expected %v, got %v", d.Path, d.Expected, d.Actual)
+}
+
+// SharderState contains response of a single sharder queried by one of Compare* methods
+type SharderState struct {
+	URL string
+
+	// FinalizedRound is the latest finalized round reported by this sharder
+	FinalizedRound int64
+
+	StatusCode int
+	Body       []byte
+	Err        error
+
+	// Diff is empty for sharders which agree with the reference sharder
+	Diff []FieldDiff
+}
+
+// Lagging reports whether the sharder has not finalized the round the comparison is pinned to
+func (s *SharderState) Lagging(round int64) bool {
+	return s.FinalizedRound < round
+}
+
+// StateComparison contains responses of every healthy sharder for the same request.
+// Every sharder is compared against the first one which finalized Round, no majority is taken.
+// Sharders which stayed behind Round are reported by Lagging instead of Divergent, as their state is older.
+type StateComparison struct {
+	// Round is the finalized round the state was read at, it is not older than the latest finalized block
+	// of the network when the comparison started
+	Round int64
+
+	Reference string
+	Sharders  []*SharderState
+}
+
+// Divergent returns sharders which finalized Round and disagree with the reference sharder
+func (c *StateComparison) Divergent() []*SharderState {
+	var result []*SharderState
+	for _, sharder := range c.Sharders {
+		if len(sharder.Diff) > 0 && !sharder.Lagging(c.Round) {
+			result = append(result, sharder)
+		}
+	}
+	return result
+}
+
+// Lagging returns sharders which have not finalized Round, their differences are not a divergence
+func (c *StateComparison) Lagging() []*SharderState {
+	var result []*SharderState
+	for _, sharder := range c.Sharders {
+		if sharder.Lagging(c.Round) {
+			result = append(result, sharder)
+		}
+	}
+	return result
+}
+
+// Diverged reports whether any sharder disagrees with the reference sharder
+func (c *StateComparison) Diverged() bool {
+	return len(c.Divergent()) > 0
+}
+
+func (c *StateComparison) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "round %d, reference sharder %s", c.Round, c.Reference)
+	for _, sharder := range c.Divergent() {
+		fmt.Fprintf(&builder, "\n%s diverged:", sharder.URL)
+		for _, diff := range sharder.Diff {
+			fmt.Fprintf(&builder, "\n\t%s", diff)
+		}
+	}
+	for _, sharder := range c.Lagging() {
+		fmt.Fprintf(&builder, "\n%s is lagging at round %d with %d differences", sharder.URL, sharder.FinalizedRound, len(sharder.Diff))
+	}
+	return builder.String()
+}
+
+// CompareSCState queries /v1/scstate/get on every healthy sharder and reports any that disagree
+func (c *APIClient) CompareSCState(t *test.SystemTest, scAddress, key string) (*StateComparison, error) {
+	return c.compareSharders(t,
+		NewURLBuilder().SetPath(SCStateGet),
+		&model.ExecutionRequest{
+			FormData: map[string]string{
+				"sc_address": scAddress,
+				"key":        key,
+			},
+		},
+		HttpPOSTMethod)
+}
+
+// CompareBalance queries /v1/client/get/balance on every healthy sharder and reports any that disagree.
+// Round of the balance is not compared, as it is reported by FinalizedRound of every sharder.
+func (c *APIClient) CompareBalance(t *test.SystemTest, clientID string) (*StateComparison, error) {
+	return c.compareSharders(t,
+		NewURLBuilder().SetPath(ClientGetBalance).AddParams("client_id", clientID),
+		&model.ExecutionRequest{},
+		HttpGETMethod,
+		"round")
+}
+
+// CompareAllocation queries storage smart contract allocation on every healthy sharder and reports any that disagree
+func (c *APIClient) CompareAllocation(t *test.SystemTest, allocationID string) (*StateComparison, error) {
+	return c.compareSharders(t,
+		NewURLBuilder().
+			SetPath(SCRestGetAllocation).
+			SetPathVariable("sc_address", StorageSmartContractAddress).
+			AddParams("allocation", allocationID),
+		&model.ExecutionRequest{},
+		HttpGETMethod)
+}
+
+// compareSharders reads the state of every sharder between two reads of its latest finalized round. Sharders do not
+// serve state of a past round, so the reads are repeated until every sharder reports the same round before and after
+// its state was read, and that round is not older than the latest finalized block of the network.
+func (c *APIClient) compareSharders(t *test.SystemTest, urlBuilder *URLBuilder, executionRequest *model.ExecutionRequest, method int, ignoredPaths ...string) (*StateComparison, error) {
+	sharders := c.serviceProviders(SharderServiceProvider)
+
+	latestFinalizedBlock, _, err := c.V1BlockGetLatestFinalizedBlock(t, HttpOkStatus)
+	if err != nil {
+		return nil, err
+	}
+	if latestFinalizedBlock == nil {
+		return nil, fmt.Errorf("%w: latest finalized block", ErrGetFromResource)
+	}
+
+	executionRequest.RequiredStatusCode = HttpOkStatus
+
+	var (
+		pinned    map[string]int64
+		responses map[string]*NodeResponse
+	)
+	for attempt := 1; ; attempt++ {
+		before, err := c.sharderRounds(t, sharders)
+		if err != nil {
+			return nil, err
+		}
+		result, err := c.executeForEachServiceProvider(context.Background(), t, urlBuilder, executionRequest, method, sharders, 0)
+		if err != nil {
+			return nil, err
+		}
+		after, err := c.sharderRounds(t, sharders)
+		if err != nil {
+			return nil, err
+		}
+
+		pinned, responses = after, make(map[string]*NodeResponse)
+		for _, response := range result.Responses {
+			responses[response.URL] = response
+		}
+
+		stable, aligned := true, true
+		for _, sharder := range sharders {
+			stable = stable && before[sharder] == after[sharder]
+			aligned = aligned && after[sharder] == after[sharders[0]] && after[sharder] >= latestFinalizedBlock.Round
+		}
+		if stable && aligned {
+			break
+		}
+		if attempt == stateReadAttempts {
+			if !stable {
+				return nil, fmt.Errorf("%w: sharders finalized new rounds during %d state reads", ErrStateNotPinned, attempt)
+			}
+			break
+		}
+		time.Sleep(stateReadBackoff)
+	}
+
+	comparison := &StateComparison{Round: latestFinalizedBlock.Round}
+	for _, round := range pinned {
+		comparison.Round = max(comparison.Round, round)
+	}
+
+	var reference *SharderState
+	for _, sharder := range sharders {
+		state := &SharderState{URL: sharder, FinalizedRound: pinned[sharder]}
+		if response, ok := responses[sharder]; ok {
+			state.StatusCode = response.StatusCode
+			state.Body = response.Body
+			state.Err = response.Err
+		}
+		comparison.Sharders = append(comparison.Sharders, state)
+
+		if reference == nil && !state.Lagging(comparison.Round) {
+			reference = state
+			comparison.Reference = sharder
+		}
+	}
+	if reference == nil {
+		return nil, fmt.Errorf("%w: no sharder finalized round %d", ErrStateNotPinned, comparison.Round)
+	}
+
+	for _, state := range comparison.Sharders {
+		if state == reference {
+			continue
+		}
+		for _, diff := range diffSharderStates(reference, state) {
+			if !slices.Contains(ignoredPaths, diff.Path) {
+				state.Diff = append(state.Diff, diff)
+			}
+		}
+	}
+
+	return comparison, nil
+}
+
+// sharderRounds returns the latest finalized round of every sharder, all of them have to report it
+func (c *APIClient) sharderRounds(t *test.SystemTest, sharders []string) (map[string]int64, error) {
+	result, err := c.executeForEachServiceProvider(context.Background(), t,
+		NewURLBuilder().SetPath(GetLatestFinalizedBlock),
+		&model.ExecutionRequest{
+			Dst:                new(*model.LatestFinalizedBlock),
+			RequiredStatusCode: HttpOkStatus,
+		},
+		HttpGETMethod, sharders, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := make(map[string]int64, len(sharders))
+	for _, response := range result.Responses {
+		block, ok := response.Decoded.(**model.LatestFinalizedBlock)
+		switch {
+		case response.Err != nil:
+			return nil, fmt.Errorf("%w: latest finalized block of sharder %s: %w", ErrGetFromResource, response.URL, response.Err)
+		case !ok || *block == nil:
+			return nil, fmt.Errorf("%w: latest finalized block of sharder %s", ErrGetFromResource, response.URL)
+		}
+		rounds[response.URL] = (*block).Round
+	}
+	for _, sharder := range sharders {
+		if _, ok := rounds[sharder]; !ok {
+			return nil, fmt.Errorf("%w: latest finalized block of sharder %s", ErrGetFromResource, sharder)
+		}
+	}
+	return rounds, nil
+}
+
+func diffSharderStates(expected, actual *SharderState) []FieldDiff {
+	if expected.StatusCode != actual.StatusCode {
+		return []FieldDiff{{Path: "status_code", Expected: expected.StatusCode, Actual: actual.StatusCode}}
+	}
+	if (expected.Err == nil) != (actual.Err == nil) {
+		return []FieldDiff{{Path: "error", Expected: expected.Err, Actual: actual.Err}}
+	}
+
+	expectedValue, err := decodeJSON(expected.Body)
+	if err != nil {
+		expectedValue = string(expected.Body)
+	}
+	actualValue, err := decodeJSON(actual.Body)
+	if err != nil {
+		actualValue = string(actual.Body)
+	}
+
+	return diffJSON("", expectedValue, actualValue)
+}
+
+func decodeJSON(body []byte) (interface{}, error) {
+	var result interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	err := decoder.Decode(&result)
+	return result, err
+}
+
+// diffJSON returns paths of every leaf which differs between two decoded JSON values
+func diffJSON(path string, expected, actual interface{}) []FieldDiff {
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make(map[string]struct{})
+		for key := range expectedValue {
+			keys[key] = struct{}{}
+		}
+		for key := range actualValue {
+			keys[key] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+
+		var result []FieldDiff
+		for _, key := range sortedKeys {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			result = append(result, diffJSON(fieldPath, expectedValue[key], actualValue[key])...)
+		}
+		return result
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+
+		var result []FieldDiff
+		for i := 0; i < len(expectedValue) || i < len(actualValue); i++ {
+			var expectedItem, actualItem interface{}
+			if i < len(expectedValue) {
+				expectedItem = expectedValue[i]
+			}
+			if i < len(actualValue) {
+				actualItem = actualValue[i]
+			}
+			result = append(result, diffJSON(fmt.Sprintf("%s[%d]", path, i), expectedItem, actualItem)...)
+		}
+		return result
+	}
+
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+	if path == "" {
+		path = "body"
+	}
+	return []FieldDiff{{Path: path, Expected: expected, Actual: actual}}
+}
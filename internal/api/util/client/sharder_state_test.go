@@ -0,0 +1,99 @@
+package client_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharderState(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	network, apiClient, newWallet := newNetwork(t)
+
+	t.RunSequentially("Sharder state divergence should be reported field by field", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+
+		comparison, err := apiClient.CompareBalance(t, wallet.Id)
+		require.NoError(t, err)
+		require.False(t, comparison.Diverged(), comparison.String())
+
+		divergent := network.Sharders[1]
+		divergent.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(model.ClientGetBalanceResponse{Balance: 1, Nonce: 0})
+		})
+		defer divergent.Handle(client.ClientGetBalance, nil)
+
+		comparison, err = apiClient.CompareBalance(t, wallet.Id)
+		require.NoError(t, err)
+		require.Equal(t, network.Sharders[0].URL(), comparison.Reference)
+		require.Len(t, comparison.Divergent(), 1)
+		require.Equal(t, divergent.URL(), comparison.Divergent()[0].URL)
+		require.Len(t, comparison.Divergent()[0].Diff, 1)
+		require.Equal(t, "balance", comparison.Divergent()[0].Diff[0].Path)
+	})
+
+	t.RunSequentially("Lagging sharders should not be reported as divergent", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+		network.AdvanceRounds(10)
+
+		lagging := network.Sharders[0]
+		lagging.Handle(client.GetLatestFinalizedBlock, func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(model.LatestFinalizedBlock{Round: 1})
+		})
+		defer lagging.Handle(client.GetLatestFinalizedBlock, nil)
+		lagging.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(model.ClientGetBalanceResponse{Balance: 1, Nonce: 0})
+		})
+		defer lagging.Handle(client.ClientGetBalance, nil)
+
+		comparison, err := apiClient.CompareBalance(t, wallet.Id)
+		require.NoError(t, err)
+		require.False(t, comparison.Diverged(), comparison.String())
+		require.Equal(t, network.Sharders[1].URL(), comparison.Reference)
+		require.Len(t, comparison.Lagging(), 1)
+		require.Equal(t, lagging.URL(), comparison.Lagging()[0].URL)
+		require.Contains(t, comparison.String(), "lagging")
+	})
+
+	t.RunSequentially("State should be read again when a sharder finalizes a round meanwhile", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+
+		reads := 0
+		moving := network.Sharders[1]
+		moving.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+			reads++
+			if reads == 1 {
+				network.AdvanceRounds(1)
+			}
+			_ = json.NewEncoder(w).Encode(model.ClientGetBalanceResponse{Balance: *tokenomics.IntToZCN(100)})
+		})
+		defer moving.Handle(client.ClientGetBalance, nil)
+
+		comparison, err := apiClient.CompareBalance(t, wallet.Id)
+		require.NoError(t, err)
+		require.Equal(t, 2, reads)
+		require.Empty(t, comparison.Lagging(), comparison.String())
+		for _, sharder := range comparison.Sharders {
+			require.Equal(t, comparison.Round, sharder.FinalizedRound)
+		}
+	})
+
+	t.RunSequentially("Failed round lookup should be reported as an error", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+
+		broken := network.Sharders[1]
+		broken.Handle(client.GetLatestFinalizedBlock, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		defer broken.Handle(client.GetLatestFinalizedBlock, nil)
+
+		_, err := apiClient.CompareBalance(t, wallet.Id)
+		require.ErrorContains(t, err, broken.URL())
+	})
+}
@@ -0,0 +1,342 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
+	resty "github.com/go-resty/resty/v2"
+)
+
+// DefaultConfirmationTimeout limits waiting for confirmation of submitted transactions
+const DefaultConfirmationTimeout = 2 * time.Minute
+
+// FeeStrategy decides fee of a transaction being built. Request is complete except for the fee,
+// hash and signature.
+type FeeStrategy func(t *test.SystemTest, c *APIClient, request *model.TransactionPutRequest) (int64, error)
+
+// EstimatedFee asks miners to estimate the fee of the transaction
+func EstimatedFee(t *test.SystemTest, c *APIClient, request *model.TransactionPutRequest) (int64, error) {
+	resp, err := c.executeForAllServiceProviders(
+		t,
+		NewURLBuilder().SetPath(TransactionFeeGet),
+		&model.ExecutionRequest{
+			Body:               request,
+			RequiredStatusCode: HttpOkStatus,
+		},
+		HttpPOSTMethod,
+		MinerServiceProvider)
+	if err != nil {
+		return 0, err
+	}
+
+	var fee struct {
+		Fee int64 `json:"fee"`
+	}
+	if err := json.Unmarshal(resp.Body(), &fee); err != nil {
+		return 0, err
+	}
+	return fee.Fee, nil
+}
+
+// ZeroFee is used for faucet pours, which are not charged
+func ZeroFee(*test.SystemTest, *APIClient, *model.TransactionPutRequest) (int64, error) {
+	return 0, nil
+}
+
+// FixedFee always uses the given fee in SAS, it does not require access to the network
+func FixedFee(fee int64) FeeStrategy {
+	return func(*test.SystemTest, *APIClient, *model.TransactionPutRequest) (int64, error) {
+		return fee, nil
+	}
+}
+
+// TransactionBuilder builds, signs and submits a single transaction of a wallet.
+//
+// Unless pinned, nonce is reserved from NonceManager of the client. Fee defaults
+// to ZeroFee for faucet pours and EstimatedFee otherwise.
+type TransactionBuilder struct {
+	client *APIClient
+	wallet *model.Wallet
+	ctx    context.Context
+
+	toClientID   string
+	txnType      int
+	data         model.TransactionData
+	value        int64
+	nonce        int
+	fee          FeeStrategy
+	creationDate int64
+	miners       []string
+}
+
+// NewTransaction returns builder of a plain transfer of TxValue tokens from the wallet
+func (c *APIClient) NewTransaction(wallet *model.Wallet) *TransactionBuilder {
+	return &TransactionBuilder{
+		client:  c,
+		wallet:  wallet,
+		ctx:     context.Background(),
+		txnType: SendTxType,
+		value:   *TxValue,
+	}
+}
+
+// To sets receiver of the transaction
+func (b *TransactionBuilder) To(clientID string) *TransactionBuilder {
+	b.toClientID = clientID
+	return b
+}
+
+// SmartContract turns the transaction into a call of the given smart contract function
+func (b *TransactionBuilder) SmartContract(address string, data model.TransactionData) *TransactionBuilder {
+	b.toClientID = address
+	b.txnType = SCTxType
+	b.data = data
+	return b
+}
+
+// Value sets transferred or locked amount in SAS
+func (b *TransactionBuilder) Value(value int64) *TransactionBuilder {
+	b.value = value
+	return b
+}
+
+// Nonce pins nonce of the transaction, e.g. to send a future or an already used nonce
+func (b *TransactionBuilder) Nonce(nonce int) *TransactionBuilder {
+	b.nonce = nonce
+	return b
+}
+
+// Fee sets fee strategy of the transaction
+func (b *TransactionBuilder) Fee(fee FeeStrategy) *TransactionBuilder {
+	b.fee = fee
+	return b
+}
+
+// CreationDate overrides creation date of the transaction, which is the current time by default
+func (b *TransactionBuilder) CreationDate(creationDate int64) *TransactionBuilder {
+	b.creationDate = creationDate
+	return b
+}
+
+// Context bounds submission of the transaction, requests to miners are cancelled with ctx
+func (b *TransactionBuilder) Context(ctx context.Context) *TransactionBuilder {
+	b.ctx = ctx
+	return b
+}
+
+// Miners limits miners the transaction is submitted to, all healthy miners are used by default
+func (b *TransactionBuilder) Miners(miners ...string) *TransactionBuilder {
+	b.miners = miners
+	return b
+}
+
+// Build hashes and signs the transaction. It does not need access to the network when both nonce
+// and fee are fixed. Reserved nonce of a transaction which is not submitted must be released
+// with NonceManager.Release.
+func (b *TransactionBuilder) Build(t *test.SystemTest) (*model.TransactionPutRequest, error) {
+	data, err := json.Marshal(b.data)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := b.nonce
+	if nonce == 0 {
+		if nonce, err = b.client.nonces.Reserve(t, b.wallet); err != nil {
+			return nil, err
+		}
+	}
+
+	request := &model.TransactionPutRequest{
+		ClientId:         b.wallet.Id,
+		PublicKey:        b.wallet.PublicKey,
+		ToClientId:       b.toClientID,
+		TransactionNonce: nonce,
+		TxnOutputHash:    TxOutput,
+		TransactionValue: b.value,
+		TransactionType:  b.txnType,
+		TransactionData:  string(data),
+		CreationDate:     b.creationDate,
+		Version:          TxVersion,
+	}
+
+	if request.CreationDate == 0 {
+		request.CreationDate = time.Now().Unix()
+	}
+
+	fee := b.fee
+	if fee == nil {
+		fee = EstimatedFee
+		if b.data.Name == "pour" {
+			fee = ZeroFee
+		}
+	}
+	request.TransactionFee, err = fee(t, b.client, request)
+	if err != nil {
+		b.release(nonce)
+		return nil, fmt.Errorf("transaction fee: %w", err)
+	}
+
+	request.Hash = crypto.Sha3256([]byte(fmt.Sprintf("%d:%d:%s:%s:%d:%s",
+		request.CreationDate,
+		request.TransactionNonce,
+		request.ClientId,
+		request.ToClientId,
+		request.TransactionValue,
+		crypto.Sha3256([]byte(request.TransactionData)))))
+
+	crypto.SignTransaction(t, request, b.wallet.Keys)
+
+	return request, nil
+}
+
+func (b *TransactionBuilder) release(nonce int) {
+	if b.nonce == 0 {
+		b.client.nonces.Release(b.wallet, nonce)
+	}
+}
+
+// Submit builds and submits the transaction. When nonce is not pinned and miners reject it,
+// the reservation is released, wallet nonce is synced with the network and the transaction is rebuilt.
+func (b *TransactionBuilder) Submit(t *test.SystemTest, requiredStatusCode int) (*model.TransactionPutResponse, *resty.Response, error) {
+	var (
+		transactionPutResponse *model.TransactionPutResponse
+		resp                   *resty.Response
+		err                    error
+	)
+
+	for retry := 0; retry < 3; retry++ {
+		var request *model.TransactionPutRequest
+		request, err = b.Build(t)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		transactionPutResponse, resp, err = b.client.SubmitTransaction(b.ctx, t, request, requiredStatusCode, b.miners...)
+		if resp == nil || resp.StatusCode() != HttpOkStatus {
+			b.release(request.TransactionNonce)
+		} else if b.nonce == 0 {
+			b.client.nonces.Accept(b.wallet, request.TransactionNonce)
+		}
+
+		if err != nil && b.nonce == 0 && strings.Contains(err.Error(), "invalid transaction nonce") {
+			if err := b.client.nonces.Sync(t, b.wallet); err != nil {
+				return transactionPutResponse, resp, err
+			}
+			continue
+		}
+
+		return transactionPutResponse, resp, err
+	}
+
+	return transactionPutResponse, resp, fmt.Errorf("%w: %w", ErrTransactionNonce, err)
+}
+
+// SubmitTransaction submits an already signed transaction to the given miners or to all healthy ones
+func (c *APIClient) SubmitTransaction(ctx context.Context, t *test.SystemTest, request *model.TransactionPutRequest, requiredStatusCode int, miners ...string) (*model.TransactionPutResponse, *resty.Response, error) {
+	var transactionPutResponse *model.TransactionPutResponse
+
+	if len(miners) == 0 {
+		miners = c.serviceProviders(MinerServiceProvider)
+	}
+
+	resp, err := c.executeForGivenServiceProviders(
+		ctx,
+		t,
+		NewURLBuilder().SetPath(TransactionPut),
+		&model.ExecutionRequest{
+			Body:               request,
+			Dst:                &transactionPutResponse,
+			RequiredStatusCode: requiredStatusCode,
+		},
+		HttpPOSTMethod,
+		miners)
+
+	if transactionPutResponse == nil {
+		transactionPutResponse = new(model.TransactionPutResponse)
+	}
+	transactionPutResponse.Request = *request
+
+	return transactionPutResponse, resp, err
+}
+
+// SubmitBatch submits transactions of a single wallet with sequential nonces and waits until all of them
+// are confirmed. Pinned nonces are ignored, as nonces of the batch are reserved from NonceManager,
+// the given builders are left unchanged.
+func (c *APIClient) SubmitBatch(ctx context.Context, t *test.SystemTest, timeout time.Duration, transactions ...*TransactionBuilder) ([]*model.TransactionGetConfirmationResponse, error) {
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	wallet := transactions[0].wallet
+	for _, transaction := range transactions {
+		if transaction.wallet != wallet {
+			return nil, ErrBatchWallet
+		}
+	}
+
+	hashes := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		unpinned := *transaction
+		unpinned.nonce = 0
+		request, err := unpinned.Build(t)
+		if err != nil {
+			return nil, err
+		}
+
+		// transactions are submitted one by one, so a rejected one does not leave later nonces in the pool
+		if _, _, err := c.SubmitTransaction(ctx, t, request, HttpOkStatus, transaction.miners...); err != nil {
+			c.nonces.Release(wallet, request.TransactionNonce)
+			return nil, fmt.Errorf("transaction with nonce %d: %w", request.TransactionNonce, err)
+		}
+		c.nonces.Accept(wallet, request.TransactionNonce)
+		hashes = append(hashes, request.Hash)
+	}
+
+	return c.WaitForConfirmations(ctx, t, timeout, hashes...)
+}
+
+// WaitForConfirmations polls sharders until every transaction is confirmed or the timeout passes.
+// Confirmations are returned in the order of the given hashes.
+func (c *APIClient) WaitForConfirmations(ctx context.Context, t *test.SystemTest, timeout time.Duration, hashes ...string) ([]*model.TransactionGetConfirmationResponse, error) {
+	confirmations := make([]*model.TransactionGetConfirmationResponse, len(hashes))
+
+	_, err := wait.Poll(ctx, t,
+		wait.Config{
+			Timeout:     timeout,
+			Backoff:     wait.Exponential(time.Second, 5*time.Second),
+			Description: fmt.Sprintf("confirmation of %d transactions", len(hashes)),
+		},
+		func(ctx context.Context) ([]string, bool, error) {
+			var pending []string
+			for i, hash := range hashes {
+				if confirmations[i] != nil {
+					continue
+				}
+
+				confirmation, err := c.transactionConfirmation(ctx, t, hash)
+				if err != nil || confirmation == nil {
+					pending = append(pending, hash)
+					continue
+				}
+				confirmations[i] = confirmation
+			}
+			return pending, len(pending) == 0, nil
+		})
+	if err != nil {
+		return confirmations, fmt.Errorf("%w: %w", ErrTransactionNotConfirmed, err)
+	}
+
+	return confirmations, nil
+}
+
+func (c *APIClient) transactionConfirmation(ctx context.Context, t *test.SystemTest, hash string) (*model.TransactionGetConfirmationResponse, error) {
+	transactionGetConfirmationResponse, _, err := CallWithContext(ctx, c, t, TransactionGetConfirmationEndpoint, model.TransactionGetConfirmationRequest{Hash: hash}, HttpOkStatus)
+	return transactionGetConfirmationResponse, err
+}
@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionBuilder(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	network, apiClient, newWallet := newNetwork(t)
+
+	t.RunSequentially("Offline signed transaction should be accepted", func(t *test.SystemTest) {
+		sender, receiver := newWallet(t), newWallet(t)
+
+		request, err := apiClient.NewTransaction(sender).
+			To(receiver.Id).
+			Value(*tokenomics.IntToZCN(1)).
+			Nonce(1).
+			Fee(client.FixedFee(mocknet.DefaultFee)).
+			Build(t)
+		require.NoError(t, err)
+		require.Equal(t, 1, request.TransactionNonce)
+
+		_, _, err = apiClient.SubmitTransaction(context.Background(), t, request, client.HttpOkStatus)
+		require.NoError(t, err)
+
+		confirmations, err := apiClient.WaitForConfirmations(context.Background(), t, client.DefaultConfirmationTimeout, request.Hash)
+		require.NoError(t, err)
+		require.Equal(t, client.TxSuccessfulStatus, confirmations[0].Status)
+
+		balance, _ := network.Balance(receiver.Id)
+		require.Equal(t, *tokenomics.IntToZCN(101), balance)
+	})
+
+	t.RunSequentially("Batch transactions should be sent with sequential nonces", func(t *test.SystemTest) {
+		sender, receiver := newWallet(t), newWallet(t)
+
+		var transactions []*client.TransactionBuilder
+		for i := 0; i < 3; i++ {
+			transactions = append(transactions, apiClient.NewTransaction(sender).To(receiver.Id))
+		}
+		pinned := apiClient.NewTransaction(sender).To(receiver.Id).Nonce(1)
+		transactions[0] = pinned
+
+		confirmations, err := apiClient.SubmitBatch(context.Background(), t, client.DefaultConfirmationTimeout, transactions...)
+		require.NoError(t, err)
+		require.Len(t, confirmations, 3)
+		for i, confirmation := range confirmations {
+			require.Equal(t, client.TxSuccessfulStatus, confirmation.Status)
+			require.Equal(t, i+1, confirmation.Transaction.TransactionNonce)
+		}
+		require.Equal(t, 3, sender.Nonce)
+
+		balance, nonce := network.Balance(sender.Id)
+		require.Equal(t, *tokenomics.IntToZCN(97)-3*mocknet.DefaultFee, balance)
+		require.Equal(t, int64(3), nonce)
+
+		request, err := pinned.Fee(client.FixedFee(mocknet.DefaultFee)).Build(t)
+		require.NoError(t, err)
+		require.Equal(t, 1, request.TransactionNonce, "batch should not change pinned nonce of the builder")
+
+		_, err = apiClient.SubmitBatch(context.Background(), t, client.DefaultConfirmationTimeout, apiClient.NewTransaction(sender), apiClient.NewTransaction(receiver))
+		require.ErrorIs(t, err, client.ErrBatchWallet)
+	})
+
+	t.RunSequentially("Transaction with nonce used by another client should be rebuilt", func(t *test.SystemTest) {
+		sender, receiver := newWallet(t), newWallet(t)
+
+		_, _, err := apiClient.NewTransaction(sender).To(receiver.Id).Submit(t, client.HttpOkStatus)
+		require.NoError(t, err)
+
+		// another client does not share nonce manager, as SDK or CLI used by the same test
+		_, _, err = client.NewAPIClient(network.URL()).NewTransaction(sender).To(receiver.Id).Value(2).Submit(t, client.HttpOkStatus)
+		require.NoError(t, err)
+
+		response, _, err := apiClient.NewTransaction(sender).To(receiver.Id).Submit(t, client.HttpOkStatus)
+		require.NoError(t, err)
+		require.Equal(t, 3, response.Request.TransactionNonce)
+
+		_, resp, err := apiClient.NewTransaction(sender).To(receiver.Id).Value(3).Nonce(1).Submit(t, client.HttpBadRequestStatus)
+		require.NoError(t, err)
+		require.Contains(t, string(resp.Body()), "invalid transaction nonce")
+	})
+	t.RunSequentially("Transaction rejected after retries should keep the last error", func(t *test.SystemTest) {
+		sender, receiver := newWallet(t), newWallet(t)
+
+		for _, miner := range network.Miners {
+			miner.Handle(client.TransactionPut, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": "invalid_request: invalid transaction nonce: expected 7, got 1"}`))
+			})
+			defer miner.Handle(client.TransactionPut, nil)
+		}
+
+		_, _, err := apiClient.NewTransaction(sender).To(receiver.Id).Submit(t, client.HttpOkStatus)
+		require.ErrorIs(t, err, client.ErrTransactionNonce)
+		require.ErrorContains(t, err, "expected 7, got 1")
+	})
+}
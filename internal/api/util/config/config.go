@@ -11,6 +11,7 @@ import (
 	"github.com/0chain/system_test/internal/api/model"
 	"github.com/0chain/system_test/internal/api/util/crypto"
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wallets"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3" //nolint
 )
@@ -38,6 +39,9 @@ type Config struct {
 	OwnerWalletMnemonics        string `yaml:"owner_wallet_mnemonics"`
 	DropboxAccessToken          string `yaml:"dropboxAccessToken"`
 	GdriveAccessToken           string `yaml:"gdriveAccessToken"`
+	NodeRequestTimeout          string `yaml:"node_request_timeout"`
+	ExecutionQuorum             int    `yaml:"execution_quorum"`
+	HealthCheckInterval         string `yaml:"health_check_interval"`
 }
 
 func Parse(configPath string) *Config {
@@ -83,7 +87,7 @@ func CreateFreeStorageMarker(
 	rawHash, err := hex.DecodeString(data)
 	require.Nil(t, err, "failed to decode hex %s", data)
 	require.NotNil(t, rawHash, "failed to decode hex %s", data)
-	secretKey := crypto.ToSecretKey(t, assignerWallet.ToCliModelWalletFile())
+	secretKey := crypto.ToSecretKey(t, wallets.FromSdk(assignerWallet).ToWalletFile())
 	marker.Signature = crypto.Sign(t, string(rawHash), secretKey)
 	marker.Assigner = assignerWallet.ClientID
 
@@ -55,6 +55,9 @@ type Signature interface {
 
 	Add(rhs Signature)
 
+	// Recover sets signature recovered from signature shares of the given ids
+	Recover(signatures []Signature, ids []ID) error
+
 	Verify(pk PublicKey, m string) bool
 }
 
@@ -1,7 +1,6 @@
 package crypto
 
 import (
-	"bytes"
 	"encoding/hex"
 	"fmt"
 	"time"
@@ -35,7 +34,7 @@ func NewHerumiScheme() *HerumiScheme {
 
 // GenerateKeys  generate fresh keys
 func (b0 *HerumiScheme) GenerateKeys() (*Wallet, error) {
-	return b0.generateKeys("0chain-client-split-key")
+	return b0.generateKeys(KeyDerivationPassword)
 }
 
 // GenerateKeysWithEth  generate fresh keys based on eth wallet
@@ -258,16 +257,16 @@ func (b0 *HerumiScheme) generateKeys(password string) (*Wallet, error) {
 
 	// Generate a Bip32 HD wallet for the mnemonic and a user supplied password
 	seed := bip39.NewSeed(b0.Mnemonic, password)
-	r := bytes.NewReader(seed)
-	BlsSignerInstance.SetRandFunc(r)
 
 	// New Wallet
 	w := &Wallet{}
 	w.Keys = make([]KeyPair, 1)
 
-	// Generate pair
+	// Generate pair, see DeriveKeys
 	sk := BlsSignerInstance.NewSecretKey()
-	sk.SetByCSPRNG()
+	if err := sk.SetLittleEndian(seed[:seedKeyLength]); err != nil {
+		return nil, err
+	}
 	w.Keys[0].PrivateKey = sk.SerializeToHexStr()
 	pub := sk.GetPublicKey()
 	w.Keys[0].PublicKey = pub.SerializeToHexStr()
@@ -280,8 +279,6 @@ func (b0 *HerumiScheme) generateKeys(password string) (*Wallet, error) {
 	w.Version = CryptoVersion
 	w.DateCreated = time.Now().Format(time.RFC3339)
 
-	// Revert the Random function to default
-	BlsSignerInstance.SetRandFunc(nil)
 	return w, nil
 }
 
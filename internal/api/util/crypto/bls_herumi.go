@@ -187,6 +187,28 @@ func (sg *herumiSignature) Add(rhs Signature) {
 	sg.Sign.Add(sg2.Sign)
 }
 
+func (sg *herumiSignature) Recover(signatures []Signature, ids []ID) error {
+	blsSignatures := make([]bls.Sign, len(signatures))
+	for i, it := range signatures {
+		s, ok := it.(*herumiSignature)
+		if !ok {
+			return errors.New("invalid herumi signature")
+		}
+		blsSignatures[i] = *s.Sign
+	}
+
+	blsIDs := make([]bls.ID, len(ids))
+	for i, it := range ids {
+		id, ok := it.(*herumiID)
+		if !ok {
+			return errors.New("invalid herumi id")
+		}
+		blsIDs[i] = id.ID
+	}
+
+	return sg.Sign.Recover(blsSignatures, blsIDs)
+}
+
 func (sg *herumiSignature) Verify(pk PublicKey, m string) bool {
 	pub, _ := pk.(*herumiPublicKey)
 
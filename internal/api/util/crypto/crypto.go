@@ -1,7 +1,6 @@
 package crypto
 
 import (
-	"bytes"
 	_ "crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -9,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/redact"
 	"github.com/0chain/system_test/internal/api/util/test"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	"github.com/herumi/bls-go-binary/bls"
@@ -37,34 +37,20 @@ func GenerateMnemonics(t *test.SystemTest) string {
 	require.NoError(t, err)
 	mnemonic, err := bip39.NewMnemonic(entropy) //nolint
 	require.NoError(t, err)
+	redact.AddSecret(mnemonic)
 	t.Logf("Generated mnemonic [%s]", mnemonic)
 
 	return mnemonic
 }
 
+// GenerateKeys derives keys of the mnemonic, see DeriveKeys
 func GenerateKeys(t *test.SystemTest, mnemonics string) *model.KeyPair {
-	defer handlePanic(t)
-	blsLock.Lock()
-	defer func() {
-		blsLock.Unlock()
-		bls.SetRandFunc(nil)
-	}()
-
-	seed := bip39.NewSeed(mnemonics, "0chain-client-split-key") //nolint
-	random := bytes.NewReader(seed)
-	bls.SetRandFunc(random)
-
-	var secretKey bls.SecretKey
-	secretKey.SetByCSPRNG()
+	_, keys, err := DefaultKeyStore.FromMnemonic(mnemonics)
+	require.NoError(t, err, "failed to derive keys from mnemonic")
 
-	publicKey := secretKey.GetPublicKey()
-	secretKeyHex := secretKey.SerializeToHexStr()
-	publicKeyHex := publicKey.SerializeToHexStr()
+	t.Logf("Generated public key [%s] and secret key [%s]", keys.PublicKey.SerializeToHexStr(), keys.PrivateKey.SerializeToHexStr())
 
-	t.Logf("Generated public key [%s] and secret key [%s]", publicKeyHex, secretKeyHex)
-	bls.SetRandFunc(nil)
-
-	return &model.KeyPair{PublicKey: *publicKey, PrivateKey: secretKey}
+	return keys
 }
 
 func Sha3256(src []byte) string {
@@ -99,22 +85,14 @@ func SignHashUsingSignatureScheme(hash, signatureScheme string, keys []*model.Ke
 }
 
 func ToSecretKey(t *test.SystemTest, wallet *climodel.WalletFile) *bls.SecretKey {
-	defer handlePanic(t)
-	blsLock.Lock()
-	defer blsLock.Unlock()
+	keys, err := DefaultKeyStore.FromWalletFile(wallet)
+	require.Nil(t, err, "failed to load keys of wallet file")
 
-	var sk bls.SecretKey
-	sk.SetByCSPRNG()
-	err := sk.DeserializeHexStr(wallet.Keys[0].PrivateKey)
-	require.Nil(t, err, "failed to serialize hex of private key")
-
-	return &sk
+	return &keys.PrivateKey
 }
 
 func Sign(t *test.SystemTest, data string, sk *bls.SecretKey) string {
 	defer handlePanic(t)
-	blsLock.Lock()
-	defer blsLock.Unlock()
 
 	sig := sk.Sign(data)
 
@@ -123,8 +101,6 @@ func Sign(t *test.SystemTest, data string, sk *bls.SecretKey) string {
 
 func SignHexString(t *test.SystemTest, data string, sk *bls.SecretKey) string {
 	defer handlePanic(t)
-	blsLock.Lock()
-	defer blsLock.Unlock()
 
 	hashToSign, err := hex.DecodeString(data)
 	require.NoError(t, err)
@@ -135,8 +111,6 @@ func SignHexString(t *test.SystemTest, data string, sk *bls.SecretKey) string {
 
 func SignTransaction(t *test.SystemTest, request *model.TransactionPutRequest, pair *model.KeyPair) {
 	defer handlePanic(t)
-	blsLock.Lock()
-	defer blsLock.Unlock()
 
 	hashToSign, err := hex.DecodeString(request.Hash)
 	require.NoError(t, err, "Error on hash")
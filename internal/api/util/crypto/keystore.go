@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/redact"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+	"github.com/herumi/bls-go-binary/bls"
+	"github.com/tyler-smith/go-bip39" //nolint
+)
+
+// Contains settings of key derivation, shared with zwallet and gosdk
+const (
+	KeyDerivationPassword = "0chain-client-split-key"
+
+	// seedKeyLength bytes of the seed are used as the little endian secret key, masked the same way
+	// bls.SecretKey.SetByCSPRNG masks bytes read from a seeded random function
+	seedKeyLength = 32
+)
+
+// DeriveKeys derives the key pair of a wallet from its mnemonic. It does not use the global BLS random
+// function, so it is safe to call from parallel tests.
+func DeriveKeys(mnemonic string) (*model.KeyPair, error) {
+	seed := bip39.NewSeed(mnemonic, KeyDerivationPassword) //nolint
+
+	var secretKey bls.SecretKey
+	if err := secretKey.SetLittleEndian(seed[:seedKeyLength]); err != nil {
+		return nil, err
+	}
+
+	return &model.KeyPair{PublicKey: *secretKey.GetPublicKey(), PrivateKey: secretKey}, nil
+}
+
+// ClientID returns id of the wallet with the given key pair
+func ClientID(keys *model.KeyPair) string {
+	return Sha3256(keys.PublicKey.Serialize())
+}
+
+// KeyStore caches key pairs of wallets by client id, so every client of a test uses the same keys
+// whether the wallet is created from a mnemonic or loaded from a wallet file
+type KeyStore struct {
+	mu         sync.RWMutex
+	keys       map[string]*model.KeyPair
+	byMnemonic map[string]string
+}
+
+// DefaultKeyStore is shared by API, SDK and CLI clients
+var DefaultKeyStore = NewKeyStore()
+
+func NewKeyStore() *KeyStore {
+	return &KeyStore{
+		keys:       make(map[string]*model.KeyPair),
+		byMnemonic: make(map[string]string),
+	}
+}
+
+// Get returns cached key pair of the client
+func (s *KeyStore) Get(clientID string) (*model.KeyPair, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys, ok := s.keys[clientID]
+	return keys, ok
+}
+
+// Put caches key pair of the client and registers its secret key for redaction
+func (s *KeyStore) Put(clientID string, keys *model.KeyPair) {
+	redact.AddSecret(keys.PrivateKey.SerializeToHexStr())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[clientID] = keys
+}
+
+// FromMnemonic returns client id and key pair of the mnemonic, keys are derived only once
+func (s *KeyStore) FromMnemonic(mnemonic string) (string, *model.KeyPair, error) {
+	s.mu.RLock()
+	clientID, ok := s.byMnemonic[mnemonic]
+	keys := s.keys[clientID]
+	s.mu.RUnlock()
+	if ok {
+		return clientID, keys, nil
+	}
+
+	keys, err := DeriveKeys(mnemonic)
+	if err != nil {
+		return "", nil, err
+	}
+	clientID = ClientID(keys)
+
+	redact.AddSecret(mnemonic)
+	s.Put(clientID, keys)
+
+	s.mu.Lock()
+	s.byMnemonic[mnemonic] = clientID
+	s.mu.Unlock()
+
+	return clientID, keys, nil
+}
+
+// FromWalletFile returns the first key pair of a zwallet wallet file. Keys of split wallets differ
+// from the keys of their client id, so they are returned without being cached.
+func (s *KeyStore) FromWalletFile(wallet *climodel.WalletFile) (*model.KeyPair, error) {
+	if len(wallet.Keys) == 0 {
+		return nil, fmt.Errorf("wallet [%s] has no keys", wallet.ClientID)
+	}
+
+	cached, ok := s.Get(wallet.ClientID)
+	if ok && cached.PublicKey.SerializeToHexStr() == wallet.Keys[0].PublicKey {
+		return cached, nil
+	}
+
+	var keys model.KeyPair
+	if err := keys.PrivateKey.DeserializeHexStr(wallet.Keys[0].PrivateKey); err != nil {
+		return nil, fmt.Errorf("failed to deserialize private key of wallet [%s]: %w", wallet.ClientID, err)
+	}
+	keys.PublicKey = *keys.PrivateKey.GetPublicKey()
+
+	if publicKey := wallet.Keys[0].PublicKey; publicKey != "" && publicKey != keys.PublicKey.SerializeToHexStr() {
+		return nil, fmt.Errorf("private key of wallet [%s] does not match its public key [%s]", wallet.ClientID, publicKey)
+	}
+
+	if ok {
+		redact.AddSecret(keys.PrivateKey.SerializeToHexStr())
+	} else {
+		s.Put(wallet.ClientID, &keys)
+	}
+	return &keys, nil
+}
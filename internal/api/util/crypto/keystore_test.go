@@ -0,0 +1,91 @@
+package crypto_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyStore(t *testing.T) {
+	// keys derived by zwallet, which seeds the global BLS random function with the mnemonic
+	vectors := []struct {
+		mnemonic, privateKey, clientID string
+	}{
+		{
+			mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			privateKey: "8f24f05b134c263621b60de16b6aef279c2783b7e38ba622221813b2bf5ed406",
+			clientID:   "9056517447c7cb83b67487da9d05d410eae25c547320ec5ee86903841b086cc8",
+		},
+		{
+			mnemonic:   "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			privateKey: "2a2cbb3a9524fde52b0dd809f4c711967cfceb53f59825e0efe0127d1c19eb0c",
+			clientID:   "d63ff7d892b3a88a3a91adbc95b89090616bffe985e3685f0eecbbf07435c0a4",
+		},
+	}
+
+	t.Run("derivation matches zwallet", func(t *testing.T) {
+		for _, vector := range vectors {
+			keys, err := crypto.DeriveKeys(vector.mnemonic)
+			require.NoError(t, err)
+			require.Equal(t, vector.privateKey, keys.PrivateKey.SerializeToHexStr())
+			require.Equal(t, vector.clientID, crypto.ClientID(keys))
+
+			scheme := crypto.NewHerumiScheme()
+			wallet, err := scheme.RecoverKeys(vector.mnemonic)
+			require.NoError(t, err)
+			require.Equal(t, vector.privateKey, wallet.Keys[0].PrivateKey)
+			require.Equal(t, vector.clientID, wallet.ClientID)
+		}
+	})
+
+	t.Run("parallel derivation", func(t *testing.T) {
+		store := crypto.NewKeyStore()
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 100)
+		for i := 0; i < 100; i++ {
+			vector := vectors[i%len(vectors)]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				clientID, keys, err := store.FromMnemonic(vector.mnemonic)
+				if err == nil && (clientID != vector.clientID || keys.PrivateKey.SerializeToHexStr() != vector.privateKey) {
+					err = fmt.Errorf("derived unexpected keys of client [%s]", clientID)
+				}
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("wallet file", func(t *testing.T) {
+		store := crypto.NewKeyStore()
+		_, derived, err := store.FromMnemonic(vectors[0].mnemonic)
+		require.NoError(t, err)
+
+		walletFile := &climodel.WalletFile{
+			ClientID: vectors[0].clientID,
+			Keys: []climodel.KeyPair{{
+				PublicKey:  derived.PublicKey.SerializeToHexStr(),
+				PrivateKey: vectors[0].privateKey,
+			}},
+		}
+		keys, err := store.FromWalletFile(walletFile)
+		require.NoError(t, err)
+		require.Same(t, derived, keys)
+
+		walletFile.Keys[0].PrivateKey = vectors[1].privateKey
+		_, err = crypto.NewKeyStore().FromWalletFile(walletFile)
+		require.Error(t, err, "private key does not match public key")
+	})
+}
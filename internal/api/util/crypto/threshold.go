@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/0chain/errors"
+)
+
+// Contains errors of threshold signatures, the returned errors wrap them with details
+var (
+	ErrInvalidThreshold  = errors.New("threshold_invalid", "threshold must be at least 1")
+	ErrNotEnoughShares   = errors.New("threshold_not_enough_shares", "not enough signature shares to reach the threshold")
+	ErrDuplicateShareID  = errors.New("threshold_duplicate_share_id", "signature shares have duplicated id")
+	ErrInvalidShare      = errors.New("threshold_invalid_share", "signature share cannot be parsed")
+	ErrInvalidShareOwner = errors.New("threshold_invalid_share_owner", "signature share is not signed by key share of its id")
+)
+
+// SignatureShare is a signature of a single threshold key share, see GenerateThresholdKeyShares
+type SignatureShare struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"public_key,omitempty"`
+	Signature string `json:"signature"`
+}
+
+// SignWithShares signs hash with every given key share
+func SignWithShares(shares []SignatureScheme, hash string) ([]SignatureShare, error) {
+	signatures := make([]SignatureShare, 0, len(shares))
+	for _, share := range shares {
+		signature, err := share.Sign(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		signatures = append(signatures, SignatureShare{
+			ID:        share.GetID(),
+			PublicKey: share.GetPublicKey(),
+			Signature: signature,
+		})
+	}
+	return signatures, nil
+}
+
+// CombineSignatureShares recovers the group signature of hash from signature shares using Lagrange interpolation.
+// Any threshold shares yield the same signature, so only the first threshold of them are combined.
+// Shares with public key set are verified first, so a wrong share is reported instead of a wrong signature.
+func CombineSignatureShares(threshold int, shares []SignatureShare, hash string) (string, error) {
+	if threshold < 1 {
+		return "", fmt.Errorf("%w: got %d", ErrInvalidThreshold, threshold)
+	}
+
+	ids := make([]ID, 0, len(shares))
+	signatures := make([]Signature, 0, len(shares))
+	seen := make(map[string]struct{}, len(shares))
+	for _, share := range shares {
+		id := BlsSignerInstance.NewID()
+		if err := id.SetHexString(share.ID); err != nil {
+			return "", fmt.Errorf("%w: id [%s]: %v", ErrInvalidShare, share.ID, err)
+		}
+		if _, ok := seen[id.GetHexString()]; ok {
+			return "", fmt.Errorf("%w: id [%s]", ErrDuplicateShareID, share.ID)
+		}
+		seen[id.GetHexString()] = struct{}{}
+
+		signature := BlsSignerInstance.NewSignature()
+		if err := signature.DeserializeHexStr(share.Signature); err != nil {
+			return "", fmt.Errorf("%w: signature of id [%s]: %v", ErrInvalidShare, share.ID, err)
+		}
+
+		if share.PublicKey != "" {
+			ok, err := VerifyThresholdSignature(share.PublicKey, share.Signature, hash)
+			if err != nil {
+				return "", fmt.Errorf("%w: id [%s]: %v", ErrInvalidShare, share.ID, err)
+			}
+			if !ok {
+				return "", fmt.Errorf("%w: id [%s]", ErrInvalidShareOwner, share.ID)
+			}
+		}
+
+		ids = append(ids, id)
+		signatures = append(signatures, signature)
+	}
+
+	if len(shares) < threshold {
+		return "", fmt.Errorf("%w: got %d of %d", ErrNotEnoughShares, len(shares), threshold)
+	}
+
+	combined := BlsSignerInstance.NewSignature()
+	if err := combined.Recover(signatures[:threshold], ids[:threshold]); err != nil {
+		return "", errors.Wrap(err, "recovering threshold signature failed")
+	}
+	return combined.SerializeToHexStr(), nil
+}
+
+// SignWithThreshold signs hash with the given key shares and combines their signatures
+func SignWithThreshold(threshold int, shares []SignatureScheme, hash string) (string, error) {
+	signatures, err := SignWithShares(shares, hash)
+	if err != nil {
+		return "", err
+	}
+	return CombineSignatureShares(threshold, signatures, hash)
+}
+
+// VerifyThresholdSignature verifies signature of hash against public key of the original key, or of a single key share
+func VerifyThresholdSignature(publicKey, signature, hash string) (bool, error) {
+	scheme := NewHerumiScheme()
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return false, err
+	}
+	return scheme.Verify(signature, hash)
+}
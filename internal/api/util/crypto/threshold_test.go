@@ -0,0 +1,76 @@
+package crypto_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdSignature(t *testing.T) {
+	const (
+		threshold = 3
+		total     = 5
+		hash      = "5f1ad0a5f2ee1d5e0b4c2a7b1d8e9f3c6a4b2d0e8f7c5a3b1d9e7f5c3a1b0d2e"
+	)
+
+	original := crypto.NewHerumiScheme()
+	wallet, err := original.GenerateKeys()
+	require.NoError(t, err)
+
+	shares, err := crypto.GenerateThresholdKeyShares(threshold, total, original)
+	require.NoError(t, err)
+	require.Len(t, shares, total)
+
+	signatures, err := crypto.SignWithShares(shares, hash)
+	require.NoError(t, err)
+
+	expected, err := original.Sign(hash)
+	require.NoError(t, err)
+
+	t.Run("any threshold shares recover the original signature", func(t *testing.T) {
+		for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+			var selected []crypto.SignatureShare
+			for _, i := range subset {
+				selected = append(selected, signatures[i])
+			}
+
+			signature, err := crypto.CombineSignatureShares(threshold, selected, hash)
+			require.NoError(t, err)
+			require.Equal(t, expected, signature, "shares %v", subset)
+
+			ok, err := crypto.VerifyThresholdSignature(wallet.ClientKey, signature, hash)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("sign with threshold", func(t *testing.T) {
+		signature, err := crypto.SignWithThreshold(threshold, shares[1:4], hash)
+		require.NoError(t, err)
+		require.Equal(t, expected, signature)
+	})
+
+	t.Run("not enough shares", func(t *testing.T) {
+		_, err := crypto.CombineSignatureShares(threshold, signatures[:threshold-1], hash)
+		require.True(t, errors.Is(err, crypto.ErrNotEnoughShares), err)
+	})
+
+	t.Run("duplicated id", func(t *testing.T) {
+		_, err := crypto.CombineSignatureShares(threshold, []crypto.SignatureShare{signatures[0], signatures[1], signatures[0]}, hash)
+		require.True(t, errors.Is(err, crypto.ErrDuplicateShareID), err)
+	})
+
+	t.Run("share of another id", func(t *testing.T) {
+		forged := signatures[1]
+		forged.Signature = signatures[2].Signature
+		_, err := crypto.CombineSignatureShares(threshold, []crypto.SignatureShare{signatures[0], forged, signatures[3]}, hash)
+		require.True(t, errors.Is(err, crypto.ErrInvalidShareOwner), err)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, err := crypto.CombineSignatureShares(0, signatures, hash)
+		require.True(t, errors.Is(err, crypto.ErrInvalidThreshold), err)
+	})
+}
@@ -0,0 +1,163 @@
+// Package datagen generates file content for upload tests. Content is derived from a seed on demand,
+// so files of any size can be uploaded and verified without keeping them in memory or on disk.
+package datagen
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+const wordSize = 8
+
+var (
+	ErrInvalidOffset    = errors.New("invalid offset")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Source is an io.ReadSeeker of deterministic pseudo-random content, sources with the same seed and size
+// have the same content
+type Source struct {
+	seed   uint64
+	size   int64
+	offset int64
+
+	checksumOnce sync.Once
+	checksum     string
+}
+
+// New creates source of size bytes derived from the seed
+func New(seed uint64, size int64) *Source {
+	return &Source{seed: seed, size: size}
+}
+
+// NewRandom creates source with a random seed, the seed is logged by callers to reproduce failures
+func NewRandom(size int64) (*Source, error) {
+	var seed [wordSize]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return nil, err
+	}
+	return New(binary.LittleEndian.Uint64(seed[:]), size), nil
+}
+
+func (s *Source) Seed() uint64 {
+	return s.seed
+}
+
+func (s *Source) Size() int64 {
+	return s.size
+}
+
+// Clone returns source with the same content positioned at the start
+func (s *Source) Clone() *Source {
+	return New(s.seed, s.size)
+}
+
+func (s *Source) Read(p []byte) (int, error) {
+	n, err := s.ReadAt(p, s.offset)
+	s.offset += int64(n)
+	return n, err
+}
+
+// ReadAt reads content at the given offset without changing the position of Read
+func (s *Source) ReadAt(p []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, ErrInvalidOffset
+	}
+	if offset >= s.size {
+		return 0, io.EOF
+	}
+
+	remaining := s.size - offset
+	if int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	var word [wordSize]byte
+	n := 0
+	for n < len(p) {
+		position := offset + int64(n)
+		binary.LittleEndian.PutUint64(word[:], s.word(position/wordSize))
+		n += copy(p[n:], word[position%wordSize:])
+	}
+
+	if offset+int64(n) == s.size {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (s *Source) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += s.offset
+	case io.SeekEnd:
+		offset += s.size
+	default:
+		return 0, fmt.Errorf("%w: unknown whence %d", ErrInvalidOffset, whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidOffset, offset)
+	}
+
+	s.offset = offset
+	return offset, nil
+}
+
+// Checksum returns hex encoded sha256 of the whole content, it is computed once without moving the position of Read
+func (s *Source) Checksum() string {
+	s.checksumOnce.Do(func() {
+		// reading the generated content cannot fail
+		s.checksum, _ = Checksum(s.Clone())
+	})
+	return s.checksum
+}
+
+// Verify checks the content read from r, e.g. a downloaded file, matches the source
+func (s *Source) Verify(r io.Reader) error {
+	checksum, err := Checksum(r)
+	if err != nil {
+		return err
+	}
+	if checksum != s.Checksum() {
+		return fmt.Errorf("%w: expected content of seed [%d] and size [%d] with checksum [%s], got [%s]",
+			ErrChecksumMismatch, s.seed, s.size, s.Checksum(), checksum)
+	}
+	return nil
+}
+
+// VerifyFile checks content of the file at path matches the source
+func (s *Source) VerifyFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return s.Verify(f)
+}
+
+// Checksum returns hex encoded sha256 of the content read from r
+func Checksum(r io.Reader) (string, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// word returns the index-th 8 bytes of content, it is splitmix64 so any part of content is generated
+// without generating the preceding parts
+func (s *Source) word(index int64) uint64 {
+	z := s.seed + uint64(index+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
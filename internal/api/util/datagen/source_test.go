@@ -0,0 +1,82 @@
+package datagen_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/datagen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	const size = 1024*1024 + 3
+
+	source := datagen.New(42, size)
+	content, err := io.ReadAll(source)
+	require.NoError(t, err)
+	require.Len(t, content, size)
+
+	t.Run("same seed should generate same content", func(t *testing.T) {
+		again, err := io.ReadAll(datagen.New(42, size))
+		require.NoError(t, err)
+		require.Equal(t, content, again)
+
+		other, err := io.ReadAll(datagen.New(43, size))
+		require.NoError(t, err)
+		require.NotEqual(t, content, other)
+
+		// shorter source is a prefix of the longer one
+		prefix, err := io.ReadAll(datagen.New(42, 13))
+		require.NoError(t, err)
+		require.Equal(t, content[:13], prefix)
+	})
+
+	t.Run("seek should read content from any offset", func(t *testing.T) {
+		source := source.Clone()
+		for _, offset := range []int64{0, 1, 7, 8, 9, 4095, size - 5} {
+			position, err := source.Seek(offset, io.SeekStart)
+			require.NoError(t, err)
+			require.Equal(t, offset, position)
+
+			buf := make([]byte, 17)
+			n, err := io.ReadFull(source, buf)
+			if offset+17 > size {
+				require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, content[offset:offset+int64(n)], buf[:n])
+		}
+
+		position, err := source.Seek(-10, io.SeekEnd)
+		require.NoError(t, err)
+		require.Equal(t, int64(size-10), position)
+
+		_, err = source.Seek(-1, io.SeekStart)
+		require.ErrorIs(t, err, datagen.ErrInvalidOffset)
+	})
+
+	t.Run("checksum should match content", func(t *testing.T) {
+		checksum, err := datagen.Checksum(bytes.NewReader(content))
+		require.NoError(t, err)
+		require.Equal(t, checksum, source.Checksum())
+		require.NoError(t, source.Verify(bytes.NewReader(content)))
+
+		path := filepath.Join(t.TempDir(), "download")
+		content[size/2]++
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+		require.ErrorIs(t, source.VerifyFile(path), datagen.ErrChecksumMismatch)
+	})
+
+	t.Run("random sources should differ", func(t *testing.T) {
+		first, err := datagen.NewRandom(size)
+		require.NoError(t, err)
+		second, err := datagen.NewRandom(size)
+		require.NoError(t, err)
+		require.NotEqual(t, first.Seed(), second.Seed())
+		require.NotEqual(t, first.Checksum(), second.Checksum())
+	})
+}
@@ -0,0 +1,219 @@
+// Package fixtures keeps chain objects provisioned by a setup phase, so tests running later against the same
+// network can find them by name instead of by position in a text file.
+package fixtures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Version is the version of registries written by this package, registries of other versions are rejected
+const Version = 1
+
+// Contains operations recorded in history of allocations
+const (
+	Write          OperationKind = "write"
+	Delete         OperationKind = "delete"
+	AddBlobber     OperationKind = "add_blobber"
+	ReplaceBlobber OperationKind = "replace_blobber"
+	Cancel         OperationKind = "cancel"
+)
+
+var (
+	ErrVersion  = errors.New("unsupported fixture registry version")
+	ErrNotFound = errors.New("fixture not found")
+	ErrInvalid  = errors.New("invalid fixture")
+)
+
+type OperationKind string
+
+// Registry is a named set of fixtures of a scenario
+type Registry struct {
+	Version  int       `json:"version"`
+	Scenario string    `json:"scenario"`
+	Created  time.Time `json:"created"`
+	// Round is the latest finalized round when the setup phase finished
+	Round int64 `json:"round"`
+
+	Wallets     map[string]Wallet      `json:"wallets"`
+	Blobbers    map[string]Blobber     `json:"blobbers"`
+	Allocations map[string]*Allocation `json:"allocations"`
+}
+
+// Wallet is a client which owns fixtures, keys are not stored. Ownership of allocations is not validated
+// when the client id is not known.
+type Wallet struct {
+	ClientID  string `json:"client_id"`
+	PublicKey string `json:"public_key"`
+}
+
+// Blobber is a storage provider referenced by a scenario
+type Blobber struct {
+	ID string `json:"id"`
+}
+
+// Allocation is an allocation together with operations applied to it during the setup phase
+type Allocation struct {
+	ID string `json:"id"`
+	// Owner is the name of the wallet which owns the allocation
+	Owner    string      `json:"owner"`
+	Blobbers []string    `json:"blobbers"`
+	History  []Operation `json:"history"`
+}
+
+// Operation is a change of an allocation, fields not related to the kind are empty
+type Operation struct {
+	Kind  OperationKind `json:"kind"`
+	Round int64         `json:"round"`
+	// Path is the remote path of written or deleted file
+	Path string `json:"path,omitempty"`
+	Size int64  `json:"size,omitempty"`
+	// Blobber is the added blobber, Replaced is the blobber it replaced
+	Blobber  string `json:"blobber,omitempty"`
+	Replaced string `json:"replaced,omitempty"`
+}
+
+// New returns an empty registry of the scenario
+func New(scenario string) *Registry {
+	return &Registry{
+		Version:     Version,
+		Scenario:    scenario,
+		Created:     time.Now().UTC(),
+		Wallets:     make(map[string]Wallet),
+		Blobbers:    make(map[string]Blobber),
+		Allocations: make(map[string]*Allocation),
+	}
+}
+
+// AddAllocation registers an allocation of an already registered wallet
+func (r *Registry) AddAllocation(name, owner, id string, blobbers []string) error {
+	if _, ok := r.Wallets[owner]; !ok {
+		return fmt.Errorf("%w: wallet %s of allocation %s", ErrNotFound, owner, name)
+	}
+	r.Allocations[name] = &Allocation{ID: id, Owner: owner, Blobbers: blobbers}
+	return nil
+}
+
+// Record appends the operation to history of the allocation, blobbers of the allocation follow the operation
+func (r *Registry) Record(allocation string, operation Operation) error {
+	a, ok := r.Allocations[allocation]
+	if !ok {
+		return fmt.Errorf("%w: allocation %s", ErrNotFound, allocation)
+	}
+	switch operation.Kind {
+	case AddBlobber:
+		a.Blobbers = append(a.Blobbers, operation.Blobber)
+	case ReplaceBlobber:
+		for i, blobber := range a.Blobbers {
+			if blobber == operation.Replaced {
+				a.Blobbers[i] = operation.Blobber
+				break
+			}
+		}
+	}
+	a.History = append(a.History, operation)
+	return nil
+}
+
+// Wallet returns the wallet registered under name
+func (r *Registry) Wallet(name string) (Wallet, error) {
+	wallet, ok := r.Wallets[name]
+	if !ok {
+		return Wallet{}, fmt.Errorf("%w: wallet %s of %s", ErrNotFound, name, r.Scenario)
+	}
+	return wallet, nil
+}
+
+// Blobber returns id of the blobber registered under name
+func (r *Registry) Blobber(name string) (string, error) {
+	blobber, ok := r.Blobbers[name]
+	if !ok {
+		return "", fmt.Errorf("%w: blobber %s of %s", ErrNotFound, name, r.Scenario)
+	}
+	return blobber.ID, nil
+}
+
+// Allocation returns the allocation registered under name
+func (r *Registry) Allocation(name string) (*Allocation, error) {
+	allocation, ok := r.Allocations[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: allocation %s of %s", ErrNotFound, name, r.Scenario)
+	}
+	return allocation, nil
+}
+
+// Canceled reports whether the allocation was canceled during the setup phase
+func (a *Allocation) Canceled() bool {
+	for _, operation := range a.History {
+		if operation.Kind == Cancel {
+			return true
+		}
+	}
+	return false
+}
+
+// Files returns remote paths of files written to the allocation and not deleted afterwards
+func (a *Allocation) Files() []string {
+	var files []string
+	for _, operation := range a.History {
+		switch operation.Kind {
+		case Write:
+			files = append(files, operation.Path)
+		case Delete:
+			for i, file := range files {
+				if file == operation.Path {
+					files = append(files[:i], files[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return files
+}
+
+// Replaced returns blobbers which were replaced in the allocation
+func (a *Allocation) Replaced() []string {
+	var replaced []string
+	for _, operation := range a.History {
+		if operation.Kind == ReplaceBlobber {
+			replaced = append(replaced, operation.Replaced)
+		}
+	}
+	return replaced
+}
+
+// Save writes the registry as JSON
+func (r *Registry) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Load reads a registry written by Save for the scenario and checks it is consistent
+func Load(path, scenario string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Registry
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalid, path, err)
+	}
+	if r.Version != Version {
+		return nil, fmt.Errorf("%w: %s has version %d, expected %d", ErrVersion, path, r.Version, Version)
+	}
+	if r.Scenario != scenario {
+		return nil, fmt.Errorf("%w: %s is registry of %q, expected %q", ErrInvalid, path, r.Scenario, scenario)
+	}
+	for name, allocation := range r.Allocations {
+		if _, ok := r.Wallets[allocation.Owner]; !ok {
+			return nil, fmt.Errorf("%w: wallet %s of allocation %s is not registered", ErrInvalid, allocation.Owner, name)
+		}
+	}
+	return &r, nil
+}
@@ -0,0 +1,134 @@
+package fixtures_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/fixtures"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := fixtures.New("challenge")
+	registry.Wallets["owner"] = fixtures.Wallet{ClientID: "client"}
+	registry.Blobbers["added"] = fixtures.Blobber{ID: "blobber3"}
+
+	require.NoError(t, registry.AddAllocation("writes", "owner", "allocation", []string{"blobber1", "blobber2"}))
+	require.ErrorIs(t, registry.AddAllocation("other", "nobody", "allocation", nil), fixtures.ErrNotFound)
+
+	for _, operation := range []fixtures.Operation{
+		{Kind: fixtures.Write, Path: "/a", Size: 1024},
+		{Kind: fixtures.Write, Path: "/b", Size: 1024},
+		{Kind: fixtures.Delete, Path: "/a"},
+		{Kind: fixtures.ReplaceBlobber, Blobber: "blobber3", Replaced: "blobber1"},
+	} {
+		require.NoError(t, registry.Record("writes", operation))
+	}
+	require.ErrorIs(t, registry.Record("other", fixtures.Operation{Kind: fixtures.Cancel}), fixtures.ErrNotFound)
+
+	allocation, err := registry.Allocation("writes")
+	require.NoError(t, err)
+	require.Equal(t, []string{"blobber3", "blobber2"}, allocation.Blobbers)
+	require.Equal(t, []string{"/b"}, allocation.Files())
+	require.Equal(t, []string{"blobber1"}, allocation.Replaced())
+	require.False(t, allocation.Canceled())
+
+	blobberID, err := registry.Blobber("added")
+	require.NoError(t, err)
+	require.Equal(t, "blobber3", blobberID)
+
+	_, err = registry.Allocation("empty")
+	require.ErrorIs(t, err, fixtures.ErrNotFound)
+
+	t.Run("registry should be loaded for its scenario", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixtures.json")
+		require.NoError(t, registry.Save(path))
+
+		loaded, err := fixtures.Load(path, "challenge")
+		require.NoError(t, err)
+		require.True(t, registry.Created.Equal(loaded.Created))
+		loaded.Created = registry.Created
+		require.Equal(t, registry, loaded)
+
+		_, err = fixtures.Load(path, "rewards")
+		require.ErrorIs(t, err, fixtures.ErrInvalid)
+	})
+
+	t.Run("registry of other version should not be loaded", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixtures.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": 0, "scenario": "challenge"}`), 0600))
+
+		_, err := fixtures.Load(path, "challenge")
+		require.ErrorIs(t, err, fixtures.ErrVersion)
+	})
+}
+
+func TestValidate(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	network := mocknet.NewDefault()
+	defer network.Close()
+	apiClient := client.NewAPIClient(network.URL())
+	blobber1, blobber2, blobber3 := network.Blobbers[0].ID, network.Blobbers[1].ID, network.Blobbers[2].ID
+
+	registry := fixtures.New("challenge")
+	registry.Wallets["owner"] = fixtures.Wallet{ClientID: "client"}
+	registry.Blobbers["added"] = fixtures.Blobber{ID: blobber3}
+	require.NoError(t, registry.AddAllocation("replaced", "owner", "allocation1", []string{blobber1, blobber2}))
+	require.NoError(t, registry.Record("replaced", fixtures.Operation{Kind: fixtures.ReplaceBlobber, Blobber: blobber3, Replaced: blobber1}))
+	require.NoError(t, registry.AddAllocation("canceled", "owner", "allocation2", []string{blobber1}))
+	require.NoError(t, registry.Record("canceled", fixtures.Operation{Kind: fixtures.Cancel}))
+
+	expiration := time.Now().Add(time.Hour).Unix()
+	network.Update(func(s *mocknet.State) {
+		s.Allocations["allocation1"] = &model.SCRestGetAllocationResponse{
+			ID:         "allocation1",
+			Owner:      "client",
+			Expiration: expiration,
+			Blobbers:   []*model.StorageNode{{ID: blobber2}, {ID: blobber3}},
+		}
+		s.Allocations["allocation2"] = &model.SCRestGetAllocationResponse{
+			ID:       "allocation2",
+			Owner:    "client",
+			Canceled: true,
+		}
+	})
+	require.NoError(t, registry.Validate(t, apiClient))
+
+	network.Update(func(s *mocknet.State) {
+		s.Allocations["allocation1"].Owner = "other"
+	})
+	require.ErrorIs(t, registry.ValidateAllocation(t, apiClient, "replaced"), fixtures.ErrStale)
+	registry.Wallets["owner"] = fixtures.Wallet{}
+	require.NoError(t, registry.ValidateAllocation(t, apiClient, "replaced"), "owner should not be validated when its client id is not known")
+	registry.Wallets["owner"] = fixtures.Wallet{ClientID: "client"}
+
+	network.Update(func(s *mocknet.State) {
+		s.Allocations["allocation1"].Owner = "client"
+		delete(s.Allocations, "allocation2")
+	})
+	require.NoError(t, registry.ValidateAllocation(t, apiClient, "replaced"), "only the stale fixture should fail")
+	require.NoError(t, registry.ValidateBlobber(t, apiClient, "added"))
+	require.ErrorIs(t, registry.ValidateAllocation(t, apiClient, "canceled"), fixtures.ErrStale)
+	require.ErrorIs(t, registry.ValidateAllocation(t, apiClient, "empty"), fixtures.ErrNotFound)
+
+	network.Update(func(s *mocknet.State) {
+		s.Allocations["allocation1"].Blobbers = append(s.Allocations["allocation1"].Blobbers, &model.StorageNode{ID: blobber1})
+		delete(s.Blobbers, blobber3)
+	})
+	require.ErrorIs(t, registry.ValidateBlobber(t, apiClient, "added"), fixtures.ErrStale)
+	err := registry.Validate(t, apiClient)
+	require.ErrorIs(t, err, fixtures.ErrStale)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap() //nolint:errorlint
+	require.Len(t, errs, 3, "every stale fixture should be reported")
+	require.ErrorContains(t, errs[0], "blobber added")
+	require.ErrorContains(t, errs[1], "allocation canceled")
+	require.ErrorContains(t, errs[2], "replaced blobber "+blobber1)
+}
@@ -0,0 +1,103 @@
+package fixtures
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+var ErrStale = errors.New("fixture is not live on chain")
+
+// Validate checks the fixtures still exist on chain in the state the setup phase left them in,
+// all stale fixtures are reported in the returned error
+func (r *Registry) Validate(t *test.SystemTest, apiClient *client.APIClient) error {
+	var errs []error
+	for _, name := range sortedKeys(r.Blobbers) {
+		if err := r.ValidateBlobber(t, apiClient, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range sortedKeys(r.Allocations) {
+		if err := r.ValidateAllocation(t, apiClient, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateBlobber checks the blobber registered under name still exists on chain
+func (r *Registry) ValidateBlobber(t *test.SystemTest, apiClient *client.APIClient, name string) error {
+	id, err := r.Blobber(name)
+	if err != nil {
+		return err
+	}
+	if _, _, err := apiClient.V1SCRestGetBlobber(t, model.SCRestGetBlobberRequest{BlobberID: id}, client.HttpOkStatus); err != nil {
+		return fmt.Errorf("%w: blobber %s (%s): %v", ErrStale, name, id, err)
+	}
+	return nil
+}
+
+// ValidateAllocation checks the allocation registered under name is on chain in the state the setup phase left it in
+func (r *Registry) ValidateAllocation(t *test.SystemTest, apiClient *client.APIClient, name string) error {
+	allocation, err := r.Allocation(name)
+	if err != nil {
+		return err
+	}
+	stale := func(format string, args ...any) error {
+		return fmt.Errorf("%w: allocation %s (%s): %s", ErrStale, name, allocation.ID, fmt.Sprintf(format, args...))
+	}
+
+	onChain, _, err := apiClient.V1SCRestGetAllocation(t, model.SCRestGetAllocationRequest{AllocationID: allocation.ID}, client.HttpOkStatus)
+	if err != nil {
+		return stale("%v", err)
+	}
+	if onChain == nil {
+		return stale("%v", client.ErrGetFromResource)
+	}
+
+	if owner := r.Wallets[allocation.Owner].ClientID; owner != "" && onChain.Owner != owner {
+		return stale("owned by %s, expected %s", onChain.Owner, owner)
+	}
+	if allocation.Canceled() {
+		if !onChain.Canceled {
+			return stale("expected to be canceled")
+		}
+		return nil
+	}
+	if onChain.Canceled || onChain.Finalized {
+		return stale("canceled or finalized")
+	}
+	if onChain.Expiration > 0 && onChain.Expiration < time.Now().Unix() {
+		return stale("expired at %s", time.Unix(onChain.Expiration, 0).UTC())
+	}
+
+	blobbers := make(map[string]bool, len(onChain.Blobbers))
+	for _, blobber := range onChain.Blobbers {
+		blobbers[blobber.ID] = true
+	}
+	for _, id := range allocation.Blobbers {
+		if !blobbers[id] {
+			return stale("blobber %s is not part of the allocation", id)
+		}
+	}
+	for _, id := range allocation.Replaced() {
+		if blobbers[id] {
+			return stale("replaced blobber %s is still part of the allocation", id)
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
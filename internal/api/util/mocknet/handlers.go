@@ -0,0 +1,384 @@
+package mocknet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+)
+
+// getUserStakePoolStat is not exported by the client package
+const getUserStakePoolStat = "/v1/screst/:sc_address/getUserStakePoolStat"
+
+func scPath(path, scAddress string) string {
+	return strings.Replace(path, ":sc_address", scAddress, 1)
+}
+
+func (n *Network) serve(node *Node, w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	storage := func(path string) string { return scPath(path, client.StorageSmartContractAddress) }
+	miner := func(path string) string { return scPath(path, client.MinerSmartContractAddress) }
+
+	switch r.URL.Path {
+	case client.ChainGetStats, client.MinerGetStatus, client.SharderGetStatus:
+		writeJSON(w, http.StatusOK, model.GetSharderStatsResponse{LastFinalizedRound: n.state.Round})
+	case client.BlobberGetStats:
+		n.serveBlobberStats(w, r)
+	case client.ClientPut:
+		n.serveClientPut(w, r)
+	case client.TransactionFeeGet:
+		writeJSON(w, http.StatusOK, map[string]int64{"fee": n.state.Fee})
+	case client.TransactionPut:
+		n.serveTransactionPut(node, w, r)
+	case client.TransactionGetConfirmation:
+		n.serveTransactionConfirmation(w, r)
+	case client.ClientGetBalance:
+		n.serveBalance(w, r)
+	case client.GetLatestFinalizedBlock:
+		writeJSON(w, http.StatusOK, model.LatestFinalizedBlock{Round: n.state.Round, MinerId: n.Miners[0].ID})
+	case client.GetLatestFinalizedMagicBlock:
+		writeJSON(w, http.StatusOK, map[string]int64{"round": n.state.Round})
+	case client.SCStateGet:
+		n.serveSCState(w, r)
+	case storage(client.GetBlobbers):
+		n.serveBlobbers(w, r)
+	case storage(client.SCRestGetBlobbers):
+		n.serveBlobber(w, r)
+	case storage(client.GetValidators):
+		writeJSON(w, http.StatusOK, n.state.Validators)
+	case storage(client.GetAllocationBlobbers):
+		n.serveAllocationBlobbers(w, r, "allocation_data")
+	case storage(client.GetFreeAllocationBlobbers):
+		n.serveAllocationBlobbers(w, r, "free_allocation_data")
+	case storage(client.SCRestGetAllocation):
+		n.serveAllocation(w, r)
+	case storage(client.GetStakePoolStat):
+		n.serveStakePoolStat(w, r)
+	case storage(getUserStakePoolStat):
+		n.serveUserStakePoolStat(w, r)
+	case storage(client.SCRestGetOpenChallenges):
+		n.serveOpenChallenges(w, r)
+	case storage(client.GetAllChallenges):
+		writeJSON(w, http.StatusOK, n.state.Challenges[r.URL.Query().Get("allocation_id")])
+	case storage(client.QueryChallengesCount):
+		n.serveChallengesCount(w, r)
+	case storage(client.QueryRewards):
+		n.serveQueryRewards(w, r)
+	case storage(client.QueryDelegateRewards):
+		writeJSON(w, http.StatusOK, n.state.QueryDelegateRewards[queryParam(r)])
+	case storage(client.PartitionSizeFrequency):
+		writeJSON(w, http.StatusOK, n.state.PartitionSizeFrequency)
+	case storage(client.BlobberPartitionSelectionFrequency):
+		writeJSON(w, http.StatusOK, n.state.BlobberPartitionSelectionFrequency)
+	case miner(client.GetMiners):
+		writeJSON(w, http.StatusOK, n.minersSharders(n.Miners))
+	case miner(client.GetSharders):
+		writeJSON(w, http.StatusOK, n.minersSharders(n.Sharders))
+	default:
+		writeError(w, http.StatusNotFound, "not_found", r.URL.Path)
+	}
+}
+
+// queryParam returns the "query" parameter, which APIClient escapes twice
+func queryParam(r *http.Request) string {
+	query := r.URL.Query().Get("query")
+	if unescaped, err := url.QueryUnescape(query); err == nil {
+		return unescaped
+	}
+	return query
+}
+
+func (n *Network) serveBlobberStats(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != "admin" || password != "password" {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "admin credentials are required")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int64{"round": n.state.Round})
+}
+
+func (n *Network) serveClientPut(w http.ResponseWriter, r *http.Request) {
+	var wallet model.Wallet
+	if err := json.NewDecoder(r.Body).Decode(&wallet); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	n.state.Wallet(wallet.Id)
+	writeJSON(w, http.StatusOK, wallet)
+}
+
+func (n *Network) serveTransactionConfirmation(w http.ResponseWriter, r *http.Request) {
+	confirmation, ok := n.state.Transactions[r.URL.Query().Get("hash")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "entity_not_found", "transaction not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, confirmation)
+}
+
+func (n *Network) serveBalance(w http.ResponseWriter, r *http.Request) {
+	wallet, ok := n.state.Wallets[r.URL.Query().Get("client_id")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "resource_not_found", "value not present")
+		return
+	}
+	writeJSON(w, http.StatusOK, model.ClientGetBalanceResponse{
+		Round:   n.state.Round,
+		Balance: wallet.Balance,
+		Nonce:   wallet.Nonce,
+	})
+}
+
+func (n *Network) serveSCState(w http.ResponseWriter, r *http.Request) {
+	value, ok := n.state.SCState[r.FormValue("sc_address")][r.FormValue("key")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "resource_not_found", "value not present")
+		return
+	}
+	writeJSON(w, http.StatusOK, value)
+}
+
+func (n *Network) serveBlobbers(w http.ResponseWriter, r *http.Request) {
+	blobbers := n.state.sortedBlobbers()
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	result := model.StorageNodes{Nodes: []*model.StorageNode{}}
+	if offset < len(blobbers) {
+		end := offset + limit
+		if end > len(blobbers) {
+			end = len(blobbers)
+		}
+		result.Nodes = blobbers[offset:end]
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (n *Network) serveBlobber(w http.ResponseWriter, r *http.Request) {
+	blobber, ok := n.state.Blobbers[r.URL.Query().Get("blobber_id")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "resource_not_found", "blobber not found")
+		return
+	}
+	pool := n.state.StakePool(blobber.ID)
+	writeJSON(w, http.StatusOK, model.SCRestGetBlobberResponse{
+		ID:                blobber.ID,
+		BaseURL:           blobber.BaseURL,
+		Terms:             blobber.Terms,
+		Capacity:          blobber.Capacity,
+		Allocated:         blobber.Allocated,
+		LastHealthCheck:   blobber.LastHealthCheck,
+		StakePoolSettings: blobber.StakePoolSettings,
+		TotalStake:        pool.Balance,
+		StorageVersion:    blobber.StorageVersion,
+		ManagingWallet:    blobber.ManagingWallet,
+	})
+}
+
+func (n *Network) serveAllocationBlobbers(w http.ResponseWriter, r *http.Request, param string) {
+	var requirements model.BlobberRequirements
+	if err := json.Unmarshal([]byte(r.URL.Query().Get(param)), &requirements); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	count := int(requirements.DataShards + requirements.ParityShards)
+	if param == "free_allocation_data" {
+		count = len(n.state.Blobbers)
+	}
+
+	blobbers := n.state.sortedBlobbers()
+	if count > len(blobbers) {
+		writeError(w, http.StatusBadRequest, "allocation_creation_failed", "not enough blobbers to honor the allocation")
+		return
+	}
+
+	ids := make([]string, 0, count)
+	for _, blobber := range blobbers[:count] {
+		ids = append(ids, blobber.ID)
+	}
+	writeJSON(w, http.StatusOK, ids)
+}
+
+func (n *Network) serveAllocation(w http.ResponseWriter, r *http.Request) {
+	allocation, ok := n.state.Allocations[r.URL.Query().Get("allocation")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "resource_not_found", "allocation not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, allocation)
+}
+
+func (n *Network) serveStakePoolStat(w http.ResponseWriter, r *http.Request) {
+	pool, ok := n.state.StakePools[r.URL.Query().Get("provider_id")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "resource_not_found", "stake pool not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, pool)
+}
+
+func (n *Network) serveUserStakePoolStat(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	result := model.SCRestGetUserStakePoolStatResponse{Pools: make(map[string]*[]model.StakePoolDelegatePoolInfo)}
+	for providerID, pool := range n.state.StakePools {
+		var delegates []model.StakePoolDelegatePoolInfo
+		for _, delegate := range pool.Delegate {
+			if delegate.DelegateID == clientID {
+				delegates = append(delegates, delegate)
+			}
+		}
+		if len(delegates) > 0 {
+			result.Pools[providerID] = &delegates
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (n *Network) serveOpenChallenges(w http.ResponseWriter, r *http.Request) {
+	blobberID := r.URL.Query().Get("blobber")
+	result := model.SCRestOpenChallengeResponse{BlobberID: blobberID, Challenges: []*model.Challenge{}}
+	for _, challenges := range n.state.Challenges {
+		for _, challenge := range challenges {
+			if challenge.BlobberID == blobberID && challenge.Responded == 0 {
+				result.Challenges = append(result.Challenges, challenge)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (n *Network) serveChallengesCount(w http.ResponseWriter, r *http.Request) {
+	conditions := parseQuery(queryParam(r))
+	result := map[string]int64{"total": 0, "passed": 0, "failed": 0, "open": 0}
+
+	for _, challenges := range n.state.Challenges {
+		for _, challenge := range challenges {
+			if !matchChallenge(challenge, conditions) {
+				continue
+			}
+			result["total"]++
+			switch {
+			case challenge.Responded == 0:
+				result["open"]++
+			case challenge.Passed:
+				result["passed"]++
+			default:
+				result["failed"]++
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (n *Network) serveQueryRewards(w http.ResponseWriter, r *http.Request) {
+	rewards, ok := n.state.QueryRewards[queryParam(r)]
+	if !ok {
+		rewards = &model.QueryRewardsResponse{}
+	}
+	writeJSON(w, http.StatusOK, rewards)
+}
+
+func (n *Network) minersSharders(nodes []*Node) model.SCRestGetMinersShardersResponse {
+	var result model.SCRestGetMinersShardersResponse
+	for _, node := range nodes {
+		pool := n.state.StakePool(node.ID)
+		parsedURL, _ := url.Parse(node.URL())
+		port, _ := strconv.Atoi(parsedURL.Port())
+
+		response := &model.SCRestGetMinerSharderResponse{
+			SimpleNodeResponse: model.SimpleNodeResponse{
+				ID:          node.ID,
+				Host:        parsedURL.Hostname(),
+				Port:        port,
+				TotalStaked: pool.Balance,
+			},
+		}
+		response.StakePoolResponse.Pools = make(map[string]*model.DelegatePoolResponse)
+		for _, delegate := range pool.Delegate {
+			response.StakePoolResponse.Pools[delegate.ID] = &model.DelegatePoolResponse{
+				DelegatePool: model.DelegatePool{
+					Balance:    delegate.Balance,
+					Reward:     delegate.Rewards,
+					DelegateID: delegate.DelegateID,
+				},
+			}
+		}
+		result.Nodes = append(result.Nodes, response)
+	}
+	return result
+}
+
+// queryCondition is a "column operator value" condition of an event db query
+type queryCondition struct {
+	column, operator, value string
+}
+
+// parseQuery understands the subset of event db queries used by the tests,
+// which is a conjunction of "column = 'value'" and "column >= number" like conditions
+func parseQuery(query string) []queryCondition {
+	var conditions []queryCondition
+	for _, condition := range strings.Split(query, " AND ") {
+		for _, operator := range []string{">=", "<=", "=", "<", ">"} {
+			column, value, ok := strings.Cut(condition, operator)
+			if !ok {
+				continue
+			}
+			conditions = append(conditions, queryCondition{
+				column:   strings.TrimSpace(column),
+				operator: operator,
+				value:    strings.Trim(strings.TrimSpace(value), "'"),
+			})
+			break
+		}
+	}
+	return conditions
+}
+
+func matchChallenge(challenge *model.Challenge, conditions []queryCondition) bool {
+	for _, condition := range conditions {
+		switch condition.column {
+		case "allocation_id":
+			if challenge.AllocationID != condition.value {
+				return false
+			}
+		case "blobber_id":
+			if challenge.BlobberID != condition.value {
+				return false
+			}
+		case "round_created_at":
+			if !compareRound(challenge.RoundCreatedAt, condition) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func compareRound(round int64, condition queryCondition) bool {
+	value, err := strconv.ParseInt(condition.value, 10, 64)
+	if err != nil {
+		return false
+	}
+	switch condition.operator {
+	case ">=":
+		return round >= value
+	case "<=":
+		return round <= value
+	case "<":
+		return round < value
+	case ">":
+		return round > value
+	}
+	return round == value
+}
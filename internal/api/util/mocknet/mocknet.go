@@ -0,0 +1,231 @@
+// Package mocknet provides an in-process stand-in for a 0chain network, so APIClient based
+// helpers can be developed and regression-tested without a live deployment.
+package mocknet
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+)
+
+// DefaultFee is returned by /v1/estimate_txn_fee unless State.Fee is changed
+const DefaultFee = int64(1e8)
+
+// DefaultFutureNonce mirrors server_chain.transaction.future_nonce of the CI deployments
+const DefaultFutureNonce = int64(100)
+
+// Config contains sizes of the mocked network
+type Config struct {
+	Miners   int
+	Sharders int
+	Blobbers int
+}
+
+// DefaultConfig mirrors the smallest network used by the CI deployments
+var DefaultConfig = Config{Miners: 3, Sharders: 2, Blobbers: 6}
+
+// Node is a single miner, sharder or blobber of the mocked network
+type Node struct {
+	ID                  string
+	ServiceProviderType int
+
+	network  *Network
+	server   *httptest.Server
+	mu       sync.RWMutex
+	down     bool
+	handlers map[string]http.HandlerFunc
+}
+
+// URL returns base url of the node
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// SetDown makes node answer every request with 503, so health checks see it as dead
+func (n *Node) SetDown(down bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.down = down
+}
+
+// Handle overrides the response of this node only for the given path.
+// It is used to script nodes which disagree with the rest of the network,
+// nil handler removes the override.
+func (n *Node) Handle(path string, handler http.HandlerFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if handler == nil {
+		delete(n.handlers, path)
+		return
+	}
+	n.handlers[path] = handler
+}
+
+func (n *Node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	down := n.down
+	handler, ok := n.handlers[r.URL.Path]
+	n.mu.RUnlock()
+
+	switch {
+	case down:
+		http.Error(w, "node is down", http.StatusServiceUnavailable)
+	case ok:
+		handler(w, r)
+	default:
+		n.network.serve(n, w, r)
+	}
+}
+
+// Network is an httptest backed fake 0chain network with scriptable state
+type Network struct {
+	Miners   []*Node
+	Sharders []*Node
+	Blobbers []*Node
+
+	entrypoint *httptest.Server
+
+	mu       sync.Mutex
+	state    *State
+	handlers map[string]TransactionHandler
+}
+
+// New starts a mocked network of the given size
+func New(config Config) *Network {
+	n := &Network{
+		state:    newState(),
+		handlers: defaultTransactionHandlers(),
+	}
+
+	for i := 0; i < config.Miners; i++ {
+		n.Miners = append(n.Miners, n.newNode(client.MinerServiceProvider, i))
+	}
+	for i := 0; i < config.Sharders; i++ {
+		n.Sharders = append(n.Sharders, n.newNode(client.SharderServiceProvider, i))
+	}
+	for i := 0; i < config.Blobbers; i++ {
+		node := n.newNode(client.BlobberServiceProvider, i)
+		n.Blobbers = append(n.Blobbers, node)
+		n.state.Blobbers[node.ID] = &model.StorageNode{
+			ID:       node.ID,
+			BaseURL:  node.URL(),
+			Terms:    model.Terms{ReadPrice: 1e9, WritePrice: 1e9},
+			Capacity: 1 << 40,
+			StakePoolSettings: model.StakePoolSettings{
+				DelegateWallet: node.ID,
+				NumDelegates:   50,
+				ServiceCharge:  0.1,
+			},
+			StorageVersion: 1,
+		}
+	}
+
+	n.entrypoint = httptest.NewServer(http.HandlerFunc(n.serveNetwork))
+
+	return n
+}
+
+// NewDefault starts a mocked network of DefaultConfig size
+func NewDefault() *Network {
+	return New(DefaultConfig)
+}
+
+// URL returns the network entrypoint to be passed to client.NewAPIClient
+func (n *Network) URL() string {
+	return n.entrypoint.URL
+}
+
+// Close stops every server of the network
+func (n *Network) Close() {
+	n.entrypoint.Close()
+	for _, nodes := range [][]*Node{n.Miners, n.Sharders, n.Blobbers} {
+		for _, node := range nodes {
+			node.server.Close()
+		}
+	}
+}
+
+// Update runs the given function with exclusive access to the chain state
+func (n *Network) Update(f func(s *State)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f(n.state)
+}
+
+// SetBalance sets balance of the given client
+func (n *Network) SetBalance(clientID string, balance int64) {
+	n.Update(func(s *State) {
+		s.Wallet(clientID).Balance = balance
+	})
+}
+
+// Balance returns balance and nonce of the given client
+func (n *Network) Balance(clientID string) (balance, nonce int64) {
+	n.Update(func(s *State) {
+		if wallet, ok := s.Wallets[clientID]; ok {
+			balance, nonce = wallet.Balance, wallet.Nonce
+		}
+	})
+	return balance, nonce
+}
+
+// AdvanceRounds moves latest finalized round forward
+func (n *Network) AdvanceRounds(rounds int64) {
+	n.Update(func(s *State) {
+		s.Round += rounds
+	})
+}
+
+// HandleTransaction registers handler for smart contract function with the given name,
+// replacing the default one if any
+func (n *Network) HandleTransaction(name string, handler TransactionHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[name] = handler
+}
+
+func (n *Network) newNode(serviceProviderType, index int) *Node {
+	node := &Node{
+		ID:                  crypto.Sha3256([]byte(fmt.Sprintf("mocknet:%d:%d", serviceProviderType, index))),
+		ServiceProviderType: serviceProviderType,
+		network:             n,
+		handlers:            make(map[string]http.HandlerFunc),
+	}
+	node.server = httptest.NewServer(node)
+	return node
+}
+
+func (n *Network) serveNetwork(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != client.GetNetworkDetails {
+		http.NotFound(w, r)
+		return
+	}
+
+	var details model.HealthyServiceProviders
+	for _, miner := range n.Miners {
+		details.Miners = append(details.Miners, miner.URL())
+	}
+	for _, sharder := range n.Sharders {
+		details.Sharders = append(details.Sharders, sharder.URL())
+	}
+	writeJSON(w, http.StatusOK, details)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{
+		"code":  code,
+		"error": fmt.Sprintf("%s: %s", code, message),
+	})
+}
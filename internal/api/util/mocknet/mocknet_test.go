@@ -0,0 +1,74 @@
+package mocknet_test
+
+import (
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockNetwork(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	network := mocknet.NewDefault()
+	defer network.Close()
+
+	apiClient := client.NewAPIClient(network.URL())
+	require.Len(t, apiClient.HealthyServiceProviders.Miners, len(network.Miners))
+	require.Len(t, apiClient.HealthyServiceProviders.Sharders, len(network.Sharders))
+	require.Len(t, apiClient.HealthyServiceProviders.Blobbers, len(network.Blobbers))
+
+	newWallet := func(t *test.SystemTest) *model.Wallet {
+		wallet := apiClient.CreateWalletForMnemonic(t, crypto.GenerateMnemonics(t))
+		network.SetBalance(wallet.Id, *tokenomics.IntToZCN(100))
+		return wallet
+	}
+
+	t.RunSequentially("Create allocation should lock tokens in write pool", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+
+		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
+		allocationBlobbers := apiClient.GetAllocationBlobbers(t, wallet, &blobberRequirements, client.HttpOkStatus)
+		allocationID := apiClient.CreateAllocation(t, wallet, allocationBlobbers, client.TxSuccessfulStatus)
+
+		allocation := apiClient.GetAllocation(t, allocationID, client.HttpOkStatus)
+		require.Equal(t, wallet.Id, allocation.Owner)
+		require.Len(t, allocation.Blobbers, int(blobberRequirements.DataShards+blobberRequirements.ParityShards))
+		require.Equal(t, *tokenomics.IntToZCN(10), allocation.WritePool)
+
+		balance, nonce := network.Balance(wallet.Id)
+		require.Equal(t, *tokenomics.IntToZCN(90)-mocknet.DefaultFee, balance)
+		require.Equal(t, int64(1), nonce)
+		require.Equal(t, 1, wallet.Nonce, "wallet nonce should follow the chain")
+	})
+
+	t.RunSequentially("Stake pool rewards should be collected once", func(t *test.SystemTest) {
+		wallet := newWallet(t)
+		blobberID := network.Blobbers[0].ID
+
+		apiClient.CreateStakePool(t, wallet, 3, blobberID, client.TxSuccessfulStatus)
+		network.Update(func(s *mocknet.State) {
+			s.AddReward(blobberID, wallet.Id, *tokenomics.IntToZCN(1))
+		})
+
+		balanceBefore, _ := network.Balance(wallet.Id)
+		_, fee := apiClient.CollectRewards(t, wallet, blobberID, 3, client.TxSuccessfulStatus)
+		balanceAfter, _ := network.Balance(wallet.Id)
+		require.Equal(t, balanceBefore+*tokenomics.IntToZCN(1)-fee, balanceAfter)
+
+		apiClient.CollectRewards(t, wallet, blobberID, 3, client.TxUnsuccessfulStatus)
+	})
+
+	t.RunSequentially("Unhealthy nodes should not be selected", func(t *test.SystemTest) {
+		network.Miners[0].SetDown(true)
+		defer network.Miners[0].SetDown(false)
+
+		apiClient := client.NewAPIClient(network.URL())
+		require.NotContains(t, apiClient.HealthyServiceProviders.Miners, network.Miners[0].URL())
+	})
+}
@@ -0,0 +1,285 @@
+package mocknet
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+func defaultTransactionHandlers() map[string]TransactionHandler {
+	return map[string]TransactionHandler{
+		"new_allocation_request":    newAllocation,
+		"update_allocation_request": updateAllocation,
+		"cancel_allocation":         cancelAllocation,
+		"write_pool_lock":           writePoolLock,
+		"add_blobber":               addBlobber,
+		"kill_blobber":              killBlobber,
+		"update_blobber_settings":   updateBlobberSettings,
+		"stake_pool_lock":           stakePoolLock,
+		"stake_pool_unlock":         stakePoolUnlock,
+		"addToDelegatePool":         stakePoolLock,
+		"deleteFromDelegatePool":    stakePoolUnlock,
+		"collect_reward":            collectReward,
+		"add_free_storage_assigner": noop,
+	}
+}
+
+func noop(_ *State, _ *model.TransactionEntity, _ json.RawMessage) (string, error) {
+	return "", nil
+}
+
+func newAllocation(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.SCRestGetAllocationBlobbersResponse
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+	if request.Blobbers == nil || len(*request.Blobbers) == 0 {
+		return "", fmt.Errorf("allocation_creation_failed: no blobbers")
+	}
+
+	allocation := &model.SCRestGetAllocationResponse{
+		ID:              txn.Hash,
+		Tx:              txn.Hash,
+		DataShards:      int(request.DataShards),
+		ParityShards:    int(request.ParityShards),
+		Size:            request.Size,
+		CreatedAt:       txn.CreationDate,
+		Expiration:      request.ExpirationDate,
+		Owner:           request.OwnerId,
+		OwnerPublicKey:  request.OwnerPublicKey,
+		Payer:           txn.ClientId,
+		Stats:           &model.AllocationStats{},
+		TimeUnit:        time.Hour,
+		StartTime:       txn.CreationDate,
+		WritePool:       txn.TransactionValue,
+		ReadPriceRange:  request.ReadPriceRange,
+		WritePriceRange: request.WritePriceRange,
+	}
+	if allocation.Owner == "" {
+		allocation.Owner = txn.ClientId
+	}
+
+	for _, id := range *request.Blobbers {
+		blobber, ok := s.Blobbers[id]
+		if !ok {
+			return "", fmt.Errorf("allocation_creation_failed: blobber %s %w", id, ErrNotFound)
+		}
+		allocation.Blobbers = append(allocation.Blobbers, blobber)
+	}
+
+	s.Allocations[allocation.ID] = allocation
+
+	output, err := json.Marshal(allocation)
+	return string(output), err
+}
+
+func updateAllocation(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.UpdateAllocationRequest
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+
+	allocation, ok := s.Allocations[request.ID]
+	if !ok {
+		return "", fmt.Errorf("allocation_updating_failed: %w", ErrNotFound)
+	}
+	if allocation.Canceled || allocation.Finalized {
+		return "", fmt.Errorf("allocation_updating_failed: allocation is finalized or canceled")
+	}
+
+	allocation.Size += request.Size
+	allocation.WritePool += txn.TransactionValue
+	if request.Extend {
+		allocation.Expiration += int64(allocation.TimeUnit.Seconds())
+	}
+	if request.SetImmutable {
+		allocation.IsImmutable = true
+	}
+
+	if request.AddBlobberId != "" {
+		blobber, ok := s.Blobbers[request.AddBlobberId]
+		if !ok {
+			return "", fmt.Errorf("allocation_updating_failed: blobber %s %w", request.AddBlobberId, ErrNotFound)
+		}
+		allocation.Blobbers = append(allocation.Blobbers, blobber)
+	}
+	if request.RemoveBlobberId != "" {
+		for i, blobber := range allocation.Blobbers {
+			if blobber.ID == request.RemoveBlobberId {
+				allocation.Blobbers = append(allocation.Blobbers[:i], allocation.Blobbers[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return "allocation updated successfully", nil
+}
+
+func cancelAllocation(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.CancelAllocationRequest
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+
+	allocation, ok := s.Allocations[request.AllocationID]
+	if !ok {
+		return "", fmt.Errorf("alloc_cancel_failed: %w", ErrNotFound)
+	}
+	if allocation.Owner != txn.ClientId {
+		return "", fmt.Errorf("alloc_cancel_failed: only owner can cancel an allocation")
+	}
+
+	allocation.Canceled = true
+	s.Wallet(txn.ClientId).Balance += allocation.WritePool
+	allocation.WritePool = 0
+
+	return "canceled", nil
+}
+
+func writePoolLock(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.CreateWritePoolRequest
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+
+	allocation, ok := s.Allocations[request.AllocationID]
+	if !ok {
+		return "", fmt.Errorf("write_pool_lock_failed: %w", ErrNotFound)
+	}
+	allocation.WritePool += txn.TransactionValue
+
+	return "locked", nil
+}
+
+func addBlobber(s *State, _ *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var blobber model.StorageNode
+	if err := json.Unmarshal(input, &blobber); err != nil {
+		return "", err
+	}
+	if _, ok := s.Blobbers[blobber.ID]; ok {
+		return "", fmt.Errorf("add_or_update_blobber_failed: blobber already exists")
+	}
+	s.Blobbers[blobber.ID] = &blobber
+
+	output, err := json.Marshal(blobber)
+	return string(output), err
+}
+
+func killBlobber(s *State, _ *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.KillBlobberRequest
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+	if _, ok := s.Blobbers[request.ProviderID]; !ok {
+		return "", fmt.Errorf("kill_blobber_failed: %w", ErrNotFound)
+	}
+	delete(s.Blobbers, request.ProviderID)
+
+	return "killed", nil
+}
+
+func updateBlobberSettings(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.SCRestGetBlobberResponse
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+
+	blobber, ok := s.Blobbers[request.ID]
+	if !ok {
+		return "", fmt.Errorf("update_blobber_settings_failed: %w", ErrNotFound)
+	}
+	blobber.Terms = request.Terms
+	blobber.Capacity = request.Capacity
+	blobber.StakePoolSettings = request.StakePoolSettings
+
+	// update does not lock any tokens
+	s.Wallet(txn.ClientId).Balance += txn.TransactionValue
+
+	return "blobber settings updated successfully", nil
+}
+
+func stakePoolLock(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.CreateStakePoolRequest
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+
+	pool := s.StakePool(request.ProviderID)
+	pool.Balance += txn.TransactionValue
+
+	for i := range pool.Delegate {
+		if pool.Delegate[i].DelegateID == txn.ClientId {
+			pool.Delegate[i].Balance += txn.TransactionValue
+			return "locked", nil
+		}
+	}
+	pool.Delegate = append(pool.Delegate, model.StakePoolDelegatePoolInfo{
+		ID:           txn.ClientId,
+		DelegateID:   txn.ClientId,
+		Balance:      txn.TransactionValue,
+		Status:       "active",
+		RoundCreated: s.Round,
+	})
+
+	return "locked", nil
+}
+
+func stakePoolUnlock(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request model.CreateStakePoolRequest
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+
+	pool, ok := s.StakePools[request.ProviderID]
+	if !ok {
+		return "", fmt.Errorf("stake_pool_unlock_failed: %w", ErrNotFound)
+	}
+
+	for i, delegate := range pool.Delegate {
+		if delegate.DelegateID != txn.ClientId {
+			continue
+		}
+		// value sent along with unlock is not locked and the stake is returned
+		s.Wallet(txn.ClientId).Balance += txn.TransactionValue + delegate.Balance + delegate.Rewards
+		pool.Balance -= delegate.Balance
+		pool.Rewards -= delegate.Rewards
+		pool.Delegate = append(pool.Delegate[:i], pool.Delegate[i+1:]...)
+		return "unlocked", nil
+	}
+
+	return "", fmt.Errorf("stake_pool_unlock_failed: delegate pool %w", ErrNotFound)
+}
+
+func collectReward(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error) {
+	var request struct {
+		ProviderID string `json:"provider_id"`
+	}
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", err
+	}
+
+	pool, ok := s.StakePools[request.ProviderID]
+	if !ok {
+		return "", fmt.Errorf("collect_reward_failed: %w", ErrNotFound)
+	}
+
+	var collected int64
+	for i := range pool.Delegate {
+		if pool.Delegate[i].DelegateID != txn.ClientId {
+			continue
+		}
+		collected += pool.Delegate[i].Rewards
+		pool.Delegate[i].TotalReward += pool.Delegate[i].Rewards
+		pool.Delegate[i].Rewards = 0
+	}
+	if collected == 0 {
+		return "", fmt.Errorf("collect_reward_failed: no rewards to collect")
+	}
+
+	pool.Rewards -= collected
+	s.Wallet(txn.ClientId).Balance += collected
+
+	return fmt.Sprintf("collected %d", collected), nil
+}
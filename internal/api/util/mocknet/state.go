@@ -0,0 +1,124 @@
+package mocknet
+
+import (
+	"sort"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+// Wallet contains the on-chain state of a single client
+type Wallet struct {
+	Balance int64
+	Nonce   int64
+}
+
+// State contains the scriptable chain state served by the mock network.
+// It must only be accessed through Network.Update.
+type State struct {
+	Round int64
+	Fee   int64
+
+	// FutureNonce limits how far ahead of the wallet nonce transactions are kept in the pool
+	FutureNonce int64
+
+	Wallets      map[string]*Wallet
+	Transactions map[string]*model.TransactionGetConfirmationResponse
+	Allocations  map[string]*model.SCRestGetAllocationResponse
+	Blobbers     map[string]*model.StorageNode
+	Validators   []*model.SCRestGetValidatorResponse
+	StakePools   map[string]*model.SCRestGetStakePoolStatResponse
+	Challenges   map[string][]*model.Challenge
+
+	// SCState is keyed by smart contract address and then by key
+	SCState map[string]map[string]interface{}
+
+	// QueryRewards and QueryDelegateRewards are keyed by the raw query string
+	QueryRewards         map[string]*model.QueryRewardsResponse
+	QueryDelegateRewards map[string]map[string]int64
+
+	PartitionSizeFrequency             map[string]int
+	BlobberPartitionSelectionFrequency map[string]int64
+
+	// futureTransactions are keyed by client id and nonce, until the preceding nonces are applied
+	futureTransactions map[string]map[int64]*model.TransactionPutRequest
+}
+
+func newState() *State {
+	return &State{
+		Round:                              1,
+		Fee:                                DefaultFee,
+		FutureNonce:                        DefaultFutureNonce,
+		Wallets:                            make(map[string]*Wallet),
+		Transactions:                       make(map[string]*model.TransactionGetConfirmationResponse),
+		Allocations:                        make(map[string]*model.SCRestGetAllocationResponse),
+		Blobbers:                           make(map[string]*model.StorageNode),
+		StakePools:                         make(map[string]*model.SCRestGetStakePoolStatResponse),
+		Challenges:                         make(map[string][]*model.Challenge),
+		SCState:                            make(map[string]map[string]interface{}),
+		QueryRewards:                       make(map[string]*model.QueryRewardsResponse),
+		QueryDelegateRewards:               make(map[string]map[string]int64),
+		PartitionSizeFrequency:             make(map[string]int),
+		BlobberPartitionSelectionFrequency: make(map[string]int64),
+		futureTransactions:                 make(map[string]map[int64]*model.TransactionPutRequest),
+	}
+}
+
+// Wallet returns wallet state for the given client, creating it when missing
+func (s *State) Wallet(clientID string) *Wallet {
+	wallet, ok := s.Wallets[clientID]
+	if !ok {
+		wallet = &Wallet{}
+		s.Wallets[clientID] = wallet
+	}
+	return wallet
+}
+
+// StakePool returns stake pool of the given provider, creating it when missing
+func (s *State) StakePool(providerID string) *model.SCRestGetStakePoolStatResponse {
+	pool, ok := s.StakePools[providerID]
+	if !ok {
+		pool = &model.SCRestGetStakePoolStatResponse{ID: providerID}
+		s.StakePools[providerID] = pool
+	}
+	return pool
+}
+
+// AddReward adds uncollected reward to delegate pool of the given provider
+func (s *State) AddReward(providerID, delegateID string, amount int64) {
+	pool := s.StakePool(providerID)
+	for i := range pool.Delegate {
+		if pool.Delegate[i].DelegateID == delegateID {
+			pool.Delegate[i].Rewards += amount
+			pool.Rewards += amount
+			return
+		}
+	}
+	pool.Delegate = append(pool.Delegate, model.StakePoolDelegatePoolInfo{
+		ID:         delegateID,
+		DelegateID: delegateID,
+		Rewards:    amount,
+		Status:     "active",
+	})
+	pool.Rewards += amount
+}
+
+// SetSCState sets value returned by /v1/scstate/get for the given smart contract address and key
+func (s *State) SetSCState(scAddress, key string, value interface{}) {
+	values, ok := s.SCState[scAddress]
+	if !ok {
+		values = make(map[string]interface{})
+		s.SCState[scAddress] = values
+	}
+	values[key] = value
+}
+
+func (s *State) sortedBlobbers() []*model.StorageNode {
+	result := make([]*model.StorageNode, 0, len(s.Blobbers))
+	for _, blobber := range s.Blobbers {
+		result = append(result, blobber)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
@@ -0,0 +1,204 @@
+package mocknet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+// TransactionHandler applies smart contract function of an accepted transaction to the state.
+// Returned string is stored as transaction output, returned error fails the transaction.
+type TransactionHandler func(s *State, txn *model.TransactionEntity, input json.RawMessage) (string, error)
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrUnknownFunction     = errors.New("unknown smart contract function")
+	ErrNotFound            = errors.New("value not present")
+)
+
+func (n *Network) serveTransactionPut(node *Node, w http.ResponseWriter, r *http.Request) {
+	var request model.TransactionPutRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	if err := verifyTransaction(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	// Every miner receives the transaction, but it must be applied only once
+	if _, ok := n.state.Transactions[request.Hash]; ok {
+		writeJSON(w, http.StatusOK, transactionPutResponse(&request))
+		return
+	}
+
+	wallet := n.state.Wallet(request.ClientId)
+	nonce := int64(request.TransactionNonce)
+	switch {
+	case nonce <= wallet.Nonce:
+		writeError(w, http.StatusBadRequest, "invalid_request",
+			fmt.Sprintf("invalid transaction nonce: expected %d, got %d", wallet.Nonce+1, request.TransactionNonce))
+		return
+	case nonce > wallet.Nonce+n.state.FutureNonce:
+		writeError(w, http.StatusBadRequest, "invalid_request",
+			fmt.Sprintf("invalid future transaction: nonce %d is more than %d ahead", request.TransactionNonce, n.state.FutureNonce))
+		return
+	}
+
+	// transactions arriving ahead of their nonce wait in the pool, as they do on miners
+	future, ok := n.state.futureTransactions[request.ClientId]
+	if !ok {
+		future = make(map[int64]*model.TransactionPutRequest)
+		n.state.futureTransactions[request.ClientId] = future
+	}
+	if _, ok := future[nonce]; !ok {
+		future[nonce] = &request
+	}
+
+	for next, ok := future[wallet.Nonce+1]; ok; next, ok = future[wallet.Nonce+1] {
+		delete(future, wallet.Nonce+1)
+		wallet.Nonce++
+		n.state.Round++
+		n.state.Transactions[next.Hash] = n.apply(node, next)
+	}
+
+	writeJSON(w, http.StatusOK, transactionPutResponse(&request))
+}
+
+func (n *Network) apply(node *Node, request *model.TransactionPutRequest) *model.TransactionGetConfirmationResponse {
+	entity := &model.TransactionEntity{
+		PublicKey:        request.PublicKey,
+		Version:          request.Version,
+		ClientId:         request.ClientId,
+		ToClientId:       request.ToClientId,
+		TransactionData:  request.TransactionData,
+		TransactionValue: request.TransactionValue,
+		CreationDate:     request.CreationDate,
+		TransactionFee:   request.TransactionFee,
+		TransactionType:  request.TransactionType,
+		TxnOutputHash:    request.TxnOutputHash,
+		TransactionNonce: request.TransactionNonce,
+		Hash:             request.Hash,
+		Signature:        request.Signature,
+	}
+
+	output, err := n.execute(entity)
+	status := client.TxSuccessfulStatus
+	if err != nil {
+		status = client.TxUnsuccessfulStatus
+		output = err.Error()
+	}
+	entity.TransactionOutput = output
+	entity.TransactionStatus = status
+
+	return &model.TransactionGetConfirmationResponse{
+		Version:      request.Version,
+		Hash:         request.Hash,
+		BlockHash:    crypto.Sha3256([]byte(fmt.Sprintf("block:%d", n.state.Round))),
+		Transaction:  entity,
+		CreationDate: time.Now().Unix(),
+		MinerID:      node.ID,
+		Round:        n.state.Round,
+		Status:       status,
+	}
+}
+
+func (n *Network) execute(txn *model.TransactionEntity) (string, error) {
+	sender := n.state.Wallet(txn.ClientId)
+
+	var data struct {
+		Name  string          `json:"name"`
+		Input json.RawMessage `json:"input"`
+	}
+	if txn.TransactionType == client.SCTxType {
+		if err := json.Unmarshal([]byte(txn.TransactionData), &data); err != nil {
+			return "", err
+		}
+	}
+
+	// faucet pours are free and paid by the faucet itself
+	if data.Name == "pour" {
+		sender.Balance += txn.TransactionValue
+		return "pour", nil
+	}
+
+	if sender.Balance < txn.TransactionValue+txn.TransactionFee {
+		return "", ErrInsufficientBalance
+	}
+	sender.Balance -= txn.TransactionFee
+
+	if txn.TransactionType != client.SCTxType {
+		sender.Balance -= txn.TransactionValue
+		n.state.Wallet(txn.ToClientId).Balance += txn.TransactionValue
+		return "transfer", nil
+	}
+
+	handler, ok := n.handlers[data.Name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownFunction, data.Name)
+	}
+
+	// value is locked by the smart contract and handlers return it when needed
+	sender.Balance -= txn.TransactionValue
+	output, err := handler(n.state, txn, data.Input)
+	if err != nil {
+		sender.Balance += txn.TransactionValue
+	}
+	return output, err
+}
+
+func verifyTransaction(request *model.TransactionPutRequest) error {
+	hash := crypto.Sha3256([]byte(fmt.Sprintf("%d:%d:%s:%s:%d:%s",
+		request.CreationDate,
+		request.TransactionNonce,
+		request.ClientId,
+		request.ToClientId,
+		request.TransactionValue,
+		crypto.Sha3256([]byte(request.TransactionData)))))
+	if hash != request.Hash {
+		return errors.New("invalid transaction hash")
+	}
+
+	var publicKey bls.PublicKey
+	if err := publicKey.DeserializeHexStr(request.PublicKey); err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+
+	var signature bls.Sign
+	if err := signature.DeserializeHexStr(request.Signature); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	rawHash, err := hex.DecodeString(request.Hash)
+	if err != nil {
+		return err
+	}
+	if !signature.Verify(&publicKey, string(rawHash)) {
+		return errors.New("invalid transaction signature")
+	}
+
+	return nil
+}
+
+func transactionPutResponse(request *model.TransactionPutRequest) model.TransactionPutResponse {
+	return model.TransactionPutResponse{
+		Async: true,
+		Entity: model.TransactionEntity{
+			Hash:             request.Hash,
+			ClientId:         request.ClientId,
+			ToClientId:       request.ToClientId,
+			TransactionNonce: request.TransactionNonce,
+			TransactionFee:   request.TransactionFee,
+		},
+	}
+}
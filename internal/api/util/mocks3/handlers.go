@@ -0,0 +1,381 @@
+package mocks3
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultMaxKeys = 1000
+
+type listBucketsResponse struct {
+	XMLName xml.Name      `xml:"ListAllMyBucketsResult"`
+	Buckets []bucketEntry `xml:"Buckets>Bucket"`
+}
+
+type bucketEntry struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+type listObjectsResponse struct {
+	XMLName               xml.Name      `xml:"ListBucketResult"`
+	Name                  string        `xml:"Name"`
+	Prefix                string        `xml:"Prefix"`
+	Delimiter             string        `xml:"Delimiter,omitempty"`
+	StartAfter            string        `xml:"StartAfter,omitempty"`
+	ContinuationToken     string        `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string        `xml:"NextContinuationToken,omitempty"`
+	KeyCount              int           `xml:"KeyCount"`
+	MaxKeys               int           `xml:"MaxKeys"`
+	IsTruncated           bool          `xml:"IsTruncated"`
+	Contents              []objectEntry `xml:"Contents"`
+	CommonPrefixes        []prefixEntry `xml:"CommonPrefixes"`
+}
+
+type objectEntry struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type prefixEntry struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type copyObjectResponse struct {
+	XMLName      xml.Name `xml:"CopyObjectResult"`
+	LastModified string   `xml:"LastModified"`
+	ETag         string   `xml:"ETag"`
+}
+
+type initiateMultipartUploadResponse struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type completeMultipartUploadRequest struct {
+	Parts []struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResponse struct {
+	XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+	Bucket  string   `xml:"Bucket"`
+	Key     string   `xml:"Key"`
+	ETag    string   `xml:"ETag"`
+}
+
+func (s *Server) listBuckets(w http.ResponseWriter) {
+	var response listBucketsResponse
+	for _, name := range s.bucketNames() {
+		response.Buckets = append(response.Buckets, bucketEntry{
+			Name:         name,
+			CreationDate: s.buckets[name].created.Format(time.RFC3339),
+		})
+	}
+	writeXML(w, http.StatusOK, response)
+}
+
+func (s *Server) createBucket(w http.ResponseWriter, bucketName string) {
+	if _, ok := s.buckets[bucketName]; ok {
+		writeError(w, http.StatusConflict, "BucketAlreadyOwnedByYou", fmt.Sprintf("bucket [%s] already exists", bucketName))
+		return
+	}
+	s.buckets[bucketName] = &bucket{created: time.Now().UTC(), objects: make(map[string]*object)}
+	w.Header().Set("Location", "/"+bucketName)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) headBucket(w http.ResponseWriter, bucketName string) {
+	if _, ok := s.bucket(w, bucketName); ok {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) deleteBucket(w http.ResponseWriter, bucketName string) {
+	b, ok := s.bucket(w, bucketName)
+	if !ok {
+		return
+	}
+	if len(b.objects) > 0 {
+		writeError(w, http.StatusConflict, "BucketNotEmpty", fmt.Sprintf("bucket [%s] is not empty", bucketName))
+		return
+	}
+	delete(s.buckets, bucketName)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request, bucketName string) {
+	b, ok := s.bucket(w, bucketName)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	response := listObjectsResponse{
+		Name:              bucketName,
+		Prefix:            query.Get("prefix"),
+		Delimiter:         query.Get("delimiter"),
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: query.Get("continuation-token"),
+		MaxKeys:           defaultMaxKeys,
+	}
+	if maxKeys := query.Get("max-keys"); maxKeys != "" {
+		var err error
+		if response.MaxKeys, err = strconv.Atoi(maxKeys); err != nil || response.MaxKeys < 0 {
+			writeError(w, http.StatusBadRequest, "InvalidArgument", "max-keys must be a non-negative integer")
+			return
+		}
+	}
+
+	// continuation token is the last returned key, it is opaque for clients
+	after := response.StartAfter
+	if response.ContinuationToken != "" {
+		decoded, err := hex.DecodeString(response.ContinuationToken)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidArgument", "invalid continuation token")
+			return
+		}
+		after = string(decoded)
+	}
+
+	keys := make([]string, 0, len(b.objects))
+	for key := range b.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seenPrefixes := make(map[string]bool)
+	last := ""
+	for _, key := range keys {
+		if key <= after || !strings.HasPrefix(key, response.Prefix) {
+			continue
+		}
+
+		commonPrefix := ""
+		if response.Delimiter != "" {
+			if i := strings.Index(key[len(response.Prefix):], response.Delimiter); i >= 0 {
+				commonPrefix = key[:len(response.Prefix)+i+len(response.Delimiter)]
+			}
+		}
+		if commonPrefix != "" && seenPrefixes[commonPrefix] {
+			continue
+		}
+
+		if response.KeyCount == response.MaxKeys {
+			response.IsTruncated = true
+			response.NextContinuationToken = hex.EncodeToString([]byte(last))
+			break
+		}
+
+		if commonPrefix != "" {
+			seenPrefixes[commonPrefix] = true
+			response.CommonPrefixes = append(response.CommonPrefixes, prefixEntry{Prefix: commonPrefix})
+			// keys below the common prefix are skipped by the next page too
+			last = commonPrefix + "\uffff"
+		} else {
+			o := b.objects[key]
+			response.Contents = append(response.Contents, objectEntry{
+				Key:          key,
+				LastModified: o.modified.Format(time.RFC3339),
+				ETag:         o.etag,
+				Size:         len(o.data),
+				StorageClass: "STANDARD",
+			})
+			last = key
+		}
+		response.KeyCount++
+	}
+	writeXML(w, http.StatusOK, response)
+}
+
+func (s *Server) putObject(w http.ResponseWriter, bucketName, key string, body []byte) {
+	b, ok := s.bucket(w, bucketName)
+	if !ok {
+		return
+	}
+	o := newObject(body)
+	b.objects[key] = o
+	w.Header().Set("ETag", o.etag)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+	source, err := url.PathUnescape(strings.TrimPrefix(r.Header.Get("X-Amz-Copy-Source"), "/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidArgument", "invalid copy source")
+		return
+	}
+	sourceBucket, sourceKey, _ := strings.Cut(source, "/")
+	from, ok := s.bucket(w, sourceBucket)
+	if !ok {
+		return
+	}
+	o, ok := from.objects[sourceKey]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchKey", fmt.Sprintf("key [%s] does not exist", sourceKey))
+		return
+	}
+	to, ok := s.bucket(w, bucketName)
+	if !ok {
+		return
+	}
+	copied := &object{data: o.data, etag: o.etag, modified: time.Now().UTC()}
+	to.objects[key] = copied
+	writeXML(w, http.StatusOK, copyObjectResponse{LastModified: copied.modified.Format(time.RFC3339), ETag: copied.etag})
+}
+
+// getObject serves GET and HEAD requests, including ranges and conditional requests
+func (s *Server) getObject(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+	b, ok := s.bucket(w, bucketName)
+	if !ok {
+		return
+	}
+	o, ok := b.objects[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchKey", fmt.Sprintf("key [%s] does not exist", key))
+		return
+	}
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, key, o.modified, bytes.NewReader(o.data))
+}
+
+func (s *Server) deleteObject(w http.ResponseWriter, bucketName, key string) {
+	b, ok := s.bucket(w, bucketName)
+	if !ok {
+		return
+	}
+	// deleting a missing key succeeds in S3
+	delete(b.objects, key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createMultipartUpload(w http.ResponseWriter, bucketName, key string) {
+	if _, ok := s.bucket(w, bucketName); !ok {
+		return
+	}
+	s.uploadSeq++
+	uploadID := fmt.Sprintf("upload-%d", s.uploadSeq)
+	s.uploads[uploadID] = &multipartUpload{bucket: bucketName, key: key, parts: make(map[int]*object)}
+	writeXML(w, http.StatusOK, initiateMultipartUploadResponse{Bucket: bucketName, Key: key, UploadID: uploadID})
+}
+
+func (s *Server) uploadPart(w http.ResponseWriter, r *http.Request, body []byte) {
+	upload, ok := s.upload(w, r)
+	if !ok {
+		return
+	}
+	partNumber, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > 10000 {
+		writeError(w, http.StatusBadRequest, "InvalidArgument", "partNumber must be between 1 and 10000")
+		return
+	}
+	part := newObject(body)
+	upload.parts[partNumber] = part
+	w.Header().Set("ETag", part.etag)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) completeMultipartUpload(w http.ResponseWriter, r *http.Request, body []byte) {
+	upload, ok := s.upload(w, r)
+	if !ok {
+		return
+	}
+	var request completeMultipartUploadRequest
+	if err := xml.Unmarshal(body, &request); err != nil || len(request.Parts) == 0 {
+		writeError(w, http.StatusBadRequest, "MalformedXML", "the list of parts is missing or invalid")
+		return
+	}
+
+	var (
+		data    []byte
+		digests []byte
+	)
+	for i, requested := range request.Parts {
+		part, ok := upload.parts[requested.PartNumber]
+		if !ok || part.etag != requested.ETag {
+			writeError(w, http.StatusBadRequest, "InvalidPart", fmt.Sprintf("part [%d] was not uploaded", requested.PartNumber))
+			return
+		}
+		if i > 0 && requested.PartNumber <= request.Parts[i-1].PartNumber {
+			writeError(w, http.StatusBadRequest, "InvalidPartOrder", "parts must be listed in ascending order")
+			return
+		}
+		data = append(data, part.data...)
+		digest, _ := hex.DecodeString(strings.Trim(part.etag, `"`))
+		digests = append(digests, digest...)
+	}
+
+	b, ok := s.bucket(w, upload.bucket)
+	if !ok {
+		return
+	}
+	sum := md5.Sum(digests) //nolint:gosec
+	o := &object{
+		data:     data,
+		etag:     fmt.Sprintf(`"%s-%d"`, hex.EncodeToString(sum[:]), len(request.Parts)),
+		modified: time.Now().UTC(),
+	}
+	b.objects[upload.key] = o
+	delete(s.uploads, r.URL.Query().Get("uploadId"))
+
+	writeXML(w, http.StatusOK, completeMultipartUploadResponse{Bucket: upload.bucket, Key: upload.key, ETag: o.etag})
+}
+
+func (s *Server) abortMultipartUpload(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.upload(w, r); !ok {
+		return
+	}
+	delete(s.uploads, r.URL.Query().Get("uploadId"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) bucket(w http.ResponseWriter, bucketName string) (*bucket, bool) {
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", fmt.Sprintf("bucket [%s] does not exist", bucketName))
+	}
+	return b, ok
+}
+
+func (s *Server) upload(w http.ResponseWriter, r *http.Request) (*multipartUpload, bool) {
+	upload, ok := s.uploads[r.URL.Query().Get("uploadId")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchUpload", "the multipart upload does not exist")
+	}
+	return upload, ok
+}
+
+func (s *Server) bucketNames() []string {
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newObject(data []byte) *object {
+	sum := md5.Sum(data) //nolint:gosec
+	return &object{
+		data:     data,
+		etag:     `"` + hex.EncodeToString(sum[:]) + `"`,
+		modified: time.Now().UTC(),
+	}
+}
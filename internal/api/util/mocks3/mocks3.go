@@ -0,0 +1,272 @@
+// Package mocks3 provides an in-process S3 stand-in for zs3server. It checks SigV4 signatures and
+// implements the subset of S3 used by the zs3 client, so S3 helpers can be tested without a deployment.
+package mocks3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// Contains credentials accepted by the stand-in unless changed with AddCredentials
+const (
+	DefaultAccessKey = "rootroot"
+	DefaultSecretKey = "rootroot"
+	DefaultRegion    = "us-east-1"
+)
+
+const (
+	amzDateFormat   = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+var authorizationPattern = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/([^/]+)/aws4_request, ?SignedHeaders=([^,]+), ?Signature=([0-9a-f]+)$`)
+
+// Server is an httptest backed S3 endpoint keeping buckets in memory
+type Server struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	credentials map[string]string
+	buckets     map[string]*bucket
+	uploads     map[string]*multipartUpload
+	uploadSeq   int
+
+	// requests counts requests by S3 operation name, e.g. PutObject
+	requests map[string]int
+}
+
+type bucket struct {
+	created time.Time
+	objects map[string]*object
+}
+
+type object struct {
+	data     []byte
+	etag     string
+	modified time.Time
+}
+
+type multipartUpload struct {
+	bucket string
+	key    string
+	parts  map[int]*object
+}
+
+// New starts the stand-in accepting DefaultAccessKey
+func New() *Server {
+	s := &Server{
+		credentials: map[string]string{DefaultAccessKey: DefaultSecretKey},
+		buckets:     make(map[string]*bucket),
+		uploads:     make(map[string]*multipartUpload),
+		requests:    make(map[string]int),
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL returns endpoint of the stand-in
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddCredentials makes the stand-in accept another access key
+func (s *Server) AddCredentials(accessKey, secretKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credentials[accessKey] = secretKey
+}
+
+// Requests returns number of requests of the S3 operation, e.g. UploadPart
+func (s *Server) Requests(operation string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[operation]
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	if code, message := s.authenticate(r, body); code != "" {
+		writeError(w, http.StatusForbidden, code, message)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucketName, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	operation := s.route(r, bucketName, key)
+	s.requests[operation]++
+
+	switch operation {
+	case "ListBuckets":
+		s.listBuckets(w)
+	case "CreateBucket":
+		s.createBucket(w, bucketName)
+	case "HeadBucket":
+		s.headBucket(w, bucketName)
+	case "DeleteBucket":
+		s.deleteBucket(w, bucketName)
+	case "ListObjectsV2":
+		s.listObjects(w, r, bucketName)
+	case "PutObject":
+		s.putObject(w, bucketName, key, body)
+	case "CopyObject":
+		s.copyObject(w, r, bucketName, key)
+	case "GetObject", "HeadObject":
+		s.getObject(w, r, bucketName, key)
+	case "DeleteObject":
+		s.deleteObject(w, bucketName, key)
+	case "CreateMultipartUpload":
+		s.createMultipartUpload(w, bucketName, key)
+	case "UploadPart":
+		s.uploadPart(w, r, body)
+	case "CompleteMultipartUpload":
+		s.completeMultipartUpload(w, r, body)
+	case "AbortMultipartUpload":
+		s.abortMultipartUpload(w, r)
+	default:
+		writeError(w, http.StatusNotImplemented, "NotImplemented", fmt.Sprintf("%s %s is not implemented", r.Method, r.URL))
+	}
+}
+
+func (s *Server) route(r *http.Request, bucketName, key string) string {
+	query := r.URL.Query()
+	switch {
+	case bucketName == "" && r.Method == http.MethodGet:
+		return "ListBuckets"
+	case key == "":
+		switch r.Method {
+		case http.MethodPut:
+			return "CreateBucket"
+		case http.MethodHead:
+			return "HeadBucket"
+		case http.MethodDelete:
+			return "DeleteBucket"
+		case http.MethodGet:
+			if query.Get("list-type") == "2" {
+				return "ListObjectsV2"
+			}
+		}
+	case query.Has("uploads") && r.Method == http.MethodPost:
+		return "CreateMultipartUpload"
+	case query.Has("uploadId"):
+		switch r.Method {
+		case http.MethodPut:
+			return "UploadPart"
+		case http.MethodPost:
+			return "CompleteMultipartUpload"
+		case http.MethodDelete:
+			return "AbortMultipartUpload"
+		}
+	default:
+		switch r.Method {
+		case http.MethodPut:
+			if r.Header.Get("X-Amz-Copy-Source") != "" {
+				return "CopyObject"
+			}
+			return "PutObject"
+		case http.MethodGet:
+			return "GetObject"
+		case http.MethodHead:
+			return "HeadObject"
+		case http.MethodDelete:
+			return "DeleteObject"
+		}
+	}
+	return ""
+}
+
+// authenticate checks SigV4 signature of the request, it returns S3 error code when the request is rejected
+func (s *Server) authenticate(r *http.Request, body []byte) (code, message string) {
+	match := authorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+	if match == nil {
+		return "AccessDenied", "request is not signed with AWS4-HMAC-SHA256"
+	}
+	accessKey, region, service, signedHeaders := match[1], match[3], match[4], strings.Split(match[5], ";")
+
+	s.mu.Lock()
+	secretKey, ok := s.credentials[accessKey]
+	s.mu.Unlock()
+	if !ok {
+		return "InvalidAccessKeyId", fmt.Sprintf("access key [%s] does not exist", accessKey)
+	}
+
+	signTime, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return "AccessDenied", "X-Amz-Date is missing or invalid"
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != unsignedPayload {
+		sum := sha256.Sum256(body)
+		if payloadHash != hex.EncodeToString(sum[:]) {
+			return "XAmzContentSHA256Mismatch", "payload hash does not match the body"
+		}
+	}
+
+	// the request is signed again with headers listed by the client only
+	signed, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		return "AccessDenied", err.Error()
+	}
+	for _, header := range signedHeaders {
+		switch header {
+		case "host":
+		case "content-length":
+			// net/http moves the header to ContentLength of the request
+			signed.Header.Set("Content-Length", strconv.FormatInt(r.ContentLength, 10))
+		default:
+			signed.Header[http.CanonicalHeaderKey(header)] = r.Header.Values(header)
+		}
+	}
+
+	signer := v4.NewSigner(credentials.NewStaticCredentials(accessKey, secretKey, ""), func(signer *v4.Signer) {
+		signer.DisableURIPathEscaping = true
+	})
+	if _, err := signer.Sign(signed, nil, service, region, signTime); err != nil {
+		return "AccessDenied", err.Error()
+	}
+	if signed.Header.Get("Authorization") != r.Header.Get("Authorization") {
+		return "SignatureDoesNotMatch", "the request signature does not match the signature calculated by the server"
+	}
+	return "", ""
+}
+
+type errorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeXML(w, status, errorResponse{Code: code, Message: message})
+}
+
+func writeXML(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,196 @@
+// Package redact removes secrets from test logs, which are shared with external contributors.
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Mask replaces every redacted secret
+const Mask = "****"
+
+// MinSecretLength is the shortest secret accepted by AddSecret, shorter ones would mask ordinary words
+const MinSecretLength = 8
+
+// mnemonicLength is the shortest run of BIP39 words considered a mnemonic
+const mnemonicLength = 12
+
+// pattern masks the named group "secret" of every match
+type pattern struct {
+	regexp *regexp.Regexp
+}
+
+// Contains patterns of secrets recognised without registration
+var defaultPatterns = []string{
+	// BLS private keys and other secrets following their name, e.g. `secret key [hex]` or `"private_key":"hex"`
+	`(?i)(?:private|secret)[_ -]?key["']?\s*(?:[:=]\s*["']?|\[|\s)\s*(?P<secret>[0-9a-f]{64})`,
+
+	// JWTs, either recognised by their header or following their name, e.g. `jwt token [token]`
+	`(?P<secret>eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*)`,
+	`(?i)jwt[_ -]?token["']?\s*(?:[:=]\s*["']?|\[|\s)\s*(?P<secret>[^\s"'\],]+)`,
+
+	// X-App-* and other authentication headers, as header maps or request dumps
+	`(?i)(?:X-App-[A-Za-z-]+|Authorization)["']?\s*(?:[:=]\s*|\s+)\[?["']?(?P<secret>[^\s"'\],]+)`,
+
+	// S3 credentials passed as CLI flags or configuration
+	`(?i)(?:--access-key|--secret-key|access[_-]key(?:[_-]id)?|secret[_-](?:access[_-])?key)["']?\s*(?:[:=]\s*["']?|\s+)(?P<secret>[^\s"',]+)`,
+	`(?P<secret>\b(?:AKIA|ASIA)[0-9A-Z]{16}\b)`,
+
+	// Dropbox and Google Drive tokens
+	`(?i)(?:--access-token|access[_-]token)["']?\s*(?:[:=]\s*["']?|\s+)(?P<secret>[^\s"',]+)`,
+	`(?P<secret>\bsl\.[A-Za-z0-9_-]{20,})`,
+	`(?P<secret>\bya29\.[A-Za-z0-9_-]+)`,
+}
+
+var mnemonicCandidate = regexp.MustCompile(`\b[a-z]+(?: [a-z]+){11,}\b`)
+
+// Redactor replaces known secrets in log messages with Mask
+type Redactor struct {
+	patterns []pattern
+
+	mu      sync.RWMutex
+	secrets map[string]struct{}
+
+	// replacer masks all secrets at once, it is rebuilt on first Redact after AddSecret
+	replacer *strings.Replacer
+}
+
+// Default is used by test.SystemTest and cliutils.Logger
+var Default = New()
+
+// New returns redactor recognising default patterns
+func New() *Redactor {
+	r := &Redactor{secrets: make(map[string]struct{})}
+	for _, expression := range defaultPatterns {
+		r.patterns = append(r.patterns, pattern{regexp: regexp.MustCompile(expression)})
+	}
+	return r
+}
+
+// AddSecret registers secrets generated or loaded at runtime, e.g. keys of a new wallet
+func (r *Redactor) AddSecret(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, secret := range secrets {
+		secret = strings.TrimSpace(secret)
+		if len(secret) < MinSecretLength {
+			continue
+		}
+		if _, ok := r.secrets[secret]; !ok {
+			r.secrets[secret] = struct{}{}
+			r.replacer = nil
+		}
+	}
+}
+
+func (r *Redactor) secretReplacer() *strings.Replacer {
+	r.mu.RLock()
+	replacer := r.replacer
+	r.mu.RUnlock()
+	if replacer != nil {
+		return replacer
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replacer != nil {
+		return r.replacer
+	}
+
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+	// replacer tries secrets in order, longer ones go first so a secret containing another one is masked whole
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+		return secrets[i] < secrets[j]
+	})
+
+	oldnew := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		oldnew = append(oldnew, secret, Mask)
+	}
+	r.replacer = strings.NewReplacer(oldnew...)
+	return r.replacer
+}
+
+// Redact returns message with every recognised secret replaced by Mask
+func (r *Redactor) Redact(message string) string {
+	message = r.secretReplacer().Replace(message)
+
+	for _, p := range r.patterns {
+		message = p.redact(message)
+	}
+
+	return redactMnemonics(message)
+}
+
+func (p pattern) redact(message string) string {
+	group := p.regexp.SubexpIndex("secret")
+
+	matches := p.regexp.FindAllStringSubmatchIndex(message, -1)
+	if len(matches) == 0 {
+		return message
+	}
+
+	var builder strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[2*group], match[2*group+1]
+		if start < 0 || message[start:end] == Mask {
+			continue
+		}
+		builder.WriteString(message[last:start])
+		builder.WriteString(Mask)
+		last = end
+	}
+	builder.WriteString(message[last:])
+	return builder.String()
+}
+
+// redactMnemonics masks every run of at least 12 BIP39 words
+func redactMnemonics(message string) string {
+	return mnemonicCandidate.ReplaceAllStringFunc(message, func(candidate string) string {
+		words := strings.Split(candidate, " ")
+
+		var result []string
+		run := 0
+		flush := func(end int) {
+			if run >= mnemonicLength {
+				result = append(result, Mask)
+			} else {
+				result = append(result, words[end-run:end]...)
+			}
+			run = 0
+		}
+		for i, word := range words {
+			if _, ok := bip39.GetWordIndex(word); ok {
+				run++
+				continue
+			}
+			flush(i)
+			result = append(result, word)
+		}
+		flush(len(words))
+
+		return strings.Join(result, " ")
+	})
+}
+
+// AddSecret registers secrets in Default redactor
+func AddSecret(secrets ...string) {
+	Default.AddSecret(secrets...)
+}
+
+// Redact removes secrets from message using Default redactor
+func Redact(message string) string {
+	return Default.Redact(message)
+}
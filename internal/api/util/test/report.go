@@ -0,0 +1,196 @@
+package test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultReporter receives records of every finished test case when set, see NewReporter
+var DefaultReporter *Reporter
+
+// TestRecord is a structured result of a single test function or test case
+type TestRecord struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"`
+	Smoke  bool   `json:"smoke"`
+
+	Scheduled time.Time `json:"scheduled"`
+
+	// QueueDuration is spent waiting for a free slot of parallel tests
+	QueueDuration time.Duration `json:"queue_duration_ns"`
+	SetupDuration time.Duration `json:"setup_duration_ns"`
+	RunDuration   time.Duration `json:"run_duration_ns"`
+
+	Timeout  time.Duration `json:"timeout_ns,omitempty"`
+	TimedOut bool          `json:"timed_out"`
+
+	// Panic contains value recovered by the framework, if any
+	Panic string `json:"panic,omitempty"`
+
+	// Retries counts failed attempts of retried CLI commands
+	Retries int `json:"retries"`
+
+	// Metrics are added by the test with AddMetrics, keyed by name in order of addition
+	Metrics map[string][]interface{} `json:"metrics,omitempty"`
+
+	Failed  bool `json:"failed"`
+	Skipped bool `json:"skipped"`
+}
+
+// Reporter writes test records of a suite as JSON lines, as soon as every test finishes,
+// and as JUnit XML when closed
+type Reporter struct {
+	suite string
+	dir   string
+
+	mu        sync.Mutex
+	started   time.Time
+	records   []*TestRecord
+	jsonLines *os.File
+}
+
+// NewReporter creates dir and starts <suite>.jsonl report in it
+func NewReporter(dir, suite string) (*Reporter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	jsonLines, err := os.Create(filepath.Join(dir, suite+".jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reporter{
+		suite:     suite,
+		dir:       dir,
+		started:   time.Now(),
+		jsonLines: jsonLines,
+	}, nil
+}
+
+// NewReporterFromEnv sets DefaultReporter writing to TEST_REPORT_DIR, reporting is disabled when it is not set
+func NewReporterFromEnv(suite string) error {
+	dir := os.Getenv("TEST_REPORT_DIR")
+	if dir == "" {
+		return nil
+	}
+
+	reporter, err := NewReporter(dir, suite)
+	if err != nil {
+		return err
+	}
+	DefaultReporter = reporter
+	return nil
+}
+
+// Record adds finished test to the report
+func (r *Reporter) Record(record *TestRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, record)
+
+	line, err := json.Marshal(record)
+	if err == nil {
+		_, err = r.jsonLines.Write(append(line, '\n'))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report of test [%s]: %v\n", record.Name, err)
+	}
+}
+
+// Records returns every recorded test in order of completion
+func (r *Reporter) Records() []*TestRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*TestRecord(nil), r.records...)
+}
+
+// Close writes <suite>.xml JUnit report
+func (r *Reporter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.jsonLines.Close(); err != nil {
+		return err
+	}
+
+	suite := junitTestSuite{
+		Name:      r.suite,
+		Timestamp: r.started.Format(time.RFC3339),
+		Time:      seconds(time.Since(r.started)),
+	}
+	for _, record := range r.records {
+		testCase := junitTestCase{
+			Name:      record.Name,
+			ClassName: r.suite,
+			Time:      seconds(record.RunDuration),
+		}
+		if record.Parent != "" {
+			testCase.ClassName = record.Parent
+		}
+
+		switch {
+		case record.TimedOut:
+			testCase.Failure = &junitFailure{Type: "timeout", Message: fmt.Sprintf("timed out after [%s]", record.Timeout)}
+		case record.Panic != "":
+			testCase.Failure = &junitFailure{Type: "panic", Message: record.Panic}
+		case record.Failed:
+			testCase.Failure = &junitFailure{Type: "failure", Message: "test failed, see test output"}
+		case record.Skipped:
+			testCase.Skipped = &struct{}{}
+		}
+
+		suite.Tests++
+		if testCase.Failure != nil {
+			suite.Failures++
+		}
+		if testCase.Skipped != nil {
+			suite.Skipped++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	output, err := xml.MarshalIndent(junitTestSuites{TestSuites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, r.suite+".xml"), append([]byte(xml.Header), output...), 0o644) //nolint:gosec
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+}
+
+func seconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
@@ -0,0 +1,88 @@
+package test_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter(t *testing.T) {
+	dir := t.TempDir()
+	reporter, err := test.NewReporter(dir, "suite")
+	require.NoError(t, err)
+
+	test.DefaultReporter = reporter
+	defer func() {
+		test.DefaultReporter = nil
+	}()
+
+	t.Run("TestSuite", func(testSetup *testing.T) {
+		s := test.NewSystemTest(testSetup)
+		s.SetSmokeTests("Retried case")
+
+		s.TestSetup("Setup", func() {
+			time.Sleep(10 * time.Millisecond)
+		})
+
+		s.RunSequentially("Retried case", func(t *test.SystemTest) {
+			t.AddRetry()
+			t.AddRetry()
+			t.AddMetrics("download", map[string]int{"bytes": 1024})
+		})
+
+		s.RunSequentially("Skipped case", func(t *test.SystemTest) {
+			t.Skip("not supported")
+		})
+	})
+
+	require.NoError(t, reporter.Close())
+
+	records := reporter.Records()
+	require.Len(t, records, 3)
+
+	retried := records[0]
+	require.Equal(t, "TestReporter/TestSuite/Retried_case", retried.Name)
+	require.Equal(t, "TestReporter/TestSuite", retried.Parent)
+	require.True(t, retried.Smoke)
+	require.Equal(t, 2, retried.Retries)
+	require.Equal(t, map[string][]interface{}{"download": {map[string]int{"bytes": 1024}}}, retried.Metrics)
+	require.Equal(t, test.DefaultTestTimeout, retried.Timeout)
+	require.False(t, retried.Failed)
+	require.False(t, retried.TimedOut)
+
+	require.True(t, records[1].Skipped)
+	require.False(t, records[1].Smoke)
+
+	suite := records[2]
+	require.Equal(t, "TestReporter/TestSuite", suite.Name)
+	require.Empty(t, suite.Parent)
+	require.GreaterOrEqual(t, suite.SetupDuration, 10*time.Millisecond)
+	require.GreaterOrEqual(t, suite.RunDuration, suite.SetupDuration)
+
+	jsonLines, err := os.Open(filepath.Join(dir, "suite.jsonl"))
+	require.NoError(t, err)
+	defer jsonLines.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(jsonLines)
+	for scanner.Scan() {
+		var record test.TestRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		names = append(names, record.Name)
+		if record.Name == retried.Name {
+			require.Equal(t, map[string][]interface{}{"download": {map[string]interface{}{"bytes": float64(1024)}}}, record.Metrics)
+		}
+	}
+	require.Equal(t, []string{retried.Name, records[1].Name, suite.Name}, names)
+
+	junit, err := os.ReadFile(filepath.Join(dir, "suite.xml"))
+	require.NoError(t, err)
+	require.Contains(t, string(junit), `<testsuite name="suite" tests="3" failures="0" skipped="1"`)
+	require.Contains(t, string(junit), `<testcase name="TestReporter/TestSuite/Retried_case" classname="TestReporter/TestSuite"`)
+}
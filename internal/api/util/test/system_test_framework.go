@@ -1,12 +1,15 @@
 package test
 
 import (
+	"fmt"
 	"log"
 	"runtime/debug"
 	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/0chain/system_test/internal/api/util/redact"
 )
 
 var DefaultTestTimeout = 40 * time.Second
@@ -18,10 +21,22 @@ type SystemTest struct {
 	childTest          bool
 	runAllTestsAsSmoke bool
 	smokeTests         map[string]bool
+
+	recordMu sync.Mutex
+	record   TestRecord
+	started  time.Time
 }
 
 func NewSystemTest(t *testing.T) *SystemTest {
-	return &SystemTest{Unwrap: t, testComplete: false, childTest: false}
+	s := &SystemTest{Unwrap: t, testComplete: false, childTest: false}
+	s.startRecord(TestRecord{Name: t.Name()})
+	t.Cleanup(func() {
+		s.recordMu.Lock()
+		s.record.Smoke = s.runAllTestsAsSmoke
+		s.recordMu.Unlock()
+		s.report()
+	})
+	return s
 }
 
 func (s *SystemTest) Run(name string, testCaseFunction func(w *SystemTest)) bool {
@@ -68,6 +83,7 @@ func (s *SystemTest) testSetup(label string, timeout time.Duration, setupFunctio
 		wg.Add(1)
 
 		s.Logf("Test setup [%s] scheduled at [%s] ", label, time.Now().Format("01-02-2006 15:04:05"))
+		defer s.addSetupDuration(time.Now())
 
 		testSetupChannel := make(chan struct{}, 1)
 
@@ -99,6 +115,13 @@ func (s *SystemTest) run(name string, timeout time.Duration, testFunction func(w
 	s.Unwrap.Helper()
 	timeoutWrappedTestCase := func(testSetup *testing.T) {
 		t := &SystemTest{Unwrap: testSetup, testComplete: false, childTest: true}
+		t.startRecord(TestRecord{
+			Name:    testSetup.Name(),
+			Parent:  s.Unwrap.Name(),
+			Smoke:   s.runAllTestsAsSmoke || s.smokeTests[name],
+			Timeout: timeout,
+		})
+		testSetup.Cleanup(t.report)
 
 		if SmokeTestMode && !s.runAllTestsAsSmoke && !s.smokeTests[name] {
 			t.Skip("Test skipped as it is not a smoke test.")
@@ -128,6 +151,7 @@ func (s *SystemTest) run(name string, timeout time.Duration, testFunction func(w
 
 		select {
 		case <-time.After(timeout):
+			t.setTimedOut()
 			t.Errorf("Test case [%s] timed out after [%s]", name, timeout)
 		case _ = <-testCaseChannel:
 		}
@@ -154,16 +178,22 @@ func executeTest(s *SystemTest, name string, testFunction func(w *SystemTest), t
 
 func handlePanic(s *SystemTest) {
 	if err := recover(); err != nil {
+		s.setPanic(err)
 		s.Errorf("Test case exited due to panic - [%v], stack: [%v]", err, string(debug.Stack()))
 	}
 }
 
 func handleTestCaseExit() {
 	if err := recover(); err != nil {
-		log.Printf("Suppressed test function panic - [%v]", err)
+		log.Print(redact.Redact(fmt.Sprintf("Suppressed test function panic - [%v]", err)))
 	}
 }
 
+// sprint formats args like testing.T.Log and removes secrets, every message of the test goes through it
+func sprint(args ...any) string {
+	return redact.Redact(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
 // Boilerplate due to go's very unhelpful lack of polymorphism...
 
 func (s *SystemTest) Cleanup(f func()) {
@@ -176,7 +206,7 @@ func (s *SystemTest) Error(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Error(args...)
+		s.Unwrap.Error(sprint(args...))
 	}
 }
 
@@ -184,7 +214,7 @@ func (s *SystemTest) Errorf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Errorf(format, args...)
+		s.Unwrap.Error(redact.Redact(fmt.Sprintf(format, args...)))
 	}
 }
 
@@ -214,7 +244,7 @@ func (s *SystemTest) Fatal(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Fatal(args...)
+		s.Unwrap.Fatal(sprint(args...))
 	}
 }
 
@@ -222,7 +252,7 @@ func (s *SystemTest) Fatalf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Fatalf(format, args...)
+		s.Unwrap.Fatal(redact.Redact(fmt.Sprintf(format, args...)))
 	}
 }
 
@@ -230,7 +260,7 @@ func (s *SystemTest) Log(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Log(args...)
+		s.Unwrap.Log(sprint(args...))
 	}
 }
 
@@ -238,7 +268,7 @@ func (s *SystemTest) Logf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Logf(format, args...)
+		s.Unwrap.Log(redact.Redact(fmt.Sprintf(format, args...)))
 	}
 }
 
@@ -269,7 +299,7 @@ func (s *SystemTest) Skip(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Skip(args...)
+		s.Unwrap.Skip(sprint(args...))
 	}
 }
 
@@ -285,7 +315,7 @@ func (s *SystemTest) Skipf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Skipf(format, args...)
+		s.Unwrap.Skip(redact.Redact(fmt.Sprintf(format, args...)))
 	}
 }
 
@@ -305,6 +335,7 @@ func (s *SystemTest) Parallel() {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
+		defer s.addQueueDuration(time.Now())
 		s.Unwrap.Parallel()
 	}
 }
@@ -319,3 +350,79 @@ func (s *SystemTest) SetSmokeTests(smokeTests ...string) {
 		s.smokeTests[v] = true
 	}
 }
+
+// AddRetry counts a failed attempt of a retried operation in the test record
+func (s *SystemTest) AddRetry() {
+	if s == nil {
+		return
+	}
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+	s.record.Retries++
+}
+
+// AddMetrics adds metrics collected by the test, e.g. of SDK operations, to the test record
+func (s *SystemTest) AddMetrics(name string, metrics interface{}) {
+	if s == nil {
+		return
+	}
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+	if s.record.Metrics == nil {
+		s.record.Metrics = make(map[string][]interface{})
+	}
+	s.record.Metrics[name] = append(s.record.Metrics[name], metrics)
+}
+
+func (s *SystemTest) startRecord(record TestRecord) {
+	s.started = time.Now()
+	record.Scheduled = s.started
+	s.record = record
+}
+
+func (s *SystemTest) addQueueDuration(since time.Time) {
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+	s.record.QueueDuration += time.Since(since)
+	s.started = time.Now()
+}
+
+func (s *SystemTest) addSetupDuration(since time.Time) {
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+	s.record.SetupDuration += time.Since(since)
+}
+
+func (s *SystemTest) setTimedOut() {
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+	s.record.TimedOut = true
+}
+
+func (s *SystemTest) setPanic(err interface{}) {
+	s.recordMu.Lock()
+	defer s.recordMu.Unlock()
+	s.record.Panic = sprint(err)
+}
+
+// report sends the record of a finished test to DefaultReporter
+func (s *SystemTest) report() {
+	if DefaultReporter == nil {
+		return
+	}
+
+	s.recordMu.Lock()
+	record := s.record
+	record.RunDuration = time.Since(s.started)
+	if s.record.Metrics != nil {
+		record.Metrics = make(map[string][]interface{}, len(s.record.Metrics))
+		for name, metrics := range s.record.Metrics {
+			record.Metrics[name] = append([]interface{}(nil), metrics...)
+		}
+	}
+	s.recordMu.Unlock()
+
+	record.Failed = s.Unwrap.Failed()
+	record.Skipped = s.Unwrap.Skipped()
+	DefaultReporter.Record(&record)
+}
@@ -0,0 +1,115 @@
+// Package rewards is a reference model of the miner and storage smart contract economics. It replays
+// a sequence of chain events against a config snapshot and predicts the exact rewards of every provider
+// and delegate pool, so tests can compare them with sharder data without a tolerance.
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/0chain/system_test/internal/currency"
+)
+
+var ErrMissingConfig = errors.New("missing config value")
+
+// Config is the snapshot of the miner and storage smart contract settings the rewards depend on
+type Config struct {
+	// Contains miner smart contract settings
+	Epoch                       int64
+	BlockReward                 currency.Coin
+	RewardDeclineRate           float64
+	ShareRatio                  float64
+	NumMinerDelegatesRewarded   int
+	NumShardersRewarded         int
+	NumSharderDelegatesRewarded int
+
+	// Contains storage smart contract settings
+	ValidatorReward    float64
+	BlobberSlash       float64
+	CancellationCharge float64
+	TimeUnit           time.Duration
+}
+
+// NewConfig reads config from the key value pairs printed by zwallet mn-config and zbox sc-config
+func NewConfig(minerSC, storageSC map[string]string) (Config, error) {
+	var (
+		config Config
+		err    error
+	)
+	p := configParser{}
+
+	config.Epoch = p.int64(minerSC, "epoch")
+	config.RewardDeclineRate = p.float64(minerSC, "reward_decline_rate")
+	config.ShareRatio = p.float64(minerSC, "share_ratio")
+	config.NumMinerDelegatesRewarded = int(p.int64(minerSC, "num_miner_delegates_rewarded"))
+	config.NumShardersRewarded = int(p.int64(minerSC, "num_sharders_rewarded"))
+	config.NumSharderDelegatesRewarded = int(p.int64(minerSC, "num_sharder_delegates_rewarded"))
+	blockReward := p.float64(minerSC, "block_reward")
+
+	config.ValidatorReward = p.float64(storageSC, "validator_reward")
+	config.BlobberSlash = p.float64(storageSC, "blobber_slash")
+	config.CancellationCharge = p.float64(storageSC, "cancellation_charge")
+	config.TimeUnit = p.duration(storageSC, "time_unit")
+
+	if p.err != nil {
+		return Config{}, p.err
+	}
+	if config.BlockReward, err = currency.ParseZCN(blockReward); err != nil {
+		return Config{}, fmt.Errorf("block_reward: %w", err)
+	}
+	return config, nil
+}
+
+// configParser keeps the first error, so the settings can be read without checking each of them
+type configParser struct {
+	err error
+}
+
+func (p *configParser) value(values map[string]string, key string) (string, bool) {
+	if p.err != nil {
+		return "", false
+	}
+	value, ok := values[key]
+	if !ok {
+		p.err = fmt.Errorf("%w: %s", ErrMissingConfig, key)
+	}
+	return value, ok
+}
+
+func (p *configParser) int64(values map[string]string, key string) int64 {
+	value, ok := p.value(values, key)
+	if !ok {
+		return 0
+	}
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		p.err = fmt.Errorf("%s: %w", key, err)
+	}
+	return result
+}
+
+func (p *configParser) float64(values map[string]string, key string) float64 {
+	value, ok := p.value(values, key)
+	if !ok {
+		return 0
+	}
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		p.err = fmt.Errorf("%s: %w", key, err)
+	}
+	return result
+}
+
+func (p *configParser) duration(values map[string]string, key string) time.Duration {
+	value, ok := p.value(values, key)
+	if !ok {
+		return 0
+	}
+	result, err := time.ParseDuration(value)
+	if err != nil {
+		p.err = fmt.Errorf("%s: %w", key, err)
+	}
+	return result
+}
@@ -0,0 +1,378 @@
+package rewards
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/0chain/system_test/internal/currency"
+)
+
+const gb = 1024 * 1024 * 1024
+
+// Event changes state of the model, events are applied in the order they happened on chain
+type Event interface {
+	apply(m *Model) error
+}
+
+// StakeChange stakes Amount to the delegate pool of provider, negative Amount unstakes
+type StakeChange struct {
+	Provider string
+	Delegate string
+	Amount   int64
+}
+
+func (e StakeChange) apply(m *Model) error {
+	p, err := m.provider(e.Provider)
+	if err != nil {
+		return err
+	}
+
+	stake := p.stakes[e.Delegate]
+	if e.Amount >= 0 {
+		p.stakes[e.Delegate], err = currency.AddInt64(stake, e.Amount)
+		return err
+	}
+
+	if stake < currency.Coin(-e.Amount) {
+		return fmt.Errorf("%w: %s has %d, unstaking %d", ErrInsufficientStake, e.Delegate, stake, -e.Amount)
+	}
+	p.stakes[e.Delegate] = stake - currency.Coin(-e.Amount)
+	return nil
+}
+
+// Block pays the block reward and the fees of a block to its miner and to the rewarded sharders.
+// The chain picks rewarded sharders and delegate pools at random, so they are taken from sharder data.
+// Picked is only needed for providers with more delegate pools than the configured number of rewarded ones.
+type Block struct {
+	Round    int64
+	Miner    string
+	Sharders []string
+	Fees     currency.Coin
+	Picked   map[string][]string
+}
+
+func (e Block) apply(m *Model) error {
+	if e.Round < 0 || m.config.Epoch <= 0 {
+		return fmt.Errorf("%w: round %d of epoch %d", ErrInvalidEvent, e.Round, m.config.Epoch)
+	}
+
+	minerReward, sharderReward, err := BlockReward(m.config, e.Round)
+	if err != nil {
+		return err
+	}
+	minerFees, err := currency.MultFloat64(e.Fees, m.config.ShareRatio)
+	if err != nil {
+		return err
+	}
+	sharderFees := e.Fees - minerFees
+
+	miner, err := m.providerOfType(e.Miner, Miner)
+	if err != nil {
+		return err
+	}
+	pools, err := e.rewardedPools(miner, m.config.NumMinerDelegatesRewarded)
+	if err != nil {
+		return err
+	}
+	if err := miner.distribute(minerReward, pools); err != nil {
+		return err
+	}
+	if err := miner.distribute(minerFees, pools); err != nil {
+		return err
+	}
+
+	if len(e.Sharders) == 0 {
+		return nil
+	}
+	if m.config.NumShardersRewarded > 0 && len(e.Sharders) > m.config.NumShardersRewarded {
+		return fmt.Errorf("%w: %d sharders rewarded, at most %d expected", ErrInvalidEvent, len(e.Sharders), m.config.NumShardersRewarded)
+	}
+
+	// remainders of the split between sharders are not minted
+	sharderReward, _, err = currency.DistributeCoin(sharderReward, int64(len(e.Sharders)))
+	if err != nil {
+		return err
+	}
+	sharderFees, _, err = currency.DistributeCoin(sharderFees, int64(len(e.Sharders)))
+	if err != nil {
+		return err
+	}
+	for _, id := range e.Sharders {
+		sharder, err := m.providerOfType(id, Sharder)
+		if err != nil {
+			return err
+		}
+		pools, err := e.rewardedPools(sharder, m.config.NumSharderDelegatesRewarded)
+		if err != nil {
+			return err
+		}
+		if err := sharder.distribute(sharderReward, pools); err != nil {
+			return err
+		}
+		if err := sharder.distribute(sharderFees, pools); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e Block) rewardedPools(p *provider, limit int) ([]string, error) {
+	pools := p.pools()
+	if limit <= 0 {
+		return nil, nil
+	}
+	if len(pools) <= limit {
+		return pools, nil
+	}
+
+	picked, ok := e.Picked[p.ID]
+	if !ok || len(picked) != limit {
+		return nil, fmt.Errorf("%w: %s has %d pools, %d rewarded", ErrDelegatesNotPicked, p.ID, len(pools), limit)
+	}
+	picked = append([]string(nil), picked...)
+	sort.Strings(picked)
+	return picked, nil
+}
+
+// BlockReward returns the block reward of round declined by epochs and split between its miner and sharders
+func BlockReward(config Config, round int64) (minerReward, sharderReward currency.Coin, err error) {
+	epoch := round / config.Epoch
+	reward, err := currency.MultFloat64(config.BlockReward, math.Pow(1-config.RewardDeclineRate, float64(epoch)))
+	if err != nil {
+		return 0, 0, err
+	}
+	minerReward, err = currency.MultFloat64(reward, config.ShareRatio)
+	if err != nil {
+		return 0, 0, err
+	}
+	return minerReward, reward - minerReward, nil
+}
+
+// NewAllocation creates allocation, WritePrice is the price of a GB per time unit on every blobber
+type NewAllocation struct {
+	ID         string
+	Blobbers   []string
+	DataShards int64
+	Size       int64
+	WritePrice currency.Coin
+	Start      time.Time
+	Expiration time.Time
+}
+
+func (e NewAllocation) apply(m *Model) error {
+	if _, ok := m.allocations[e.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateAllocation, e.ID)
+	}
+	if len(e.Blobbers) == 0 || e.DataShards <= 0 || e.DataShards > int64(len(e.Blobbers)) || !e.Expiration.After(e.Start) {
+		return fmt.Errorf("%w: allocation %s", ErrInvalidEvent, e.ID)
+	}
+
+	a := &allocation{
+		blobbers:       append([]string(nil), e.Blobbers...),
+		dataShards:     e.DataShards,
+		size:           e.Size,
+		writePrice:     e.WritePrice,
+		start:          e.Start,
+		expiration:     e.Expiration,
+		challengePools: make(map[string]currency.Coin),
+		lastChallenges: make(map[string]time.Time),
+	}
+	for _, id := range e.Blobbers {
+		if _, err := m.providerOfType(id, Blobber); err != nil {
+			return err
+		}
+		a.lastChallenges[id] = e.Start
+	}
+	m.allocations[e.ID] = a
+	return nil
+}
+
+// Upload moves the write price of the uploaded size for the rest of the allocation to the challenge pool
+type Upload struct {
+	Allocation string
+	Size       int64
+	At         time.Time
+}
+
+func (e Upload) apply(m *Model) error {
+	a, err := m.activeAllocation(e.Allocation, e.At)
+	if err != nil {
+		return err
+	}
+	if e.Size <= 0 || m.config.TimeUnit <= 0 {
+		return fmt.Errorf("%w: upload of %d bytes in time unit %s", ErrInvalidEvent, e.Size, m.config.TimeUnit)
+	}
+
+	shardSize := (e.Size + a.dataShards - 1) / a.dataShards
+	timeUnits := float64(a.expiration.Sub(e.At)) / float64(m.config.TimeUnit)
+	cost, err := currency.MultFloat64(a.writePrice, float64(shardSize)/gb*timeUnits)
+	if err != nil {
+		return err
+	}
+	for _, id := range a.blobbers {
+		a.challengePools[id] += cost
+		a.pools.ChallengePool += cost
+		a.pools.MovedToChallenge += cost
+	}
+	return nil
+}
+
+// Challenge pays the part of the challenge pool of blobber for the time since its previous challenge.
+// Validators share the validator reward of it equally. Reward of a failed challenge goes back to the
+// allocation and the blobber is slashed blobber_slash of it.
+type Challenge struct {
+	Allocation string
+	Blobber    string
+	Validators []string
+	Passed     bool
+	At         time.Time
+}
+
+func (e Challenge) apply(m *Model) error {
+	a, err := m.activeAllocation(e.Allocation, e.At)
+	if err != nil {
+		return err
+	}
+	blobber, err := a.blobber(m, e.Blobber)
+	if err != nil {
+		return err
+	}
+	if len(e.Validators) == 0 {
+		return fmt.Errorf("%w: challenge without validators", ErrInvalidEvent)
+	}
+
+	reward, err := a.challengeReward(e.Blobber, e.At)
+	if err != nil {
+		return err
+	}
+
+	validatorsReward, err := currency.MultFloat64(reward, m.config.ValidatorReward)
+	if err != nil {
+		return err
+	}
+	validatorReward, _, err := currency.DistributeCoin(validatorsReward, int64(len(e.Validators)))
+	if err != nil {
+		return err
+	}
+	for _, id := range e.Validators {
+		validator, err := m.providerOfType(id, Validator)
+		if err != nil {
+			return err
+		}
+		if err := validator.distribute(validatorReward, validator.pools()); err != nil {
+			return err
+		}
+	}
+
+	blobberReward := reward - validatorReward*currency.Coin(len(e.Validators))
+	if e.Passed {
+		return blobber.distribute(blobberReward, blobber.pools())
+	}
+
+	a.pools.MovedBack += blobberReward
+	penalty, err := currency.MultFloat64(blobberReward, m.config.BlobberSlash)
+	if err != nil {
+		return err
+	}
+	return blobber.slash(penalty)
+}
+
+// CancelAllocation pays blobbers for the time the allocation was active and returns the rest of the
+// challenge pool. Blobbers share the cancellation charge of a time unit of the allocation equally,
+// minus what they have already earned from it.
+type CancelAllocation struct {
+	Allocation string
+	At         time.Time
+}
+
+func (e CancelAllocation) apply(m *Model) error {
+	a, err := m.activeAllocation(e.Allocation, e.At)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range a.blobbers {
+		blobber, err := a.blobber(m, id)
+		if err != nil {
+			return err
+		}
+		reward, err := a.challengeReward(id, e.At)
+		if err != nil {
+			return err
+		}
+		if err := blobber.distribute(reward, blobber.pools()); err != nil {
+			return err
+		}
+	}
+	for _, id := range a.blobbers {
+		a.pools.MovedBack += a.challengePools[id]
+		a.pools.ChallengePool -= a.challengePools[id]
+		a.challengePools[id] = 0
+	}
+	a.cancelled = true
+
+	shardSize := (a.size + a.dataShards - 1) / a.dataShards
+	charge, err := currency.MultFloat64(a.writePrice, float64(shardSize)/gb*float64(len(a.blobbers))*m.config.CancellationCharge)
+	if err != nil {
+		return err
+	}
+	earned := a.pools.MovedToChallenge - a.pools.MovedBack
+	if charge <= earned {
+		return nil
+	}
+
+	blobberCharge, _, err := currency.DistributeCoin(charge-earned, int64(len(a.blobbers)))
+	if err != nil {
+		return err
+	}
+	for _, id := range a.blobbers {
+		blobber, err := a.blobber(m, id)
+		if err != nil {
+			return err
+		}
+		if err := blobber.distribute(blobberCharge, blobber.pools()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Model) activeAllocation(id string, at time.Time) (*allocation, error) {
+	a, err := m.allocation(id)
+	if err != nil {
+		return nil, err
+	}
+	if a.cancelled || at.Before(a.start) || at.After(a.expiration) {
+		return nil, fmt.Errorf("%w: allocation %s is not active at %s", ErrInvalidEvent, id, at)
+	}
+	return a, nil
+}
+
+func (a *allocation) blobber(m *Model, id string) (*provider, error) {
+	if _, ok := a.lastChallenges[id]; !ok {
+		return nil, fmt.Errorf("%w: blobber %s is not in the allocation", ErrInvalidEvent, id)
+	}
+	return m.providerOfType(id, Blobber)
+}
+
+// challengeReward takes the part of the challenge pool of blobber for the time since its previous challenge
+func (a *allocation) challengeReward(blobber string, at time.Time) (currency.Coin, error) {
+	last := a.lastChallenges[blobber]
+	if at.Before(last) {
+		return 0, fmt.Errorf("%w: challenge at %s is before the previous one", ErrInvalidEvent, at)
+	}
+	a.lastChallenges[blobber] = at
+	if !a.expiration.After(last) {
+		return 0, nil
+	}
+
+	reward, err := currency.MultFloat64(a.challengePools[blobber], float64(at.Sub(last))/float64(a.expiration.Sub(last)))
+	if err != nil {
+		return 0, err
+	}
+	a.challengePools[blobber] -= reward
+	a.pools.ChallengePool -= reward
+	return reward, nil
+}
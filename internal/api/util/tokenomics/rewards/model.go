@@ -0,0 +1,266 @@
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Contains types of providers
+const (
+	Miner     ProviderType = "miner"
+	Sharder   ProviderType = "sharder"
+	Blobber   ProviderType = "blobber"
+	Validator ProviderType = "validator"
+)
+
+var (
+	ErrUnknownProvider     = errors.New("unknown provider")
+	ErrDuplicateProvider   = errors.New("duplicate provider")
+	ErrProviderType        = errors.New("unexpected provider type")
+	ErrUnknownAllocation   = errors.New("unknown allocation")
+	ErrDuplicateAllocation = errors.New("duplicate allocation")
+	ErrInsufficientStake   = errors.New("insufficient stake")
+	ErrDelegatesNotPicked  = errors.New("rewarded delegates are not picked")
+	ErrInvalidEvent        = errors.New("invalid event")
+)
+
+type ProviderType string
+
+// Provider is a node registered in the model before the events are applied
+type Provider struct {
+	ID            string
+	Type          ProviderType
+	ServiceCharge float64
+}
+
+// Payout is the reward of a provider split between the provider and its delegate pools
+type Payout struct {
+	Provider  currency.Coin
+	Delegates map[string]currency.Coin
+}
+
+// Total returns reward of the provider together with rewards of its delegates
+func (p Payout) Total() currency.Coin {
+	total := p.Provider
+	for _, reward := range p.Delegates {
+		total += reward
+	}
+	return total
+}
+
+// AllocationPools contains tokens moved between the write pool and the challenge pool of an allocation
+type AllocationPools struct {
+	MovedToChallenge currency.Coin
+	MovedBack        currency.Coin
+	ChallengePool    currency.Coin
+}
+
+// Model replays events and keeps the rewards expected after each of them
+type Model struct {
+	config      Config
+	providers   map[string]*provider
+	allocations map[string]*allocation
+}
+
+type provider struct {
+	Provider
+	stakes    map[string]currency.Coin
+	rewards   Payout
+	penalties map[string]currency.Coin
+}
+
+type allocation struct {
+	blobbers   []string
+	dataShards int64
+	size       int64
+	writePrice currency.Coin
+	start      time.Time
+	expiration time.Time
+	cancelled  bool
+
+	challengePools map[string]currency.Coin
+	lastChallenges map[string]time.Time
+	pools          AllocationPools
+}
+
+func New(config Config) *Model {
+	return &Model{
+		config:      config,
+		providers:   make(map[string]*provider),
+		allocations: make(map[string]*allocation),
+	}
+}
+
+// AddProvider registers provider without any stake, stakes are added by StakeChange events
+func (m *Model) AddProvider(p Provider) error {
+	if _, ok := m.providers[p.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateProvider, p.ID)
+	}
+	m.providers[p.ID] = &provider{
+		Provider:  p,
+		stakes:    make(map[string]currency.Coin),
+		rewards:   Payout{Delegates: make(map[string]currency.Coin)},
+		penalties: make(map[string]currency.Coin),
+	}
+	return nil
+}
+
+// Apply applies events in order, it stops at the first event which is not valid for the state of the model
+func (m *Model) Apply(events ...Event) error {
+	for i, event := range events {
+		if err := event.apply(m); err != nil {
+			return fmt.Errorf("event %d %T: %w", i, event, err)
+		}
+	}
+	return nil
+}
+
+// Rewards returns all rewards paid to provider and its delegates so far
+func (m *Model) Rewards(providerID string) (Payout, error) {
+	p, err := m.provider(providerID)
+	if err != nil {
+		return Payout{}, err
+	}
+	return Payout{Provider: p.rewards.Provider, Delegates: copyCoins(p.rewards.Delegates)}, nil
+}
+
+// Penalties returns stake slashed from delegates of provider so far
+func (m *Model) Penalties(providerID string) (map[string]currency.Coin, error) {
+	p, err := m.provider(providerID)
+	if err != nil {
+		return nil, err
+	}
+	return copyCoins(p.penalties), nil
+}
+
+// Stake returns balance of delegate pool after penalties
+func (m *Model) Stake(providerID, delegate string) (currency.Coin, error) {
+	p, err := m.provider(providerID)
+	if err != nil {
+		return 0, err
+	}
+	return p.stakes[delegate], nil
+}
+
+func (m *Model) AllocationPools(allocationID string) (AllocationPools, error) {
+	a, err := m.allocation(allocationID)
+	if err != nil {
+		return AllocationPools{}, err
+	}
+	return a.pools, nil
+}
+
+func (m *Model) provider(id string) (*provider, error) {
+	p, ok := m.providers[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, id)
+	}
+	return p, nil
+}
+
+func (m *Model) providerOfType(id string, providerType ProviderType) (*provider, error) {
+	p, err := m.provider(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Type != providerType {
+		return nil, fmt.Errorf("%w: %s is %s, expected %s", ErrProviderType, id, p.Type, providerType)
+	}
+	return p, nil
+}
+
+func (m *Model) allocation(id string) (*allocation, error) {
+	a, ok := m.allocations[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAllocation, id)
+	}
+	return a, nil
+}
+
+// distribute pays the service charge to provider and splits the rest between the rewarded delegate pools
+// in proportion to their stake. Rewards are rounded down as on chain and the remainder of the split goes to
+// the pool with the lowest id. Which pool takes the remainder on chain is not verified, so delegate rewards
+// may differ from the chain by a unit. Provider takes the whole reward when no pool is rewarded.
+func (p *provider) distribute(value currency.Coin, pools []string) error {
+	if value == 0 {
+		return nil
+	}
+
+	var totalStake currency.Coin
+	for _, id := range pools {
+		totalStake += p.stakes[id]
+	}
+	if len(pools) == 0 || totalStake == 0 {
+		p.rewards.Provider += value
+		return nil
+	}
+
+	charge, err := currency.MultFloat64(value, p.ServiceCharge)
+	if err != nil {
+		return err
+	}
+	p.rewards.Provider += charge
+
+	left := value - charge
+	remainder := left
+	for _, id := range pools {
+		reward, err := currency.MultFloat64(left, float64(p.stakes[id])/float64(totalStake))
+		if err != nil {
+			return err
+		}
+		p.rewards.Delegates[id] += reward
+		remainder -= reward
+	}
+	p.rewards.Delegates[pools[0]] += remainder
+	return nil
+}
+
+// slash takes value from the delegate pools in proportion to their stake
+func (p *provider) slash(value currency.Coin) error {
+	pools := p.pools()
+
+	var totalStake currency.Coin
+	for _, id := range pools {
+		totalStake += p.stakes[id]
+	}
+	if value > totalStake {
+		value = totalStake
+	}
+	if value == 0 {
+		return nil
+	}
+
+	for _, id := range pools {
+		penalty, err := currency.MultFloat64(value, float64(p.stakes[id])/float64(totalStake))
+		if err != nil {
+			return err
+		}
+		p.stakes[id] -= penalty
+		p.penalties[id] += penalty
+	}
+	return nil
+}
+
+// pools returns ids of delegate pools with stake sorted, the chain iterates pools in the same order
+func (p *provider) pools() []string {
+	pools := make([]string, 0, len(p.stakes))
+	for id, stake := range p.stakes {
+		if stake > 0 {
+			pools = append(pools, id)
+		}
+	}
+	sort.Strings(pools)
+	return pools
+}
+
+func copyCoins(coins map[string]currency.Coin) map[string]currency.Coin {
+	result := make(map[string]currency.Coin, len(coins))
+	for id, coin := range coins {
+		result[id] = coin
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+package rewards_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/stretchr/testify/require"
+)
+
+const zcn = 1e10
+
+var config = rewards.Config{
+	Epoch:                       100,
+	BlockReward:                 1 * zcn,
+	RewardDeclineRate:           0.1,
+	ShareRatio:                  0.8,
+	NumMinerDelegatesRewarded:   2,
+	NumShardersRewarded:         2,
+	NumSharderDelegatesRewarded: 0,
+	ValidatorReward:             0.1,
+	BlobberSlash:                0.1,
+	CancellationCharge:          0.2,
+	TimeUnit:                    time.Hour,
+}
+
+func TestNewConfig(t *testing.T) {
+	minerSC := map[string]string{
+		"epoch":                          "100",
+		"block_reward":                   "1",
+		"reward_decline_rate":            "0.1",
+		"share_ratio":                    "0.8",
+		"num_miner_delegates_rewarded":   "2",
+		"num_sharders_rewarded":          "2",
+		"num_sharder_delegates_rewarded": "0",
+	}
+	storageSC := map[string]string{
+		"validator_reward":    "0.1",
+		"blobber_slash":       "0.1",
+		"cancellation_charge": "0.2",
+		"time_unit":           "1h0m0s",
+	}
+
+	parsed, err := rewards.NewConfig(minerSC, storageSC)
+	require.NoError(t, err)
+	require.Equal(t, config, parsed)
+
+	delete(storageSC, "blobber_slash")
+	_, err = rewards.NewConfig(minerSC, storageSC)
+	require.ErrorIs(t, err, rewards.ErrMissingConfig)
+	require.ErrorContains(t, err, "blobber_slash")
+}
+
+func TestBlockRewards(t *testing.T) {
+	model := newModel(t,
+		rewards.Provider{ID: "miner", Type: rewards.Miner, ServiceCharge: 0.1},
+		rewards.Provider{ID: "sharder1", Type: rewards.Sharder, ServiceCharge: 0.1},
+		rewards.Provider{ID: "sharder2", Type: rewards.Sharder, ServiceCharge: 0.1},
+	)
+	require.NoError(t, model.Apply(
+		rewards.StakeChange{Provider: "miner", Delegate: "delegate1", Amount: 1 * zcn},
+		rewards.StakeChange{Provider: "miner", Delegate: "delegate2", Amount: 3 * zcn},
+		rewards.StakeChange{Provider: "sharder1", Delegate: "delegate1", Amount: 1 * zcn},
+	))
+
+	minerReward, sharderReward, err := rewards.BlockReward(config, 250)
+	require.NoError(t, err)
+	require.Equal(t, currency.Coin(6_480_000_000), minerReward)
+	require.Equal(t, currency.Coin(1_620_000_000), sharderReward)
+
+	require.NoError(t, model.Apply(rewards.Block{Round: 250, Miner: "miner", Sharders: []string{"sharder1", "sharder2"}, Fees: 1000}))
+
+	requireRewards(t, model, "miner", 648_000_080, map[string]currency.Coin{
+		"delegate1": 1_458_000_180,
+		"delegate2": 4_374_000_540,
+	})
+	// delegates of sharders are not rewarded
+	requireRewards(t, model, "sharder1", 810_000_100, map[string]currency.Coin{})
+	requireRewards(t, model, "sharder2", 810_000_100, map[string]currency.Coin{})
+
+	t.Run("delegates picked by chain should be rewarded", func(t *testing.T) {
+		model := newModel(t, rewards.Provider{ID: "miner", Type: rewards.Miner})
+		require.NoError(t, model.Apply(
+			rewards.StakeChange{Provider: "miner", Delegate: "delegate1", Amount: 1 * zcn},
+			rewards.StakeChange{Provider: "miner", Delegate: "delegate2", Amount: 1 * zcn},
+			rewards.StakeChange{Provider: "miner", Delegate: "delegate3", Amount: 2 * zcn},
+		))
+
+		err := model.Apply(rewards.Block{Round: 1, Miner: "miner"})
+		require.ErrorIs(t, err, rewards.ErrDelegatesNotPicked)
+
+		require.NoError(t, model.Apply(rewards.Block{
+			Round:  1,
+			Miner:  "miner",
+			Picked: map[string][]string{"miner": {"delegate3", "delegate1"}},
+		}))
+		requireRewards(t, model, "miner", 0, map[string]currency.Coin{
+			"delegate1": 2_666_666_667,
+			"delegate3": 5_333_333_333,
+		})
+	})
+
+	t.Run("events should be checked against the model", func(t *testing.T) {
+		err := model.Apply(rewards.Block{Round: 1, Miner: "sharder1"})
+		require.ErrorIs(t, err, rewards.ErrProviderType)
+
+		err = model.Apply(rewards.Block{Round: 1, Miner: "unknown"})
+		require.ErrorIs(t, err, rewards.ErrUnknownProvider)
+
+		err = model.Apply(rewards.StakeChange{Provider: "miner", Delegate: "delegate1", Amount: -2 * zcn})
+		require.ErrorIs(t, err, rewards.ErrInsufficientStake)
+	})
+}
+
+func TestStorageRewards(t *testing.T) {
+	model := newModel(t,
+		rewards.Provider{ID: "blobber1", Type: rewards.Blobber, ServiceCharge: 0.1},
+		rewards.Provider{ID: "blobber2", Type: rewards.Blobber, ServiceCharge: 0.1},
+		rewards.Provider{ID: "validator1", Type: rewards.Validator, ServiceCharge: 0.1},
+		rewards.Provider{ID: "validator2", Type: rewards.Validator, ServiceCharge: 0.1},
+	)
+	start := time.Unix(1_700_000_000, 0)
+	validators := []string{"validator1", "validator2"}
+
+	require.NoError(t, model.Apply(
+		rewards.StakeChange{Provider: "blobber1", Delegate: "delegate1", Amount: 1 * zcn},
+		rewards.StakeChange{Provider: "blobber2", Delegate: "delegate2", Amount: 2e9},
+		rewards.NewAllocation{
+			ID:         "allocation",
+			Blobbers:   []string{"blobber1", "blobber2"},
+			DataShards: 1,
+			Size:       100 << 30,
+			WritePrice: 1 * zcn,
+			Start:      start,
+			Expiration: start.Add(10 * time.Hour),
+		},
+		rewards.Upload{Allocation: "allocation", Size: 1 << 30, At: start},
+	))
+	requirePools(t, model, rewards.AllocationPools{MovedToChallenge: 20 * zcn, ChallengePool: 20 * zcn})
+
+	require.NoError(t, model.Apply(
+		rewards.Challenge{Allocation: "allocation", Blobber: "blobber1", Validators: validators, Passed: true, At: start.Add(2 * time.Hour)},
+		rewards.Challenge{Allocation: "allocation", Blobber: "blobber2", Validators: validators, Passed: false, At: start.Add(5 * time.Hour)},
+	))
+	requirePools(t, model, rewards.AllocationPools{MovedToChallenge: 20 * zcn, MovedBack: 4.5 * zcn, ChallengePool: 13 * zcn})
+
+	// slash of failed challenge is limited by the stake of blobber
+	penalties, err := model.Penalties("blobber2")
+	require.NoError(t, err)
+	require.Equal(t, map[string]currency.Coin{"delegate2": 2e9}, penalties)
+	stake, err := model.Stake("blobber2", "delegate2")
+	require.NoError(t, err)
+	require.Zero(t, stake)
+
+	require.NoError(t, model.Apply(rewards.CancelAllocation{Allocation: "allocation", At: start.Add(6 * time.Hour)}))
+	requirePools(t, model, rewards.AllocationPools{MovedToChallenge: 20 * zcn, MovedBack: 12.5 * zcn})
+
+	requireRewards(t, model, "blobber1", 2.205*zcn, map[string]currency.Coin{"delegate1": 19.845 * zcn})
+	requireRewards(t, model, "blobber2", 17.25*zcn, map[string]currency.Coin{})
+	requireRewards(t, model, "validator1", 0.35*zcn, map[string]currency.Coin{})
+	requireRewards(t, model, "validator2", 0.35*zcn, map[string]currency.Coin{})
+
+	err = model.Apply(rewards.Upload{Allocation: "allocation", Size: 1 << 30, At: start.Add(7 * time.Hour)})
+	require.ErrorIs(t, err, rewards.ErrInvalidEvent)
+}
+
+func newModel(t *testing.T, providers ...rewards.Provider) *rewards.Model {
+	model := rewards.New(config)
+	for _, provider := range providers {
+		require.NoError(t, model.AddProvider(provider))
+	}
+	return model
+}
+
+func requireRewards(t *testing.T, model *rewards.Model, providerID string, provider currency.Coin, delegates map[string]currency.Coin) {
+	t.Helper()
+	payout, err := model.Rewards(providerID)
+	require.NoError(t, err)
+	require.Equal(t, provider, payout.Provider, "reward of %s", providerID)
+	require.Equal(t, delegates, payout.Delegates, "delegate rewards of %s", providerID)
+}
+
+func requirePools(t *testing.T, model *rewards.Model, expected rewards.AllocationPools) {
+	t.Helper()
+	pools, err := model.AllocationPools("allocation")
+	require.NoError(t, err)
+	require.Equal(t, expected, pools)
+}
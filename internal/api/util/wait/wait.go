@@ -1,30 +1,183 @@
 package wait
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
 )
 
-// PoolImmediately pools passed function for a certain amount of time
-func PoolImmediately(t *test.SystemTest, duration time.Duration, predicate func() bool) {
-	backoffPeriod := time.Second * 2
-	ticker := time.NewTicker(backoffPeriod)
+// Contains default settings of polling
+const (
+	DefaultTimeout = 2 * time.Minute
+	DefaultBackoff = 2 * time.Second
+)
+
+var ErrTimeout = errors.New("timed out waiting for condition")
+
+// Backoff returns delay before the given attempt, the first attempt is 1
+type Backoff func(attempt int) time.Duration
+
+// Constant waits the same duration between attempts
+func Constant(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// Exponential doubles the delay after every attempt, up to max
+func Exponential(initial, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+}
+
+// Jittered randomizes delay of the given backoff by up to the given fraction in both directions,
+// so parallel tests do not poll the network at the same time
+func Jittered(backoff Backoff, fraction float64) Backoff {
+	return func(attempt int) time.Duration {
+		delay := float64(backoff(attempt))
+		return time.Duration(delay + delay*fraction*(2*rand.Float64()-1)) //nolint:gosec
+	}
+}
+
+// Config contains settings of Poll, zero values are replaced with defaults
+type Config struct {
+	Timeout time.Duration
+	Backoff Backoff
+
+	// Description of the awaited condition, used in logs and timeout error
+	Description string
+}
+
+func (c Config) withDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.Backoff == nil {
+		c.Backoff = Constant(DefaultBackoff)
+	}
+	if c.Description == "" {
+		c.Description = "wait condition"
+	}
+	return c
+}
+
+// TimeoutError is returned when condition is not met in time. It contains the last observed
+// value, so the failure can be diagnosed from the test output.
+type TimeoutError struct {
+	Description  string
+	Timeout      time.Duration
+	Attempts     int
+	LastObserved interface{}
+
+	// LastErr is the error returned by the last attempt, if any
+	LastErr error
 
-	defer ticker.Stop()
+	// Cause is set when the context was cancelled before the timeout
+	Cause error
+}
 
-	after := time.After(duration)
+func (e *TimeoutError) Error() string {
+	message := fmt.Sprintf("%s is not met after %v and %d attempts, last observed: %+v", e.Description, e.Timeout, e.Attempts, e.LastObserved)
+	if e.LastErr != nil {
+		message += fmt.Sprintf(", last error: %v", e.LastErr)
+	}
+	if e.Cause != nil {
+		message += fmt.Sprintf(", cancelled: %v", e.Cause)
+	}
+	return message
+}
+
+func (e *TimeoutError) Unwrap() []error {
+	result := []error{ErrTimeout}
+	if e.Cause != nil {
+		result = append(result, e.Cause)
+	}
+	return result
+}
+
+// Poll calls observe until it reports done, the timeout passes or ctx is cancelled.
+// Errors returned by observe are retried. The last observed value is returned in both cases.
+func Poll[T any](ctx context.Context, t *test.SystemTest, config Config, observe func(ctx context.Context) (T, bool, error)) (T, error) {
+	config = config.withDefaults()
+
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	timeoutErr := &TimeoutError{Description: config.Description, Timeout: config.Timeout}
+
+	var observed T
+	for attempt := 1; ; attempt++ {
+		var (
+			done bool
+			err  error
+		)
+		observed, done, err = observe(ctx)
+		timeoutErr.Attempts = attempt
+		timeoutErr.LastObserved = observed
+		timeoutErr.LastErr = err
+
+		if err == nil && done {
+			t.Logf("%s has succeed", config.Description)
+			return observed, nil
+		}
 
-	for range ticker.C {
+		delay := config.Backoff(attempt)
+		t.Logf("%s failed, last observed: %+v. Waiting an additional [%v]...", config.Description, observed, delay)
+
+		timer := time.NewTimer(delay)
 		select {
-		case <-after:
-			t.Fatal("Timed out waiting for wait condition to pass")
-		default:
-			if predicate() {
-				t.Log("Wait condition has succeed")
-				return
+		case <-ctx.Done():
+			timer.Stop()
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				timeoutErr.Cause = ctx.Err()
 			}
-			t.Logf("Wait condition failed. Waiting an additional [%v]...", backoffPeriod)
+			return observed, timeoutErr
+		case <-timer.C:
 		}
 	}
 }
+
+// UntilRound waits until latestRound reports the given round as finalized and returns the latest finalized round
+func UntilRound(ctx context.Context, t *test.SystemTest, config Config, round int64, latestRound func(ctx context.Context) (int64, error)) (int64, error) {
+	if config.Description == "" {
+		config.Description = fmt.Sprintf("round %d to be finalized", round)
+	}
+
+	return Poll(ctx, t, config, func(ctx context.Context) (int64, bool, error) {
+		current, err := latestRound(ctx)
+		return current, current >= round, err
+	})
+}
+
+// ForRounds waits until the given number of rounds is finalized after the current one
+func ForRounds(ctx context.Context, t *test.SystemTest, config Config, rounds int64, latestRound func(ctx context.Context) (int64, error)) (int64, error) {
+	current, err := latestRound(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return UntilRound(ctx, t, config, current+rounds, latestRound)
+}
+
+// PoolImmediately pools passed function for a certain amount of time and fails the test on timeout.
+// New code should prefer Poll, which returns an error with the last observed value.
+func PoolImmediately(t *test.SystemTest, duration time.Duration, predicate func() bool) {
+	_, err := Poll(context.Background(), t, Config{Timeout: duration}, func(context.Context) (bool, bool, error) {
+		done := predicate()
+		return done, done, nil
+	})
+	if err != nil {
+		t.Fatal("Timed out waiting for wait condition to pass")
+	}
+}
@@ -0,0 +1,47 @@
+package wait_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWait(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	network := mocknet.NewDefault()
+	defer network.Close()
+	apiClient := client.NewAPIClient(network.URL())
+
+	t.RunSequentially("Round wait should return once the round is finalized", func(t *test.SystemTest) {
+		latestRound, err := apiClient.LatestFinalizedRound(t)(context.Background())
+		require.NoError(t, err)
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			network.AdvanceRounds(5)
+		}()
+
+		round, err := wait.UntilRound(context.Background(), t,
+			wait.Config{Timeout: 10 * time.Second, Backoff: wait.Jittered(wait.Exponential(10*time.Millisecond, 100*time.Millisecond), 0.5)},
+			latestRound+5, apiClient.LatestFinalizedRound(t))
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, round, latestRound+5)
+
+		round, err = apiClient.WaitForRounds(t, 1000, 50*time.Millisecond)
+		require.ErrorIs(t, err, wait.ErrTimeout)
+		require.Contains(t, err.Error(), fmt.Sprintf("last observed: %d", round))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = wait.ForRounds(ctx, t, wait.Config{}, 1000, apiClient.LatestFinalizedRound(t))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,99 @@
+package walletpool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// Funder reads balances of pooled wallets and refills them
+type Funder interface {
+	// Refresh returns balance of the wallet in SAS and syncs its nonce with the chain
+	Refresh(t *test.SystemTest, wallet *model.Wallet) (int64, error)
+
+	// TopUp sends amount SAS to the wallet and waits until the transaction is confirmed
+	TopUp(t *test.SystemTest, wallet *model.Wallet, amount int64) error
+}
+
+// APIFunder refills wallets from the owner wallet, or from the faucet when the owner is not set.
+// When Client is not set, it is created with NewClient on first use, so creating the funder
+// does not contact the network.
+type APIFunder struct {
+	Client    *client.APIClient
+	NewClient func() *client.APIClient
+	Owner     *model.Wallet
+
+	once sync.Once
+}
+
+func (f *APIFunder) client() *client.APIClient {
+	f.once.Do(func() {
+		if f.Client == nil && f.NewClient != nil {
+			f.Client = f.NewClient()
+		}
+	})
+	return f.Client
+}
+
+func (f *APIFunder) Refresh(t *test.SystemTest, wallet *model.Wallet) (int64, error) {
+	apiClient := f.client()
+
+	balance, result, err := apiClient.V1ClientGetBalanceWithConsensus(t, model.ClientGetBalanceRequest{ClientID: wallet.Id}, client.HttpOkStatus)
+	switch {
+	case err != nil && unknownWallet(result):
+		// wallet which never received tokens is not known to sharders
+		apiClient.Nonces().Update(wallet, 0)
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("balance of wallet [%s]: %w", wallet.Id, err)
+	case balance == nil:
+		return 0, fmt.Errorf("balance of wallet [%s]: %w", wallet.Id, client.ErrGetFromResource)
+	}
+
+	apiClient.Nonces().Update(wallet, int(balance.Nonce))
+	return balance.Balance, nil
+}
+
+// unknownWallet reports whether sharders rejected the balance request instead of failing it,
+// which they do for clients without any state
+func unknownWallet(result *client.ConsensusResult) bool {
+	if result == nil {
+		return false
+	}
+
+	var rejected int
+	for _, response := range result.Responses {
+		switch response.StatusCode {
+		case http.StatusOK:
+			return false
+		case http.StatusBadRequest:
+			rejected++
+		}
+	}
+	return rejected > 0
+}
+
+func (f *APIFunder) TopUp(t *test.SystemTest, wallet *model.Wallet, amount int64) error {
+	apiClient := f.client()
+
+	transaction := apiClient.NewTransaction(wallet).
+		SmartContract(client.FaucetSmartContractAddress, model.NewFaucetTransactionData()).
+		Value(amount)
+	if f.Owner != nil {
+		transaction = apiClient.NewTransaction(f.Owner).To(wallet.Id).Value(amount)
+	}
+
+	confirmations, err := apiClient.SubmitBatch(context.Background(), t, client.DefaultConfirmationTimeout, transaction)
+	if err != nil {
+		return err
+	}
+	if status := confirmations[0].Status; status != client.TxSuccessfulStatus {
+		return fmt.Errorf("top up of wallet [%s] failed with transaction status [%d]", wallet.Id, status)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// Package walletpool leases pre-created wallets to tests and keeps them funded, so long runs do not
+// fail once the wallets are drained.
+package walletpool
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+// Contains default settings of the pool, amounts are in SAS
+const (
+	DefaultMinBalance   = int64(1e10)
+	DefaultTopUpAmount  = int64(5e10)
+	DefaultLeaseTimeout = 5 * time.Minute
+)
+
+var (
+	ErrPoolClosed   = errors.New("wallet pool is closed")
+	ErrLeaseTimeout = errors.New("timed out waiting for a free wallet")
+)
+
+// Config contains settings of Pool, zero values are replaced with defaults
+type Config struct {
+	// MinBalance is the lowest balance of a leased wallet, a drained wallet is refilled
+	MinBalance int64
+
+	// TopUpAmount is sent to a wallet whose balance dropped below MinBalance
+	TopUpAmount int64
+
+	// LeaseTimeout limits waiting for a wallet when all of them are leased or being refilled
+	LeaseTimeout time.Duration
+
+	// SingleUse keeps returned wallets out of the pool. It is used when tests leave state on the chain
+	// which later tests do not expect, e.g. stake pools or allocations of the wallet.
+	SingleUse bool
+}
+
+func (c Config) withDefaults() Config {
+	if c.MinBalance == 0 {
+		c.MinBalance = DefaultMinBalance
+	}
+	if c.TopUpAmount == 0 {
+		c.TopUpAmount = DefaultTopUpAmount
+	}
+	if c.LeaseTimeout == 0 {
+		c.LeaseTimeout = DefaultLeaseTimeout
+	}
+	return c
+}
+
+// Pool leases wallets to tests. A leased wallet is returned to the pool when the test finishes, its balance
+// is checked and refilled in the background, so the next test gets it funded and with a synced nonce.
+type Pool struct {
+	funder Funder
+	config Config
+
+	// background is used by refills, which outlive the test which returned the wallet.
+	// It is not run by the testing package, so refills log to the standard logger.
+	background *test.SystemTest
+
+	free    chan *model.Wallet
+	refills sync.WaitGroup
+
+	mu     sync.Mutex
+	leased map[string]*model.Wallet
+	closed bool
+}
+
+// New creates pool of the given wallets. Background refills log to the given test, usually the one created in TestMain.
+func New(background *test.SystemTest, funder Funder, config Config, wallets ...*model.Wallet) *Pool {
+	p := &Pool{
+		funder:     funder,
+		config:     config.withDefaults(),
+		background: background,
+		free:       make(chan *model.Wallet, len(wallets)),
+		leased:     make(map[string]*model.Wallet),
+	}
+	for _, wallet := range wallets {
+		p.free <- wallet
+	}
+	return p
+}
+
+// Lease returns a funded wallet with synced nonce, which is returned to the pool when the test finishes
+func (p *Pool) Lease(t *test.SystemTest) *model.Wallet {
+	wallet, err := p.Acquire(t)
+	require.NoError(t, err, "failed to lease a wallet")
+
+	t.Cleanup(func() {
+		p.Return(wallet)
+	})
+	return wallet
+}
+
+// Acquire returns a funded wallet with synced nonce, which is kept until it is given back with Return
+func (p *Pool) Acquire(t *test.SystemTest) (*model.Wallet, error) {
+	timer := time.NewTimer(p.config.LeaseTimeout)
+	defer timer.Stop()
+
+	var wallet *model.Wallet
+	select {
+	case wallet = <-p.free:
+	case <-timer.C:
+		return nil, fmt.Errorf("%w after %v, %d wallets are leased", ErrLeaseTimeout, p.config.LeaseTimeout, p.Leased())
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+	p.leased[wallet.Id] = wallet
+	p.mu.Unlock()
+
+	// wallet is refilled right away when the background refill failed or it was drained from the start
+	if err := p.ensureBalance(t, wallet); err != nil {
+		p.giveBack(wallet)
+		return nil, err
+	}
+
+	t.Logf("Leased wallet [%s] with nonce [%d]", wallet.Id, wallet.Nonce)
+	return wallet, nil
+}
+
+// Return gives the wallet back to the pool, it is refilled in the background when needed.
+// Wallets of a single use pool are dropped.
+func (p *Pool) Return(wallet *model.Wallet) {
+	p.mu.Lock()
+	if _, ok := p.leased[wallet.Id]; !ok || p.closed {
+		p.mu.Unlock()
+		return
+	}
+	if p.config.SingleUse {
+		delete(p.leased, wallet.Id)
+		p.mu.Unlock()
+		return
+	}
+	p.refills.Add(1)
+	p.mu.Unlock()
+
+	go func() {
+		defer p.refills.Done()
+		defer p.giveBack(wallet)
+
+		if err := p.ensureBalance(p.background, wallet); err != nil {
+			log.Printf("Refill of wallet [%s] failed, it will be retried on lease: %v", wallet.Id, err)
+		}
+	}()
+}
+
+// Leased returns number of wallets which are leased or being refilled
+func (p *Pool) Leased() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.leased)
+}
+
+// Close waits for running refills, wallets cannot be leased afterwards
+func (p *Pool) Close() {
+	p.refills.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+}
+
+func (p *Pool) giveBack(wallet *model.Wallet) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.leased, wallet.Id)
+	p.free <- wallet
+}
+
+func (p *Pool) logf(t *test.SystemTest, format string, args ...interface{}) {
+	if t == p.background {
+		log.Printf(format, args...)
+		return
+	}
+	t.Logf(format, args...)
+}
+
+func (p *Pool) ensureBalance(t *test.SystemTest, wallet *model.Wallet) error {
+	balance, err := p.funder.Refresh(t, wallet)
+	if err != nil {
+		return err
+	}
+	if balance >= p.config.MinBalance {
+		return nil
+	}
+
+	p.logf(t, "Balance [%d] of wallet [%s] is below [%d], topping up [%d]", balance, wallet.Id, p.config.MinBalance, p.config.TopUpAmount)
+	if err := p.funder.TopUp(t, wallet, p.config.TopUpAmount); err != nil {
+		return err
+	}
+
+	_, err = p.funder.Refresh(t, wallet)
+	return err
+}
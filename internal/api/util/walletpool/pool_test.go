@@ -0,0 +1,174 @@
+package walletpool_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/mocknet"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics"
+	"github.com/0chain/system_test/internal/api/util/walletpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	network := mocknet.NewDefault()
+	defer network.Close()
+
+	apiClient := client.NewAPIClient(network.URL())
+	config := walletpool.Config{
+		MinBalance:   *tokenomics.IntToZCN(5),
+		TopUpAmount:  *tokenomics.IntToZCN(10),
+		LeaseTimeout: time.Second,
+	}
+
+	newWallet := func(t *test.SystemTest, balance int64) *model.Wallet {
+		wallet := apiClient.CreateWalletForMnemonic(t, crypto.GenerateMnemonics(t))
+		network.SetBalance(wallet.Id, balance)
+		return wallet
+	}
+
+	t.RunSequentially("Leased wallet should be returned when the test finishes", func(t *test.SystemTest) {
+		wallet := newWallet(t, *tokenomics.IntToZCN(100))
+		pool := walletpool.New(t, &walletpool.APIFunder{Client: apiClient}, config, wallet)
+		defer pool.Close()
+
+		t.RunSequentially("lease", func(t *test.SystemTest) {
+			require.Equal(t, wallet, pool.Lease(t))
+			require.Equal(t, 1, pool.Leased())
+
+			_, err := pool.Acquire(t)
+			require.ErrorIs(t, err, walletpool.ErrLeaseTimeout)
+		})
+
+		pool.Close()
+		require.Equal(t, 0, pool.Leased())
+		balance, _ := network.Balance(wallet.Id)
+		require.Equal(t, *tokenomics.IntToZCN(100), balance)
+	})
+
+	t.RunSequentially("Drained wallet should be topped up from the faucet", func(t *test.SystemTest) {
+		wallet := newWallet(t, *tokenomics.IntToZCN(1))
+		pool := walletpool.New(t, &walletpool.APIFunder{Client: apiClient}, config, wallet)
+		defer pool.Close()
+
+		t.RunSequentially("lease", func(t *test.SystemTest) {
+			pool.Lease(t)
+			balance, nonce := network.Balance(wallet.Id)
+			require.Equal(t, *tokenomics.IntToZCN(11), balance)
+			require.Equal(t, int64(1), nonce)
+			require.Equal(t, 1, wallet.Nonce)
+
+			network.SetBalance(wallet.Id, *tokenomics.IntToZCN(2))
+		})
+
+		// returned wallet is refilled in the background
+		pool.Close()
+		balance, _ := network.Balance(wallet.Id)
+		require.Equal(t, *tokenomics.IntToZCN(12), balance)
+	})
+
+	t.RunSequentially("Drained wallet should be topped up from the owner wallet", func(t *test.SystemTest) {
+		owner := newWallet(t, *tokenomics.IntToZCN(100))
+		wallet := newWallet(t, 0)
+		pool := walletpool.New(t, &walletpool.APIFunder{Client: apiClient, Owner: owner}, config, wallet)
+		defer pool.Close()
+
+		t.RunSequentially("lease", func(t *test.SystemTest) {
+			pool.Lease(t)
+			balance, _ := network.Balance(wallet.Id)
+			require.Equal(t, *tokenomics.IntToZCN(10), balance)
+			require.Equal(t, 0, wallet.Nonce)
+		})
+
+		balance, nonce := network.Balance(owner.Id)
+		require.Equal(t, *tokenomics.IntToZCN(90)-mocknet.DefaultFee, balance)
+		require.Equal(t, int64(1), nonce)
+	})
+
+	t.RunSequentially("Lease should refresh nonce used outside of the pool", func(t *test.SystemTest) {
+		wallet := newWallet(t, *tokenomics.IntToZCN(100))
+		pool := walletpool.New(t, &walletpool.APIFunder{Client: apiClient}, config, wallet)
+		defer pool.Close()
+
+		network.Update(func(s *mocknet.State) {
+			s.Wallet(wallet.Id).Nonce = 7
+		})
+
+		t.RunSequentially("lease", func(t *test.SystemTest) {
+			require.Equal(t, 7, pool.Lease(t).Nonce)
+		})
+	})
+	t.RunSequentially("Wallet unknown to sharders should be topped up", func(t *test.SystemTest) {
+		wallet := apiClient.CreateWalletForMnemonic(t, crypto.GenerateMnemonics(t))
+		pool := walletpool.New(t, &walletpool.APIFunder{Client: apiClient}, config, wallet)
+		defer pool.Close()
+
+		t.RunSequentially("lease", func(t *test.SystemTest) {
+			pool.Lease(t)
+			balance, _ := network.Balance(wallet.Id)
+			require.Equal(t, *tokenomics.IntToZCN(10), balance)
+		})
+	})
+
+	t.RunSequentially("Single use wallet should not be leased again", func(t *test.SystemTest) {
+		wallet := newWallet(t, *tokenomics.IntToZCN(100))
+		singleUse := config
+		singleUse.SingleUse = true
+		pool := walletpool.New(t, &walletpool.APIFunder{Client: apiClient}, singleUse, wallet)
+		defer pool.Close()
+
+		t.RunSequentially("lease", func(t *test.SystemTest) {
+			require.Equal(t, wallet, pool.Lease(t))
+		})
+
+		require.Equal(t, 0, pool.Leased())
+		_, err := pool.Acquire(t)
+		require.ErrorIs(t, err, walletpool.ErrLeaseTimeout)
+	})
+
+	t.RunSequentially("Funder should create its client on first use", func(t *test.SystemTest) {
+		wallet := newWallet(t, *tokenomics.IntToZCN(100))
+		var created int
+		funder := &walletpool.APIFunder{NewClient: func() *client.APIClient {
+			created++
+			return apiClient
+		}}
+		pool := walletpool.New(t, funder, config, wallet)
+		defer pool.Close()
+		require.Zero(t, created)
+
+		t.RunSequentially("lease", func(t *test.SystemTest) {
+			pool.Lease(t)
+		})
+
+		pool.Close()
+		require.Equal(t, 1, created)
+	})
+
+	t.RunSequentially("Failed balance request should not be taken as zero balance", func(t *test.SystemTest) {
+		wallet := newWallet(t, *tokenomics.IntToZCN(1))
+		pool := walletpool.New(t, &walletpool.APIFunder{Client: apiClient}, config, wallet)
+		defer pool.Close()
+
+		for _, sharder := range network.Sharders {
+			sharder.Handle(client.ClientGetBalance, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+			defer sharder.Handle(client.ClientGetBalance, nil)
+		}
+
+		_, err := pool.Acquire(t)
+		require.Error(t, err)
+		require.Equal(t, 0, pool.Leased(), "wallet should be given back to the pool")
+
+		balance, _ := network.Balance(wallet.Id)
+		require.Equal(t, *tokenomics.IntToZCN(1), balance, "wallet should not be topped up")
+	})
+}
@@ -0,0 +1,126 @@
+package wallets
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WalletFileSuffix is appended to wallet names by zwallet and zbox, e.g. `--wallet sc_owner_wallet.json`
+const WalletFileSuffix = "_wallet.json"
+
+// Load reads wallet file, e.g. config/wallets/sc_owner_wallet.json
+func Load(path string) (*Wallet, error) {
+	var wallet Wallet
+	if err := readJSON(path, &wallet); err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// LoadList reads file with a list of wallets, e.g. api_tests config/wallets.json
+func LoadList(path string) ([]*Wallet, error) {
+	var wallets []*Wallet
+	if err := readJSON(path, &wallets); err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
+
+// Save writes wallet file readable by zwallet and zbox
+func (w *Wallet) Save(path string) error {
+	return writeJSON(path, w)
+}
+
+// SaveList writes file with a list of wallets
+func SaveList(path string, wallets []*Wallet) error {
+	return writeJSON(path, wallets)
+}
+
+// Store keeps wallets in a directory in the CLI layout, every wallet is stored in <name>_wallet.json
+type Store struct {
+	dir string
+
+	mu      sync.Mutex
+	wallets map[string]*Wallet
+}
+
+func NewStore(dir string) *Store {
+	return &Store{
+		dir:     dir,
+		wallets: make(map[string]*Wallet),
+	}
+}
+
+// Path returns path of the wallet file, which is passed to CLI tools
+func (s *Store) Path(name string) string {
+	return filepath.Join(s.dir, name+WalletFileSuffix)
+}
+
+// Get returns the wallet of the given name, it is read from disk only once
+func (s *Store) Get(name string) (*Wallet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if wallet, ok := s.wallets[name]; ok {
+		return wallet, nil
+	}
+
+	wallet, err := Load(s.Path(name))
+	if err != nil {
+		return nil, err
+	}
+	s.wallets[name] = wallet
+	return wallet, nil
+}
+
+// Put writes the wallet of the given name
+func (s *Store) Put(name string, wallet *Wallet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	if err := wallet.Save(s.Path(name)); err != nil {
+		return err
+	}
+	s.wallets[name] = wallet
+	return nil
+}
+
+// Names lists names of wallets stored in the directory, other files, e.g. keystores of ethereum wallets, are ignored
+func (s *Store) Names() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), WalletFileSuffix) {
+			names = append(names, strings.TrimSuffix(entry.Name(), WalletFileSuffix))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
+
+func writeJSON(path string, v interface{}) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
@@ -0,0 +1,274 @@
+// Package wallets contains the canonical wallet of system tests. It is converted to every wallet type
+// used by API, SDK and CLI clients, and stored in the zwallet layout, so all suites share the same wallets.
+package wallets
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var ErrNoKeys = errors.New("wallet has no keys")
+
+// KeyPair contains hex serialized BLS keys
+type KeyPair struct {
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key"`
+}
+
+// Wallet is stored as zwallet wallet file. Split wallets have one key pair per split key and
+// the client key of the primary key, multi-key wallets have one key pair per signer.
+type Wallet struct {
+	ClientID  string    `json:"client_id"`
+	ClientKey string    `json:"client_key"`
+	Keys      []KeyPair `json:"keys"`
+	Mnemonic  string    `json:"mnemonics"`
+	Version   string    `json:"version"`
+
+	// DateCreated is kept as written by the tool which created the wallet, formats differ between versions
+	DateCreated string `json:"date_created"`
+	Nonce       int64  `json:"nonce,omitempty"`
+
+	// PeerPublicKey is set only for split wallets created by zauth
+	PeerPublicKey string `json:"peer_public_key,omitempty"`
+	IsSplit       bool   `json:"is_split,omitempty"`
+
+	// ChainID is written by gosdk to wallets of some test configs
+	ChainID string `json:"ChainID,omitempty"`
+}
+
+// FromMnemonic creates single key wallet of the mnemonic
+func FromMnemonic(mnemonic string) (*Wallet, error) {
+	clientID, keys, err := crypto.DefaultKeyStore.FromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := keys.PublicKey.SerializeToHexStr()
+	return &Wallet{
+		ClientID:    clientID,
+		ClientKey:   publicKey,
+		Keys:        []KeyPair{{PublicKey: publicKey, PrivateKey: keys.PrivateKey.SerializeToHexStr()}},
+		Mnemonic:    mnemonic,
+		Version:     crypto.CryptoVersion,
+		DateCreated: time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// FromModel converts wallet used by APIClient, which contains a single key pair
+func FromModel(w *model.Wallet) *Wallet {
+	wallet := &Wallet{
+		ClientID:  w.Id,
+		ClientKey: w.PublicKey,
+		Mnemonic:  w.Mnemonics,
+		Version:   w.Version,
+		Nonce:     int64(w.Nonce),
+	}
+	if w.Keys != nil {
+		wallet.Keys = []KeyPair{{
+			PublicKey:  w.Keys.PublicKey.SerializeToHexStr(),
+			PrivateKey: w.Keys.PrivateKey.SerializeToHexStr(),
+		}}
+	}
+	if w.CreationDate != nil {
+		wallet.DateCreated = time.Unix(int64(*w.CreationDate), 0).UTC().Format(time.RFC3339)
+	}
+	return wallet
+}
+
+// ToModel converts to wallet used by APIClient. It signs with the first key pair, which is
+// registered in crypto.DefaultKeyStore.
+func (w *Wallet) ToModel() (*model.Wallet, error) {
+	keys, err := crypto.DefaultKeyStore.FromWalletFile(w.ToWalletFile())
+	if err != nil {
+		return nil, err
+	}
+
+	wallet := &model.Wallet{
+		Id:        w.ClientID,
+		Version:   w.Version,
+		PublicKey: w.ClientKey,
+		Nonce:     int(w.Nonce),
+		Keys:      keys,
+		Mnemonics: w.Mnemonic,
+	}
+	if created, err := time.Parse(time.RFC3339, w.DateCreated); err == nil {
+		creationDate := int(created.Unix())
+		wallet.CreationDate = &creationDate
+	}
+	return wallet, nil
+}
+
+// FromSdk converts wallet used by SDKClient
+func FromSdk(w *model.SdkWallet) *Wallet {
+	wallet := &Wallet{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		Mnemonic:      w.Mnemonics,
+		Version:       w.Version,
+		DateCreated:   w.DateCreated,
+		PeerPublicKey: w.PeerPublicKey,
+		IsSplit:       w.IsSplit,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, KeyPair{PublicKey: key.PublicKey, PrivateKey: key.PrivateKey})
+	}
+	return wallet
+}
+
+// ToSdk converts to wallet used by SDKClient
+func (w *Wallet) ToSdk() *model.SdkWallet {
+	wallet := &model.SdkWallet{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		Mnemonics:     w.Mnemonic,
+		Version:       w.Version,
+		DateCreated:   w.DateCreated,
+		PeerPublicKey: w.PeerPublicKey,
+		IsSplit:       w.IsSplit,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, &model.SdkKeyPair{PublicKey: key.PublicKey, PrivateKey: key.PrivateKey})
+	}
+	return wallet
+}
+
+// FromWalletFile converts wallet file read by CLI tests
+func FromWalletFile(w *climodel.WalletFile) *Wallet {
+	wallet := &Wallet{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		Mnemonic:      w.Mnemonic,
+		Version:       w.Version,
+		DateCreated:   w.DateCreated,
+		PeerPublicKey: w.PeerPublicKey,
+		IsSplit:       w.IsSplit,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, KeyPair(key))
+	}
+	return wallet
+}
+
+// ToWalletFile converts to wallet file read by CLI tests
+func (w *Wallet) ToWalletFile() *climodel.WalletFile {
+	wallet := &climodel.WalletFile{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		Mnemonic:      w.Mnemonic,
+		Version:       w.Version,
+		DateCreated:   w.DateCreated,
+		PeerPublicKey: w.PeerPublicKey,
+		IsSplit:       w.IsSplit,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, climodel.KeyPair(key))
+	}
+	return wallet
+}
+
+// FromZCNCrypto converts wallet used by gosdk
+func FromZCNCrypto(w *zcncrypto.Wallet) *Wallet {
+	wallet := &Wallet{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		Mnemonic:      w.Mnemonic,
+		Version:       w.Version,
+		DateCreated:   w.DateCreated,
+		Nonce:         w.Nonce,
+		PeerPublicKey: w.PeerPublicKey,
+		IsSplit:       w.IsSplit,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, KeyPair(key))
+	}
+	return wallet
+}
+
+// ToZCNCrypto converts to wallet used by gosdk
+func (w *Wallet) ToZCNCrypto() *zcncrypto.Wallet {
+	wallet := &zcncrypto.Wallet{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		Mnemonic:      w.Mnemonic,
+		Version:       w.Version,
+		DateCreated:   w.DateCreated,
+		Nonce:         w.Nonce,
+		PeerPublicKey: w.PeerPublicKey,
+		IsSplit:       w.IsSplit,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, zcncrypto.KeyPair(key))
+	}
+	return wallet
+}
+
+// FromCrypto converts wallet generated by crypto.SignatureScheme
+func FromCrypto(w *crypto.Wallet) *Wallet {
+	wallet := &Wallet{
+		ClientID:    w.ClientID,
+		ClientKey:   w.ClientKey,
+		Mnemonic:    w.Mnemonic,
+		Version:     w.Version,
+		DateCreated: w.DateCreated,
+		Nonce:       w.Nonce,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, KeyPair(key))
+	}
+	return wallet
+}
+
+// ToCrypto converts to wallet of crypto.SignatureScheme, which has no split wallet fields
+func (w *Wallet) ToCrypto() *crypto.Wallet {
+	wallet := &crypto.Wallet{
+		ClientID:    w.ClientID,
+		ClientKey:   w.ClientKey,
+		Mnemonic:    w.Mnemonic,
+		Version:     w.Version,
+		DateCreated: w.DateCreated,
+		Nonce:       w.Nonce,
+	}
+	for _, key := range w.Keys {
+		wallet.Keys = append(wallet.Keys, crypto.KeyPair(key))
+	}
+	return wallet
+}
+
+// Validate checks that every private key matches its public key and that the client id is derived
+// from the client key. Client key is the key of the first pair, unless the wallet is split.
+func (w *Wallet) Validate() error {
+	if len(w.Keys) == 0 {
+		return fmt.Errorf("%w: [%s]", ErrNoKeys, w.ClientID)
+	}
+
+	for i, key := range w.Keys {
+		var secretKey bls.SecretKey
+		if err := secretKey.DeserializeHexStr(key.PrivateKey); err != nil {
+			return fmt.Errorf("private key [%d] of wallet [%s]: %w", i, w.ClientID, err)
+		}
+		if secretKey.GetPublicKey().SerializeToHexStr() != key.PublicKey {
+			return fmt.Errorf("private key [%d] of wallet [%s] does not match its public key", i, w.ClientID)
+		}
+	}
+
+	if !w.IsSplit && w.ClientKey != w.Keys[0].PublicKey {
+		return fmt.Errorf("client key of wallet [%s] does not match its first public key", w.ClientID)
+	}
+
+	clientKey, err := hex.DecodeString(w.ClientKey)
+	if err != nil {
+		return fmt.Errorf("client key of wallet [%s]: %w", w.ClientID, err)
+	}
+	if crypto.Sha3256(clientKey) != w.ClientID {
+		return fmt.Errorf("client id of wallet [%s] does not match its client key", w.ClientID)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package wallets_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/wallets"
+	"github.com/stretchr/testify/require"
+)
+
+const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+// zwalletFile is written by older zwallet versions, with date in Go default format
+const zwalletFile = `{
+  "client_id": "9056517447c7cb83b67487da9d05d410eae25c547320ec5ee86903841b086cc8",
+  "client_key": "a53b150130a1c3eff9e1711472ff9a28a7db089e10eabf81f51a9259bc07261d0efb8c508351bd7c77eeaea8a9f41c9a8d6a387c9debb6e506541eba324c791a",
+  "keys": [
+    {
+      "public_key": "a53b150130a1c3eff9e1711472ff9a28a7db089e10eabf81f51a9259bc07261d0efb8c508351bd7c77eeaea8a9f41c9a8d6a387c9debb6e506541eba324c791a",
+      "private_key": "8f24f05b134c263621b60de16b6aef279c2783b7e38ba622221813b2bf5ed406"
+    }
+  ],
+  "mnemonics": "` + mnemonic + `",
+  "version": "1.0",
+  "date_created": "2021-08-04 18:53:56.949069945 +0100 BST m=+0.018986002"
+}`
+
+func TestWallet(t *testing.T) {
+	single, err := wallets.FromMnemonic(mnemonic)
+	require.NoError(t, err)
+	single.DateCreated = "2023-11-26T01:23:58Z"
+	single.Nonce = 5
+	require.NoError(t, single.Validate())
+
+	split := splitWallet(t)
+	require.NoError(t, split.Validate())
+
+	t.Run("zwallet file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sc_owner_wallet.json")
+		require.NoError(t, os.WriteFile(path, []byte(zwalletFile), 0o600))
+
+		loaded, err := wallets.Load(path)
+		require.NoError(t, err)
+		require.NoError(t, loaded.Validate())
+		require.Equal(t, "9056517447c7cb83b67487da9d05d410eae25c547320ec5ee86903841b086cc8", loaded.ClientID)
+
+		require.NoError(t, loaded.Save(path))
+		saved, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.JSONEq(t, zwalletFile, string(saved))
+	})
+
+	for _, tc := range []struct {
+		name   string
+		wallet *wallets.Wallet
+	}{
+		{name: "single key", wallet: single},
+		{name: "split key", wallet: split},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := tc.wallet
+
+			require.Equal(t, w, wallets.FromZCNCrypto(w.ToZCNCrypto()))
+
+			// formats below have no nonce
+			expected := *w
+			expected.Nonce = 0
+			require.Equal(t, &expected, wallets.FromSdk(w.ToSdk()))
+			require.Equal(t, &expected, wallets.FromWalletFile(w.ToWalletFile()))
+
+			// signature schemes never create split wallets, so their format has no split wallet fields
+			expected.Nonce, expected.PeerPublicKey, expected.IsSplit = w.Nonce, "", false
+			require.Equal(t, &expected, wallets.FromCrypto(w.ToCrypto()))
+
+			path := filepath.Join(t.TempDir(), "wallets.json")
+			require.NoError(t, wallets.SaveList(path, []*wallets.Wallet{w, w}))
+			loaded, err := wallets.LoadList(path)
+			require.NoError(t, err)
+			require.Equal(t, []*wallets.Wallet{w, w}, loaded)
+		})
+	}
+
+	t.Run("api model", func(t *testing.T) {
+		modelWallet, err := single.ToModel()
+		require.NoError(t, err)
+		require.Equal(t, single.Keys[0].PrivateKey, modelWallet.Keys.PrivateKey.SerializeToHexStr())
+		require.Equal(t, single, wallets.FromModel(modelWallet))
+
+		// API client signs with the first split key
+		modelWallet, err = split.ToModel()
+		require.NoError(t, err)
+		require.Equal(t, split.Keys[0].PrivateKey, modelWallet.Keys.PrivateKey.SerializeToHexStr())
+		require.Equal(t, split.ClientKey, modelWallet.PublicKey)
+	})
+
+	t.Run("store", func(t *testing.T) {
+		dir := t.TempDir()
+		store := wallets.NewStore(dir)
+		require.NoError(t, store.Put("sc_owner", single))
+		require.NoError(t, store.Put("zauth_split", split))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "UTC--2022-11-13T22-51-36.015115000Z--d8c9156e"), []byte("{}"), 0o600))
+
+		names, err := wallets.NewStore(dir).Names()
+		require.NoError(t, err)
+		require.Equal(t, []string{"sc_owner", "zauth_split"}, names)
+
+		loaded, err := wallets.NewStore(dir).Get("zauth_split")
+		require.NoError(t, err)
+		require.Equal(t, split, loaded)
+		require.Equal(t, filepath.Join(dir, "zauth_split_wallet.json"), store.Path("zauth_split"))
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		invalid := *single
+		invalid.Keys = []wallets.KeyPair{{PublicKey: split.Keys[0].PublicKey, PrivateKey: single.Keys[0].PrivateKey}}
+		require.Error(t, invalid.Validate())
+
+		invalid.Keys = nil
+		require.ErrorIs(t, invalid.Validate(), wallets.ErrNoKeys)
+	})
+}
+
+func splitWallet(t *testing.T) *wallets.Wallet {
+	scheme := crypto.NewHerumiScheme()
+	_, err := scheme.RecoverKeys(mnemonic)
+	require.NoError(t, err)
+
+	split, err := scheme.SplitKeys(2)
+	require.NoError(t, err)
+
+	w := wallets.FromCrypto(split)
+	w.Mnemonic = mnemonic
+	w.IsSplit = true
+	w.PeerPublicKey = split.Keys[1].PublicKey
+
+	content, err := json.Marshal(w)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `"is_split":true`)
+	return w
+}
@@ -0,0 +1,349 @@
+// Package zs3 talks to zs3server over the S3 protocol with SigV4 signed requests, the same way S3 tools of our users do.
+package zs3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Contains credentials and region of zs3server deployments
+const (
+	DefaultAccessKey = "rootroot"
+	DefaultSecretKey = "rootroot"
+	DefaultRegion    = "us-east-1"
+)
+
+// MinPartSize is the smallest part S3 accepts in a multipart upload, except for the last part
+const MinPartSize = 5 * 1024 * 1024
+
+// Client is S3 client of a zs3server endpoint
+type Client struct {
+	endpoint string
+	s3       *s3.S3
+
+	// quiet disables logging of requests, load workloads send too many of them
+	quiet bool
+}
+
+// Object is an entry returned by ListObjects
+type Object struct {
+	Key  string
+	Size int64
+	ETag string
+}
+
+// ObjectsPage is a single page of ListObjectsV2
+type ObjectsPage struct {
+	Objects        []Object
+	CommonPrefixes []string
+
+	// NextContinuationToken is empty on the last page
+	NextContinuationToken string
+}
+
+// ListOptions are parameters of ListObjectsV2, zero values are omitted
+type ListOptions struct {
+	Prefix            string
+	Delimiter         string
+	StartAfter        string
+	ContinuationToken string
+	MaxKeys           int64
+}
+
+// NewClient creates client of zs3server at endpoint, e.g. https://dev.zus.network/zs3server
+func NewClient(endpoint, accessKey, secretKey string) (*Client, error) {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String(DefaultRegion),
+		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Client{endpoint: endpoint, s3: s3.New(sess)}, nil
+}
+
+// ErrorCode returns S3 error code of err, e.g. NoSuchKey, or empty string when err is not an S3 error
+func ErrorCode(err error) string {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code()
+	}
+	return ""
+}
+
+// StatusCode returns HTTP status of a failed S3 request, or 0 when the request did not get a response
+func StatusCode(err error) int {
+	var requestErr awserr.RequestFailure
+	if errors.As(err, &requestErr) {
+		return requestErr.StatusCode()
+	}
+	return 0
+}
+
+func (c *Client) CreateBucket(t *test.SystemTest, bucket string) error {
+	_, err := c.s3.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String(bucket)})
+	c.log(t, "CreateBucket", bucket, "", err)
+	return err
+}
+
+func (c *Client) ListBuckets(t *test.SystemTest) ([]string, error) {
+	output, err := c.s3.ListBuckets(&s3.ListBucketsInput{})
+	c.log(t, "ListBuckets", "", "", err)
+	if err != nil {
+		return nil, err
+	}
+	buckets := make([]string, 0, len(output.Buckets))
+	for _, bucket := range output.Buckets {
+		buckets = append(buckets, aws.StringValue(bucket.Name))
+	}
+	return buckets, nil
+}
+
+func (c *Client) DeleteBucket(t *test.SystemTest, bucket string) error {
+	_, err := c.s3.DeleteBucket(&s3.DeleteBucketInput{Bucket: aws.String(bucket)})
+	c.log(t, "DeleteBucket", bucket, "", err)
+	return err
+}
+
+// PutObject uploads content in a single request and returns ETag of the object
+func (c *Client) PutObject(t *test.SystemTest, bucket, key string, content io.ReadSeeker) (string, error) {
+	output, err := c.s3.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   content,
+	})
+	c.log(t, "PutObject", bucket, key, err)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(output.ETag), nil
+}
+
+func (c *Client) GetObject(t *test.SystemTest, bucket, key string) ([]byte, error) {
+	return c.getObject(t, bucket, key, nil)
+}
+
+// GetObjectRange reads length bytes of the object starting at offset, length must be positive
+func (c *Client) GetObjectRange(t *test.SystemTest, bucket, key string, offset, length int64) ([]byte, error) {
+	if offset < 0 || length <= 0 {
+		return nil, fmt.Errorf("%w: offset %d, length %d", ErrInvalidRange, offset, length)
+	}
+	return c.getObject(t, bucket, key, aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)))
+}
+
+func (c *Client) getObject(t *test.SystemTest, bucket, key string, byteRange *string) ([]byte, error) {
+	output, err := c.s3.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Range:  byteRange,
+	})
+	c.log(t, "GetObject", bucket, key, err)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return io.ReadAll(output.Body)
+}
+
+// HeadObject returns metadata of the object without its content
+func (c *Client) HeadObject(t *test.SystemTest, bucket, key string) (*Object, error) {
+	output, err := c.s3.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	c.log(t, "HeadObject", bucket, key, err)
+	if err != nil {
+		return nil, err
+	}
+	return &Object{
+		Key:  key,
+		Size: aws.Int64Value(output.ContentLength),
+		ETag: aws.StringValue(output.ETag),
+	}, nil
+}
+
+// CopyObject copies the object on the server, buckets of source and destination may differ
+func (c *Client) CopyObject(t *test.SystemTest, sourceBucket, sourceKey, bucket, key string) error {
+	_, err := c.s3.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(key),
+		CopySource: aws.String((&url.URL{Path: sourceBucket + "/" + sourceKey}).EscapedPath()),
+	})
+	c.log(t, "CopyObject "+sourceBucket+"/"+sourceKey+" to", bucket, key, err)
+	return err
+}
+
+func (c *Client) DeleteObject(t *test.SystemTest, bucket, key string) error {
+	_, err := c.s3.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	c.log(t, "DeleteObject", bucket, key, err)
+	return err
+}
+
+// MultipartUpload uploads content in parts of partSize and returns ETag of the object.
+// The upload is aborted when any part fails, so no parts are left behind on the server.
+func (c *Client) MultipartUpload(t *test.SystemTest, bucket, key string, content io.Reader, partSize int64) (string, error) {
+	if partSize <= 0 {
+		return "", fmt.Errorf("part size must be positive, got %d", partSize)
+	}
+
+	created, err := c.s3.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	c.log(t, "CreateMultipartUpload", bucket, key, err)
+	if err != nil {
+		return "", err
+	}
+	uploadID := created.UploadId
+
+	etag, err := c.uploadParts(t, bucket, key, uploadID, content, partSize)
+	if err != nil {
+		_, abortErr := c.s3.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(key),
+			UploadId: uploadID,
+		})
+		c.log(t, "AbortMultipartUpload", bucket, key, abortErr)
+		return "", err
+	}
+	return etag, nil
+}
+
+func (c *Client) uploadParts(t *test.SystemTest, bucket, key string, uploadID *string, content io.Reader, partSize int64) (string, error) {
+	var parts []*s3.CompletedPart
+	buf := make([]byte, partSize)
+	for partNumber := int64(1); ; partNumber++ {
+		n, err := io.ReadFull(content, buf)
+		if errors.Is(err, io.EOF) && len(parts) > 0 {
+			break
+		}
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return "", err
+		}
+
+		output, uploadErr := c.s3.UploadPart(&s3.UploadPartInput{
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(key),
+			UploadId:   uploadID,
+			PartNumber: aws.Int64(partNumber),
+			Body:       bytes.NewReader(buf[:n]),
+		})
+		c.log(t, fmt.Sprintf("UploadPart %d", partNumber), bucket, key, uploadErr)
+		if uploadErr != nil {
+			return "", uploadErr
+		}
+		parts = append(parts, &s3.CompletedPart{ETag: output.ETag, PartNumber: aws.Int64(partNumber)})
+
+		// short read is the last part
+		if err != nil {
+			break
+		}
+	}
+
+	output, err := c.s3.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		UploadId:        uploadID,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	c.log(t, "CompleteMultipartUpload", bucket, key, err)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(output.ETag), nil
+}
+
+// ListObjectsPage returns a single page of ListObjectsV2
+func (c *Client) ListObjectsPage(t *test.SystemTest, bucket string, options ListOptions) (*ObjectsPage, error) {
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(bucket)}
+	if options.Prefix != "" {
+		input.Prefix = aws.String(options.Prefix)
+	}
+	if options.Delimiter != "" {
+		input.Delimiter = aws.String(options.Delimiter)
+	}
+	if options.StartAfter != "" {
+		input.StartAfter = aws.String(options.StartAfter)
+	}
+	if options.ContinuationToken != "" {
+		input.ContinuationToken = aws.String(options.ContinuationToken)
+	}
+	if options.MaxKeys > 0 {
+		input.MaxKeys = aws.Int64(options.MaxKeys)
+	}
+
+	output, err := c.s3.ListObjectsV2(input)
+	c.log(t, "ListObjectsV2", bucket, options.Prefix, err)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ObjectsPage{}
+	for _, object := range output.Contents {
+		page.Objects = append(page.Objects, Object{
+			Key:  aws.StringValue(object.Key),
+			Size: aws.Int64Value(object.Size),
+			ETag: aws.StringValue(object.ETag),
+		})
+	}
+	for _, prefix := range output.CommonPrefixes {
+		page.CommonPrefixes = append(page.CommonPrefixes, aws.StringValue(prefix.Prefix))
+	}
+	if aws.BoolValue(output.IsTruncated) {
+		page.NextContinuationToken = aws.StringValue(output.NextContinuationToken)
+	}
+	return page, nil
+}
+
+// ListObjects follows continuation tokens and returns objects of every page
+func (c *Client) ListObjects(t *test.SystemTest, bucket string, options ListOptions) (*ObjectsPage, error) {
+	all := &ObjectsPage{}
+	for {
+		page, err := c.ListObjectsPage(t, bucket, options)
+		if err != nil {
+			return nil, err
+		}
+		all.Objects = append(all.Objects, page.Objects...)
+		all.CommonPrefixes = append(all.CommonPrefixes, page.CommonPrefixes...)
+		if page.NextContinuationToken == "" {
+			return all, nil
+		}
+		options.ContinuationToken = page.NextContinuationToken
+		options.StartAfter = ""
+	}
+}
+
+func (c *Client) withoutLogs() *Client {
+	quiet := *c
+	quiet.quiet = true
+	return &quiet
+}
+
+func (c *Client) log(t *test.SystemTest, operation, bucket, key string, err error) {
+	if c.quiet {
+		return
+	}
+	target := strings.TrimSuffix(bucket+"/"+key, "/")
+	if err != nil {
+		t.Logf("%s %s on %s failed: %v", operation, target, c.endpoint, err)
+		return
+	}
+	t.Logf("%s %s on %s succeeded", operation, target, c.endpoint)
+}
@@ -0,0 +1,178 @@
+package zs3_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/datagen"
+	"github.com/0chain/system_test/internal/api/util/mocks3"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/zs3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	server := mocks3.New()
+	defer server.Close()
+
+	// trailing slash of configured urls should be accepted
+	client, err := zs3.NewClient(server.URL()+"/", mocks3.DefaultAccessKey, mocks3.DefaultSecretKey)
+	require.NoError(t, err)
+
+	const bucket = "system-test"
+
+	t.RunSequentially("Bucket operations should succeed", func(t *test.SystemTest) {
+		require.NoError(t, client.CreateBucket(t, bucket))
+
+		err := client.CreateBucket(t, bucket)
+		require.Equal(t, "BucketAlreadyOwnedByYou", zs3.ErrorCode(err))
+		require.Equal(t, http.StatusConflict, zs3.StatusCode(err))
+
+		require.NoError(t, client.CreateBucket(t, "to-delete"))
+		buckets, err := client.ListBuckets(t)
+		require.NoError(t, err)
+		require.Equal(t, []string{bucket, "to-delete"}, buckets)
+
+		require.NoError(t, client.DeleteBucket(t, "to-delete"))
+		err = client.DeleteBucket(t, "to-delete")
+		require.Equal(t, "NoSuchBucket", zs3.ErrorCode(err))
+	})
+
+	t.RunSequentially("Object operations should succeed", func(t *test.SystemTest) {
+		content := []byte("zs3server system test")
+		etag, err := client.PutObject(t, bucket, "dir/file.txt", bytes.NewReader(content))
+		require.NoError(t, err)
+		require.NotEmpty(t, etag)
+
+		object, err := client.HeadObject(t, bucket, "dir/file.txt")
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), object.Size)
+		require.Equal(t, etag, object.ETag)
+
+		got, err := client.GetObject(t, bucket, "dir/file.txt")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+
+		got, err = client.GetObjectRange(t, bucket, "dir/file.txt", 4, 6)
+		require.NoError(t, err)
+		require.Equal(t, content[4:10], got)
+		_, err = client.GetObjectRange(t, bucket, "dir/file.txt", 4, 0)
+		require.ErrorIs(t, err, zs3.ErrInvalidRange)
+
+		err = client.DeleteBucket(t, bucket)
+		require.Equal(t, "BucketNotEmpty", zs3.ErrorCode(err))
+
+		require.NoError(t, client.CreateBucket(t, "copies"))
+		require.NoError(t, client.CopyObject(t, bucket, "dir/file.txt", "copies", "dir/copy of file.txt"))
+		got, err = client.GetObject(t, "copies", "dir/copy of file.txt")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+		err = client.CopyObject(t, bucket, "missing", "copies", "copy")
+		require.Equal(t, "NoSuchKey", zs3.ErrorCode(err))
+		require.NoError(t, client.DeleteObject(t, "copies", "dir/copy of file.txt"))
+		require.NoError(t, client.DeleteBucket(t, "copies"))
+
+		require.NoError(t, client.DeleteObject(t, bucket, "dir/file.txt"))
+		_, err = client.GetObject(t, bucket, "dir/file.txt")
+		require.Equal(t, "NoSuchKey", zs3.ErrorCode(err))
+
+		// S3 does not fail removal of missing objects
+		require.NoError(t, client.DeleteObject(t, bucket, "dir/file.txt"))
+	})
+
+	t.RunSequentially("Multipart upload should assemble parts", func(t *test.SystemTest) {
+		const size = 2*zs3.MinPartSize + 1024
+		source := datagen.New(7, size)
+
+		etag, err := client.MultipartUpload(t, bucket, "big.bin", source.Clone(), zs3.MinPartSize)
+		require.NoError(t, err)
+		require.Contains(t, etag, "-3")
+		require.Equal(t, 3, server.Requests("UploadPart"))
+
+		got, err := client.GetObject(t, bucket, "big.bin")
+		require.NoError(t, err)
+		require.NoError(t, source.Verify(bytes.NewReader(got)))
+
+		got, err = client.GetObjectRange(t, bucket, "big.bin", zs3.MinPartSize-10, 20)
+		require.NoError(t, err)
+		expected := make([]byte, 20)
+		_, err = source.ReadAt(expected, zs3.MinPartSize-10)
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+
+		_, err = client.MultipartUpload(t, "missing-bucket", "big.bin", source.Clone(), zs3.MinPartSize)
+		require.Equal(t, "NoSuchBucket", zs3.ErrorCode(err))
+
+		require.NoError(t, client.DeleteObject(t, bucket, "big.bin"))
+	})
+
+	t.RunSequentially("Failed multipart upload should be aborted", func(t *test.SystemTest) {
+		_, err := client.MultipartUpload(t, bucket, "broken.bin", io.MultiReader(bytes.NewReader(make([]byte, 10)), failingReader{}), 4)
+		require.ErrorIs(t, err, errRead)
+		require.Equal(t, 1, server.Requests("AbortMultipartUpload"))
+
+		_, err = client.HeadObject(t, bucket, "broken.bin")
+		require.Equal(t, http.StatusNotFound, zs3.StatusCode(err))
+	})
+
+	t.RunSequentially("ListObjects should follow continuation tokens", func(t *test.SystemTest) {
+		for i := 0; i < 7; i++ {
+			_, err := client.PutObject(t, bucket, fmt.Sprintf("list/file-%d", i), bytes.NewReader([]byte{byte(i)}))
+			require.NoError(t, err)
+		}
+		_, err := client.PutObject(t, bucket, "list/nested/file", bytes.NewReader(nil))
+		require.NoError(t, err)
+
+		page, err := client.ListObjectsPage(t, bucket, zs3.ListOptions{Prefix: "list/", MaxKeys: 3})
+		require.NoError(t, err)
+		require.Len(t, page.Objects, 3)
+		require.NotEmpty(t, page.NextContinuationToken)
+
+		all, err := client.ListObjects(t, bucket, zs3.ListOptions{Prefix: "list/", MaxKeys: 3})
+		require.NoError(t, err)
+		require.Len(t, all.Objects, 8)
+		require.Equal(t, "list/file-0", all.Objects[0].Key)
+		require.Equal(t, "list/nested/file", all.Objects[7].Key)
+
+		all, err = client.ListObjects(t, bucket, zs3.ListOptions{Prefix: "list/", Delimiter: "/", MaxKeys: 2})
+		require.NoError(t, err)
+		require.Len(t, all.Objects, 7)
+		require.Equal(t, []string{"list/nested/"}, all.CommonPrefixes)
+
+		all, err = client.ListObjects(t, bucket, zs3.ListOptions{Prefix: "list/", StartAfter: "list/file-5"})
+		require.NoError(t, err)
+		require.Len(t, all.Objects, 2)
+	})
+
+	t.RunSequentially("Requests with wrong credentials should be rejected", func(t *test.SystemTest) {
+		wrongSecret, err := zs3.NewClient(server.URL(), mocks3.DefaultAccessKey, "wrong-secret")
+		require.NoError(t, err)
+		_, err = wrongSecret.ListBuckets(t)
+		require.Equal(t, "SignatureDoesNotMatch", zs3.ErrorCode(err))
+		require.Equal(t, http.StatusForbidden, zs3.StatusCode(err))
+
+		wrongKey, err := zs3.NewClient(server.URL(), "wrong-access-key", mocks3.DefaultSecretKey)
+		require.NoError(t, err)
+		_, err = wrongKey.PutObject(t, bucket, "file", bytes.NewReader([]byte("content")))
+		require.Equal(t, "InvalidAccessKeyId", zs3.ErrorCode(err))
+
+		server.AddCredentials("other", "other-secret")
+		other, err := zs3.NewClient(server.URL(), "other", "other-secret")
+		require.NoError(t, err)
+		_, err = other.ListBuckets(t)
+		require.NoError(t, err)
+	})
+}
+
+var errRead = fmt.Errorf("read failed")
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
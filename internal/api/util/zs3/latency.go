@@ -0,0 +1,104 @@
+package zs3
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyStats summarises requests of a single S3 operation of a workload
+type LatencyStats struct {
+	Count  int   `json:"count"`
+	Errors int   `json:"errors"`
+	Bytes  int64 `json:"bytes"`
+
+	Min  time.Duration `json:"min_ns"`
+	Mean time.Duration `json:"mean_ns"`
+	P50  time.Duration `json:"p50_ns"`
+	P90  time.Duration `json:"p90_ns"`
+	P99  time.Duration `json:"p99_ns"`
+	Max  time.Duration `json:"max_ns"`
+
+	// OpsPerSecond and BytesPerSecond are measured over duration of the whole workload
+	OpsPerSecond   float64 `json:"ops_per_second"`
+	BytesPerSecond float64 `json:"bytes_per_second"`
+}
+
+// latencyRecorder collects latency of successful requests, failed requests are only counted
+type latencyRecorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	bytes     map[string]int64
+	lastError error
+}
+
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+		bytes:     make(map[string]int64),
+	}
+}
+
+func (r *latencyRecorder) record(operation string, started time.Time, bytes int64, err error) {
+	latency := time.Since(started)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.errors[operation]++
+		r.lastError = err
+		return
+	}
+	r.latencies[operation] = append(r.latencies[operation], latency)
+	r.bytes[operation] += bytes
+}
+
+func (r *latencyRecorder) stats(elapsed time.Duration) map[string]*LatencyStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make(map[string]*LatencyStats)
+	for operation, count := range r.errors {
+		stats[operation] = &LatencyStats{Errors: count}
+	}
+	for operation, latencies := range r.latencies {
+		s, ok := stats[operation]
+		if !ok {
+			s = &LatencyStats{}
+			stats[operation] = s
+		}
+
+		sorted := append([]time.Duration(nil), latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		var total time.Duration
+		for _, latency := range sorted {
+			total += latency
+		}
+		s.Count = len(sorted)
+		s.Bytes = r.bytes[operation]
+		s.Min = sorted[0]
+		s.Max = sorted[len(sorted)-1]
+		s.Mean = total / time.Duration(len(sorted))
+		s.P50 = percentile(sorted, 50)
+		s.P90 = percentile(sorted, 90)
+		s.P99 = percentile(sorted, 99)
+		if elapsed > 0 {
+			s.OpsPerSecond = float64(s.Count) / elapsed.Seconds()
+			s.BytesPerSecond = float64(s.Bytes) / elapsed.Seconds()
+		}
+	}
+	return stats
+}
+
+// percentile uses nearest rank of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
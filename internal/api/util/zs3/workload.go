@@ -0,0 +1,543 @@
+package zs3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/datagen"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// Contains operations reported by workloads, named the same way as by warp
+const (
+	OpPut    = "PUT"
+	OpGet    = "GET"
+	OpStat   = "STAT"
+	OpDelete = "DELETE"
+	OpList   = "LIST"
+	OpFanout = "FANOUT"
+)
+
+// Contains defaults of zero fields of LoadConfig
+const (
+	DefaultObjectSize = 256
+	DefaultObjects    = 100
+	DefaultCopies     = 50
+	DefaultPrefix     = "zs3-load/"
+)
+
+var (
+	ErrUnboundedLoad   = errors.New("load needs duration or number of operations")
+	ErrContentMismatch = errors.New("object content does not match uploaded content")
+	ErrListingMismatch = errors.New("listing does not match uploaded objects")
+	ErrInvalidSize     = errors.New("invalid size")
+	ErrInvalidRange    = errors.New("invalid byte range")
+)
+
+var sizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"KIB": 1 << 10,
+	"MB":  1000 * 1000,
+	"MIB": 1 << 20,
+	"GB":  1000 * 1000 * 1000,
+	"GIB": 1 << 30,
+}
+
+// mixedDistribution is share of operations in the mixed workload, the same as warp mixed uses
+var mixedDistribution = []struct {
+	operation string
+	weight    int
+}{
+	{OpGet, 45},
+	{OpStat, 30},
+	{OpPut, 15},
+	{OpDelete, 10},
+}
+
+// LoadConfig configures a workload. Workloads run until Duration elapses or Operations are sent,
+// whichever comes first, and remove objects they uploaded when they finish.
+type LoadConfig struct {
+	Bucket      string        `json:"bucket"`
+	Prefix      string        `json:"prefix"`
+	ObjectSize  int64         `json:"object_size"`
+	Concurrency int           `json:"concurrency"`
+	Duration    time.Duration `json:"duration_ns"`
+	Operations  int           `json:"operations"`
+
+	// Objects is number of objects uploaded before mixed and listing workloads start
+	Objects int `json:"objects"`
+
+	// Copies is number of objects written by a single fanout operation
+	Copies int `json:"copies"`
+
+	// PageSize is MaxKeys of listing requests, server default is used when zero
+	PageSize int64 `json:"page_size"`
+}
+
+// LoadResult is reported by workloads and added to metrics of the test
+type LoadResult struct {
+	Workload   string                   `json:"workload"`
+	Config     LoadConfig               `json:"config"`
+	Duration   time.Duration            `json:"duration_ns"`
+	Operations map[string]*LatencyStats `json:"operations"`
+
+	// LastError is the last failed request, it helps to tell why Errors is not zero
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Errors sums failed requests of every operation
+func (r *LoadResult) Errors() int {
+	var errs int
+	for _, stats := range r.Operations {
+		errs += stats.Errors
+	}
+	return errs
+}
+
+// Summary formats the result in a line per operation
+func (r *LoadResult) Summary() string {
+	operations := make([]string, 0, len(r.Operations))
+	for operation := range r.Operations {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	lines := []string{fmt.Sprintf("%s workload ran for %v with concurrency %d and objects of %d bytes",
+		r.Workload, r.Duration.Round(time.Millisecond), r.Config.Concurrency, r.Config.ObjectSize)}
+	for _, operation := range operations {
+		s := r.Operations[operation]
+		lines = append(lines, fmt.Sprintf("%s: %d requests, %d errors, %.2f ops/s, %.0f B/s, latency p50 %v p90 %v p99 %v max %v",
+			operation, s.Count, s.Errors, s.OpsPerSecond, s.BytesPerSecond, s.P50, s.P90, s.P99, s.Max))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseSize parses object sizes in format of warp, e.g. 256B, 512KiB or 10MB
+func ParseSize(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+	i := strings.IndexFunc(size, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(size)
+	}
+	unit, ok := sizeUnits[strings.ToUpper(strings.TrimSpace(size[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit of %q", ErrInvalidSize, size)
+	}
+	value, err := strconv.ParseFloat(size[:i], 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, size)
+	}
+	return int64(value * float64(unit)), nil
+}
+
+// RunPut uploads new objects
+func (c *Client) RunPut(t *test.SystemTest, config LoadConfig) (*LoadResult, error) {
+	run, err := c.newLoadRun(t, "put", config)
+	if err != nil {
+		return nil, err
+	}
+
+	run.elapsed = run.run(func(rnd *rand.Rand) {
+		_, _ = run.put(rnd, run.written)
+	})
+	return run.finish(nil)
+}
+
+// RunMixed uploads Objects objects, then reads, stats, writes and deletes them in proportions of warp mixed.
+// Objects read by the workload are never deleted by it, so every read is expected to succeed.
+func (c *Client) RunMixed(t *test.SystemTest, config LoadConfig) (*LoadResult, error) {
+	run, err := c.newLoadRun(t, "mixed", config)
+	if err != nil {
+		return nil, err
+	}
+	prepared, err := run.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, share := range mixedDistribution {
+		total += share.weight
+	}
+
+	run.elapsed = run.run(func(rnd *rand.Rand) {
+		pick := rnd.Intn(total)
+		operation := ""
+		for _, share := range mixedDistribution {
+			if pick < share.weight {
+				operation = share.operation
+				break
+			}
+			pick -= share.weight
+		}
+
+		switch operation {
+		case OpGet:
+			run.get(prepared.random(rnd))
+		case OpStat:
+			run.stat(prepared.random(rnd))
+		case OpDelete:
+			if key, ok := run.written.take(rnd); ok {
+				started := time.Now()
+				err := run.client.DeleteObject(run.t, run.config.Bucket, key)
+				run.recorder.record(OpDelete, started, 0, err)
+				return
+			}
+			// nothing was written yet, so the object to delete is written first
+			fallthrough
+		case OpPut:
+			_, _ = run.put(rnd, run.written)
+		}
+	})
+	return run.finish(prepared)
+}
+
+// RunFanout uploads an object and copies it on the server to Copies-1 other keys in a single operation
+func (c *Client) RunFanout(t *test.SystemTest, config LoadConfig) (*LoadResult, error) {
+	if config.Copies == 0 {
+		config.Copies = DefaultCopies
+	}
+	run, err := c.newLoadRun(t, "fanout", config)
+	if err != nil {
+		return nil, err
+	}
+
+	run.elapsed = run.run(func(rnd *rand.Rand) {
+		started := time.Now()
+		source, err := run.put(rnd, run.written)
+		if err != nil {
+			run.recorder.record(OpFanout, started, 0, err)
+			return
+		}
+
+		var (
+			wg      sync.WaitGroup
+			errOnce sync.Once
+			copyErr error
+		)
+		for i := 1; i < run.config.Copies; i++ {
+			key := run.nextKey()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := run.client.CopyObject(run.t, run.config.Bucket, source, run.config.Bucket, key); err != nil {
+					errOnce.Do(func() { copyErr = err })
+					return
+				}
+				run.written.add(key, run.written.seed(source))
+			}()
+		}
+		wg.Wait()
+		run.recorder.record(OpFanout, started, run.config.ObjectSize*int64(run.config.Copies), copyErr)
+	})
+	return run.finish(nil)
+}
+
+// RunListingPurge uploads Objects objects, lists them until the load ends and then deletes every object.
+// A listing which does not return exactly the uploaded objects is recorded as failed.
+func (c *Client) RunListingPurge(t *test.SystemTest, config LoadConfig) (*LoadResult, error) {
+	run, err := c.newLoadRun(t, "listing-purge", config)
+	if err != nil {
+		return nil, err
+	}
+	prepared, err := run.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	run.elapsed = run.run(func(*rand.Rand) {
+		run.list(len(prepared.keys()))
+	})
+
+	started := time.Now()
+	if err := run.deleteAll(prepared.keys(), run.recorder); err != nil {
+		run.elapsed += time.Since(started)
+		return run.finish(prepared)
+	}
+	run.elapsed += time.Since(started)
+
+	// listing after the purge must be empty
+	run.list(0)
+	return run.finish(nil)
+}
+
+type loadRun struct {
+	t        *test.SystemTest
+	client   *Client
+	workload string
+	config   LoadConfig
+	prefix   string
+
+	recorder *latencyRecorder
+	written  *objectSet
+	elapsed  time.Duration
+
+	keySeq int64
+}
+
+func (c *Client) newLoadRun(t *test.SystemTest, workload string, config LoadConfig) (*loadRun, error) {
+	if config.Duration <= 0 && config.Operations <= 0 {
+		return nil, ErrUnboundedLoad
+	}
+	if config.ObjectSize == 0 {
+		config.ObjectSize = DefaultObjectSize
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	if config.Objects == 0 {
+		config.Objects = DefaultObjects
+	}
+	if config.Prefix == "" {
+		config.Prefix = DefaultPrefix
+	}
+
+	t.Logf("Starting %s workload on %s/%s with concurrency %d, objects of %d bytes, duration %v and %d operations",
+		workload, c.endpoint, config.Bucket, config.Concurrency, config.ObjectSize, config.Duration, config.Operations)
+	return &loadRun{
+		t:        t,
+		client:   c.withoutLogs(),
+		workload: workload,
+		config:   config,
+		// every run gets its own keys, so listing counts only objects of the run
+		prefix:   fmt.Sprintf("%s%s-%d/", config.Prefix, workload, time.Now().UnixNano()),
+		recorder: newLatencyRecorder(),
+		written:  newObjectSet(),
+	}, nil
+}
+
+// run sends operations from Concurrency workers and returns time it took
+func (r *loadRun) run(operation func(rnd *rand.Rand)) time.Duration {
+	var (
+		sent     int64
+		wg       sync.WaitGroup
+		started  = time.Now()
+		deadline = started.Add(r.config.Duration)
+	)
+	for worker := 0; worker < r.config.Concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(started.UnixNano() + int64(worker))) //nolint:gosec
+			for {
+				if r.config.Duration > 0 && time.Now().After(deadline) {
+					return
+				}
+				if r.config.Operations > 0 && atomic.AddInt64(&sent, 1) > int64(r.config.Operations) {
+					return
+				}
+				operation(rnd)
+			}
+		}(worker)
+	}
+	wg.Wait()
+	return time.Since(started)
+}
+
+func (r *loadRun) nextKey() string {
+	return fmt.Sprintf("%sobject-%08d", r.prefix, atomic.AddInt64(&r.keySeq, 1))
+}
+
+// put uploads a new object and adds it to objects
+func (r *loadRun) put(rnd *rand.Rand, objects *objectSet) (string, error) {
+	key := r.nextKey()
+	seed := rnd.Uint64()
+	started := time.Now()
+	_, err := r.client.PutObject(r.t, r.config.Bucket, key, datagen.New(seed, r.config.ObjectSize))
+	r.recorder.record(OpPut, started, r.config.ObjectSize, err)
+	if err != nil {
+		return "", err
+	}
+	objects.add(key, seed)
+	return key, nil
+}
+
+func (r *loadRun) get(key string, seed uint64) {
+	started := time.Now()
+	content, err := r.client.GetObject(r.t, r.config.Bucket, key)
+	if err == nil {
+		if verifyErr := datagen.New(seed, r.config.ObjectSize).Verify(bytes.NewReader(content)); verifyErr != nil {
+			err = fmt.Errorf("%w: %s: %v", ErrContentMismatch, key, verifyErr)
+		}
+	}
+	r.recorder.record(OpGet, started, int64(len(content)), err)
+}
+
+func (r *loadRun) stat(key string, _ uint64) {
+	started := time.Now()
+	object, err := r.client.HeadObject(r.t, r.config.Bucket, key)
+	if err == nil && object.Size != r.config.ObjectSize {
+		err = fmt.Errorf("%w: %s has %d bytes, expected %d", ErrContentMismatch, key, object.Size, r.config.ObjectSize)
+	}
+	r.recorder.record(OpStat, started, 0, err)
+}
+
+func (r *loadRun) list(expected int) {
+	started := time.Now()
+	page, err := r.client.ListObjects(r.t, r.config.Bucket, ListOptions{Prefix: r.prefix, MaxKeys: r.config.PageSize})
+	if err == nil && len(page.Objects) != expected {
+		err = fmt.Errorf("%w: listed %d objects, expected %d", ErrListingMismatch, len(page.Objects), expected)
+	}
+	r.recorder.record(OpList, started, 0, err)
+}
+
+// prepare uploads Objects objects before the load starts, uploads are not part of the result
+func (r *loadRun) prepare() (*objectSet, error) {
+	prepared := newObjectSet()
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+
+	keys := make(chan struct{}, r.config.Objects)
+	for i := 0; i < r.config.Objects; i++ {
+		keys <- struct{}{}
+	}
+	close(keys)
+
+	var (
+		wg    sync.WaitGroup
+		rndMu sync.Mutex
+	)
+	for worker := 0; worker < r.config.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range keys {
+				rndMu.Lock()
+				workerRnd := rand.New(rand.NewSource(rnd.Int63())) //nolint:gosec
+				rndMu.Unlock()
+				_, _ = r.put(workerRnd, prepared)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the load is measured from a clean recorder
+	uploads := r.recorder
+	r.recorder = newLatencyRecorder()
+	if errs := uploads.errors[OpPut]; errs > 0 {
+		_ = r.deleteAll(prepared.keys(), nil)
+		return nil, fmt.Errorf("%d of %d objects could not be uploaded: %w", errs, r.config.Objects, uploads.lastError)
+	}
+	r.t.Logf("Uploaded %d objects of %s workload to %s", r.config.Objects, r.workload, r.prefix)
+	return prepared, nil
+}
+
+// deleteAll removes objects with Concurrency workers, deletes are recorded when recorder is set
+func (r *loadRun) deleteAll(keys []string, recorder *latencyRecorder) error {
+	queue := make(chan string, len(keys))
+	for _, key := range keys {
+		queue <- key
+	}
+	close(queue)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for worker := 0; worker < r.config.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range queue {
+				started := time.Now()
+				err := r.client.DeleteObject(r.t, r.config.Bucket, key)
+				if recorder != nil {
+					recorder.record(OpDelete, started, 0, err)
+				}
+				if err != nil {
+					errOnce.Do(func() { firstErr = fmt.Errorf("deleting %s: %w", key, err) })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// finish removes objects left by the workload and adds the result to metrics of the test
+func (r *loadRun) finish(prepared *objectSet) (*LoadResult, error) {
+	leftovers := r.written.keys()
+	if prepared != nil {
+		leftovers = append(leftovers, prepared.keys()...)
+	}
+	cleanupErr := r.deleteAll(leftovers, nil)
+
+	result := &LoadResult{
+		Workload:   r.workload,
+		Config:     r.config,
+		Duration:   r.elapsed,
+		Operations: r.recorder.stats(r.elapsed),
+	}
+	if r.recorder.lastError != nil {
+		result.LastError = r.recorder.lastError.Error()
+	}
+	r.t.Log(result.Summary())
+	r.t.AddMetrics("zs3_"+r.workload, result)
+
+	if cleanupErr != nil {
+		return result, fmt.Errorf("objects of %s workload could not be removed: %w", r.workload, cleanupErr)
+	}
+	return result, nil
+}
+
+// objectSet tracks uploaded objects with seeds of their content
+type objectSet struct {
+	mu    sync.Mutex
+	order []string
+	seeds map[string]uint64
+}
+
+func newObjectSet() *objectSet {
+	return &objectSet{seeds: make(map[string]uint64)}
+}
+
+func (s *objectSet) add(key string, seed uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.order = append(s.order, key)
+	s.seeds[key] = seed
+}
+
+func (s *objectSet) seed(key string) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seeds[key]
+}
+
+func (s *objectSet) random(rnd *rand.Rand) (string, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := s.order[rnd.Intn(len(s.order))]
+	return key, s.seeds[key]
+}
+
+// take removes a random object from the set
+func (s *objectSet) take(rnd *rand.Rand) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.order) == 0 {
+		return "", false
+	}
+	i := rnd.Intn(len(s.order))
+	key := s.order[i]
+	s.order[i] = s.order[len(s.order)-1]
+	s.order = s.order[:len(s.order)-1]
+	delete(s.seeds, key)
+	return key, true
+}
+
+func (s *objectSet) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.order...)
+}
@@ -0,0 +1,141 @@
+package zs3_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/mocks3"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/zs3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloads(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	server := mocks3.New()
+	defer server.Close()
+
+	client, err := zs3.NewClient(server.URL(), mocks3.DefaultAccessKey, mocks3.DefaultSecretKey)
+	require.NoError(t, err)
+
+	const bucket = "load"
+	require.NoError(t, client.CreateBucket(t, bucket))
+
+	config := zs3.LoadConfig{
+		Bucket:      bucket,
+		ObjectSize:  1024,
+		Concurrency: 4,
+		Operations:  200,
+		Objects:     20,
+		Copies:      5,
+		PageSize:    7,
+	}
+
+	requireNoObjectsLeft := func(t *test.SystemTest) {
+		objects, err := client.ListObjects(t, bucket, zs3.ListOptions{})
+		require.NoError(t, err)
+		require.Empty(t, objects.Objects)
+	}
+
+	requireStats := func(t *test.SystemTest, stats *zs3.LatencyStats) {
+		require.NotNil(t, stats)
+		require.Zero(t, stats.Errors)
+		require.Positive(t, stats.Count)
+		require.LessOrEqual(t, stats.Min, stats.P50)
+		require.LessOrEqual(t, stats.P50, stats.P90)
+		require.LessOrEqual(t, stats.P90, stats.P99)
+		require.LessOrEqual(t, stats.P99, stats.Max)
+		require.Positive(t, stats.OpsPerSecond)
+	}
+
+	t.RunSequentially("Load without bounds should be rejected", func(t *test.SystemTest) {
+		_, err := client.RunPut(t, zs3.LoadConfig{Bucket: bucket})
+		require.ErrorIs(t, err, zs3.ErrUnboundedLoad)
+	})
+
+	t.RunSequentially("Put workload should upload objects", func(t *test.SystemTest) {
+		result, err := client.RunPut(t, config)
+		require.NoError(t, err)
+		require.Zero(t, result.Errors())
+		requireStats(t, result.Operations[zs3.OpPut])
+		require.Equal(t, 200, result.Operations[zs3.OpPut].Count)
+		require.Equal(t, int64(200*1024), result.Operations[zs3.OpPut].Bytes)
+		requireNoObjectsLeft(t)
+	})
+
+	t.RunSequentially("Mixed workload should verify content of reads", func(t *test.SystemTest) {
+		result, err := client.RunMixed(t, config)
+		require.NoError(t, err)
+		require.Zero(t, result.Errors(), result.LastError)
+
+		requests := 0
+		for _, operation := range []string{zs3.OpGet, zs3.OpStat, zs3.OpPut} {
+			requireStats(t, result.Operations[operation])
+			requests += result.Operations[operation].Count
+		}
+		if deletes, ok := result.Operations[zs3.OpDelete]; ok {
+			requests += deletes.Count
+		}
+		require.Equal(t, 200, requests)
+		require.Equal(t, int64(result.Operations[zs3.OpGet].Count*1024), result.Operations[zs3.OpGet].Bytes)
+		requireNoObjectsLeft(t)
+	})
+
+	t.RunSequentially("Fanout workload should copy every object", func(t *test.SystemTest) {
+		fanout := config
+		fanout.Operations = 10
+		result, err := client.RunFanout(t, fanout)
+		require.NoError(t, err)
+		require.Zero(t, result.Errors())
+		requireStats(t, result.Operations[zs3.OpFanout])
+		require.Equal(t, 10, result.Operations[zs3.OpFanout].Count)
+		require.Equal(t, 40, server.Requests("CopyObject"))
+		requireNoObjectsLeft(t)
+	})
+
+	t.RunSequentially("Listing workload should list and purge objects", func(t *test.SystemTest) {
+		listing := config
+		listing.Operations = 0
+		listing.Duration = 200 * time.Millisecond
+		result, err := client.RunListingPurge(t, listing)
+		require.NoError(t, err)
+		require.Zero(t, result.Errors(), result.LastError)
+		requireStats(t, result.Operations[zs3.OpList])
+		requireStats(t, result.Operations[zs3.OpDelete])
+		require.Equal(t, 20, result.Operations[zs3.OpDelete].Count)
+		require.GreaterOrEqual(t, result.Duration, listing.Duration)
+		requireNoObjectsLeft(t)
+	})
+
+	t.RunSequentially("Failed requests should be counted", func(t *test.SystemTest) {
+		result, err := client.RunPut(t, zs3.LoadConfig{Bucket: "missing", Operations: 5})
+		require.NoError(t, err)
+		require.Equal(t, 5, result.Errors())
+		require.Contains(t, result.LastError, "NoSuchBucket")
+
+		_, err = client.RunMixed(t, zs3.LoadConfig{Bucket: "missing", Operations: 5})
+		require.Error(t, err)
+	})
+}
+
+func TestParseSize(t *testing.T) {
+	for size, expected := range map[string]int64{
+		"256":    256,
+		"256B":   256,
+		"512KiB": 512 * 1024,
+		"1.5MiB": 3 * 512 * 1024,
+		"10MB":   10 * 1000 * 1000,
+		"1 GiB":  1 << 30,
+		"2kb":    2000,
+	} {
+		parsed, err := zs3.ParseSize(size)
+		require.NoError(t, err, size)
+		require.Equal(t, expected, parsed, size)
+	}
+
+	for _, size := range []string{"", "KiB", "10XB", "-1B"} {
+		_, err := zs3.ParseSize(size)
+		require.ErrorIs(t, err, zs3.ErrInvalidSize, size)
+	}
+}
@@ -411,6 +411,9 @@ type WalletFile struct {
 	Mnemonic    string    `json:"mnemonics"`
 	Version     string    `json:"version"`
 	DateCreated string    `json:"date_created"`
+
+	PeerPublicKey string `json:"peer_public_key,omitempty"`
+	IsSplit       bool   `json:"is_split,omitempty"`
 }
 
 type KeyPair struct {
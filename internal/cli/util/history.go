@@ -24,6 +24,8 @@ type ChainHistory struct {
 	providerRewards []model.RewardProvider
 	transactions    []model.EventDBTransaction
 	roundHistories  map[int64]RoundHistory
+
+	includeTransactions bool
 }
 
 type RoundHistory struct {
@@ -80,13 +82,18 @@ func (ch *ChainHistory) FeesForRound(t *test.SystemTest, round int64) int64 {
 }
 
 func (ch *ChainHistory) Read(t *test.SystemTest, sharderBaseUrl string, includeTransactions bool) {
+	ch.read(t, sharderBaseUrl, includeTransactions)
+	ch.setup(t)
+}
+
+func (ch *ChainHistory) read(t *test.SystemTest, sharderBaseUrl string, includeTransactions bool) {
 	ch.readBlocks(t, sharderBaseUrl)
 	ch.readDelegateRewards(t, sharderBaseUrl)
 	ch.readProviderRewards(t, sharderBaseUrl)
 	if includeTransactions {
 		ch.readTransaction(t, sharderBaseUrl)
 	}
-	ch.setup(t)
+	ch.includeTransactions = includeTransactions
 }
 
 func (ch *ChainHistory) readBlocks(t *test.SystemTest, sharderBaseUrl string) {
@@ -164,6 +171,9 @@ func (ch *ChainHistory) setup(t *test.SystemTest) { // nolint:
 		}
 		currentHistory.DelegateRewards = append(currentHistory.DelegateRewards, dr)
 	}
+	if currentRound > 0 {
+		ch.roundHistories[currentRound] = currentHistory
+	}
 	ch.setupTransactions(t)
 
 	require.Equalf(t, int(ch.to-ch.from+1), len(ch.roundHistories),
@@ -0,0 +1,221 @@
+package cliutils
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
+	"github.com/0chain/system_test/internal/cli/model"
+)
+
+// Contains how the ledger accounts for each reward type
+const (
+	// Minted rewards are created by the miner smart contract for each block
+	Minted RewardSource = iota
+	// Fees are paid out of the fees of the block transactions
+	Fees
+	// Transferred rewards are moved from the pools of storage and bridge smart contracts
+	Transferred
+	// Slashed is taken from the stake of providers
+	Slashed
+)
+
+type RewardSource int
+
+var rewardSources = map[model.Reward]RewardSource{
+	model.BlockRewardMiner:         Minted,
+	model.BlockRewardSharder:       Minted,
+	model.FeeRewardMiner:           Fees,
+	model.FeeRewardSharder:         Fees,
+	model.BlockRewardBlobber:       Transferred,
+	model.FeeRewardAuthorizer:      Transferred,
+	model.ValidationReward:         Transferred,
+	model.FileDownloadReward:       Transferred,
+	model.ChallengePassReward:      Transferred,
+	model.CancellationChargeReward: Transferred,
+	model.ChallengeSlashPenalty:    Slashed,
+}
+
+// Discrepancy is a round in which payouts do not add up, ProviderID is empty for totals of the round
+type Discrepancy struct {
+	Round      int64
+	ProviderID string
+	RewardType model.Reward
+	Expected   int64
+	Actual     int64
+	Reason     string
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("round %d provider %q reward type %d: %s, expected %d, actual %d",
+		d.Round, d.ProviderID, d.RewardType, d.Reason, d.Expected, d.Actual)
+}
+
+// Ledger is the result of reconciling rewards of a chain history
+type Ledger struct {
+	Minted        int64
+	Fees          int64
+	Paid          map[model.Reward]int64
+	Discrepancies []Discrepancy
+}
+
+// Reconcile replays every round of the history and checks that minted block rewards and block fees
+// are paid out in full to the providers and delegates of the round. Block rewards and fees shared by
+// sharders are split equally, the remainder of the split is not paid out. Fees are only reconciled
+// when the history was read with transactions.
+func (ch *ChainHistory) Reconcile(config rewards.Config) Ledger {
+	ledger := Ledger{Paid: make(map[model.Reward]int64)}
+
+	for round := ch.from; round <= ch.to; round++ {
+		rh, ok := ch.roundHistories[round]
+		if !ok || rh.Block == nil {
+			ledger.Discrepancies = append(ledger.Discrepancies, Discrepancy{
+				Round:  round,
+				Reason: "no block in history",
+			})
+			continue
+		}
+		ch.reconcileRound(config, round, rh, &ledger)
+	}
+	return ledger
+}
+
+func (ch *ChainHistory) reconcileRound(config rewards.Config, round int64, rh RoundHistory, ledger *Ledger) {
+	paid := make(map[model.Reward]int64)
+	sharders := make(map[model.Reward]map[string]int64)
+	add := func(providerID string, rewardType model.Reward, amount int64) {
+		if _, ok := rewardSources[rewardType]; !ok {
+			ledger.Discrepancies = append(ledger.Discrepancies, Discrepancy{
+				Round:      round,
+				ProviderID: providerID,
+				RewardType: rewardType,
+				Actual:     amount,
+				Reason:     "unknown reward type",
+			})
+			return
+		}
+
+		paid[rewardType] += amount
+		ledger.Paid[rewardType] += amount
+
+		switch rewardType {
+		case model.BlockRewardMiner, model.FeeRewardMiner:
+			if providerID != rh.Block.MinerID {
+				ledger.Discrepancies = append(ledger.Discrepancies, Discrepancy{
+					Round:      round,
+					ProviderID: providerID,
+					RewardType: rewardType,
+					Actual:     amount,
+					Reason:     "block was mined by " + rh.Block.MinerID,
+				})
+			}
+		case model.BlockRewardSharder, model.FeeRewardSharder:
+			if sharders[rewardType] == nil {
+				sharders[rewardType] = make(map[string]int64)
+			}
+			sharders[rewardType][providerID] += amount
+		}
+	}
+	for _, pr := range rh.ProviderRewards {
+		add(pr.ProviderId, pr.RewardType, pr.Amount)
+	}
+	for _, dr := range rh.DelegateRewards {
+		add(dr.ProviderID, dr.RewardType, dr.Amount)
+	}
+
+	minerReward, sharderReward, err := rewards.BlockReward(config, round)
+	if err != nil {
+		ledger.Discrepancies = append(ledger.Discrepancies, Discrepancy{
+			Round:  round,
+			Reason: "cannot calculate block reward: " + err.Error(),
+		})
+		return
+	}
+	ledger.Minted += int64(minerReward) + sharedBySharders(int64(sharderReward), len(sharders[model.BlockRewardSharder]))
+	ledger.check(round, rh.Block.MinerID, model.BlockRewardMiner, int64(minerReward), paid[model.BlockRewardMiner])
+	ledger.checkSharders(config, round, model.BlockRewardSharder, int64(sharderReward), sharders[model.BlockRewardSharder])
+
+	if !ch.includeTransactions {
+		if feeRewards := paid[model.FeeRewardMiner] + paid[model.FeeRewardSharder]; feeRewards > 0 {
+			ledger.Discrepancies = append(ledger.Discrepancies, Discrepancy{
+				Round:  round,
+				Actual: feeRewards,
+				Reason: "fee rewards cannot be reconciled, history was read without transactions",
+			})
+		}
+		return
+	}
+	var fees int64
+	for i := range rh.Transactions {
+		fees += rh.Transactions[i].Fee
+	}
+	minerFees := int64(float64(fees) * config.ShareRatio)
+	ledger.Fees += minerFees + sharedBySharders(fees-minerFees, len(sharders[model.FeeRewardSharder]))
+	ledger.check(round, rh.Block.MinerID, model.FeeRewardMiner, minerFees, paid[model.FeeRewardMiner])
+	ledger.checkSharders(config, round, model.FeeRewardSharder, fees-minerFees, sharders[model.FeeRewardSharder])
+}
+
+// sharedBySharders returns the part of value paid out when it is split equally between sharders
+func sharedBySharders(value int64, sharders int) int64 {
+	if sharders == 0 {
+		return value
+	}
+	return value / int64(sharders) * int64(sharders)
+}
+
+func (l *Ledger) check(round int64, providerID string, rewardType model.Reward, expected, actual int64) {
+	if expected == actual {
+		return
+	}
+	l.Discrepancies = append(l.Discrepancies, Discrepancy{
+		Round:      round,
+		ProviderID: providerID,
+		RewardType: rewardType,
+		Expected:   expected,
+		Actual:     actual,
+		Reason:     "payouts of the round do not add up",
+	})
+}
+
+// checkSharders checks that at most NumShardersRewarded sharders were rewarded in the round and that each of them,
+// together with its delegates, got an equal share
+func (l *Ledger) checkSharders(config rewards.Config, round int64, rewardType model.Reward, total int64, paid map[string]int64) {
+	if len(paid) == 0 {
+		l.check(round, "", rewardType, total, 0)
+		return
+	}
+	if config.NumShardersRewarded > 0 && len(paid) > config.NumShardersRewarded {
+		l.Discrepancies = append(l.Discrepancies, Discrepancy{
+			Round:      round,
+			RewardType: rewardType,
+			Expected:   int64(config.NumShardersRewarded),
+			Actual:     int64(len(paid)),
+			Reason:     "too many sharders rewarded",
+		})
+	}
+
+	ids := make([]string, 0, len(paid))
+	for id := range paid {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		l.check(round, id, rewardType, total/int64(len(paid)), paid[id])
+	}
+}
+
+// Balanced reports whether every round of the history reconciled
+func (l *Ledger) Balanced() bool {
+	return len(l.Discrepancies) == 0
+}
+
+// PaidBy returns total paid in the history from the source
+func (l *Ledger) PaidBy(source RewardSource) int64 {
+	var total int64
+	for rewardType, amount := range l.Paid {
+		if rewardSources[rewardType] == source {
+			total += amount
+		}
+	}
+	return total
+}
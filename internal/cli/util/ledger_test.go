@@ -0,0 +1,93 @@
+package cliutils
+
+import (
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
+	"github.com/0chain/system_test/internal/cli/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	config := rewards.Config{Epoch: 100, BlockReward: 1e10, ShareRatio: 0.8}
+
+	history := testHistory(t)
+
+	ledger := history.Reconcile(config)
+
+	require.False(t, ledger.Balanced())
+	require.Equal(t, []Discrepancy{
+		{Round: 11, ProviderID: "miner1", RewardType: model.BlockRewardMiner, Actual: 100, Reason: "block was mined by miner2"},
+		{Round: 11, ProviderID: "blobber1", RewardType: model.NumOfRewards, Actual: 1, Reason: "unknown reward type"},
+		{Round: 11, ProviderID: "miner2", RewardType: model.BlockRewardMiner, Expected: 8e9, Actual: 8e9 + 100, Reason: "payouts of the round do not add up"},
+		{Round: 11, ProviderID: "sharder2", RewardType: model.BlockRewardSharder, Expected: 1e9, Actual: 999_999_999, Reason: "payouts of the round do not add up"},
+	}, ledger.Discrepancies)
+
+	require.EqualValues(t, 2e10, ledger.Minted)
+	// remainder of the fees split between sharders is not paid out
+	require.EqualValues(t, 1000, ledger.Fees)
+	require.EqualValues(t, 2e10-1+100, ledger.PaidBy(Minted))
+	require.EqualValues(t, 1000, ledger.PaidBy(Fees))
+	require.EqualValues(t, 500, ledger.PaidBy(Transferred))
+
+	t.Run("sharders above the rewarded number should be reported", func(t *testing.T) {
+		config := config
+		config.NumShardersRewarded = 1
+		ledger := testHistory(t).Reconcile(config)
+		require.Contains(t, ledger.Discrepancies, Discrepancy{
+			Round: 10, RewardType: model.BlockRewardSharder, Expected: 1, Actual: 2, Reason: "too many sharders rewarded",
+		})
+		require.Contains(t, ledger.Discrepancies, Discrepancy{
+			Round: 10, RewardType: model.FeeRewardSharder, Expected: 1, Actual: 2, Reason: "too many sharders rewarded",
+		})
+	})
+
+	t.Run("fee rewards without transactions should be reported", func(t *testing.T) {
+		history := testHistory(t)
+		history.includeTransactions = false
+		ledger := history.Reconcile(config)
+		require.Contains(t, ledger.Discrepancies, Discrepancy{
+			Round: 10, Actual: 1000, Reason: "fee rewards cannot be reconciled, history was read without transactions",
+		})
+	})
+
+	t.Run("rounds without block should be reported", func(t *testing.T) {
+		history := &ChainHistory{from: 1, to: 1}
+		ledger := history.Reconcile(config)
+		require.Equal(t, []Discrepancy{{Round: 1, Reason: "no block in history"}}, ledger.Discrepancies)
+	})
+}
+
+// testHistory returns history of rounds 10 and 11, round 11 has payouts which do not add up
+func testHistory(t *testing.T) *ChainHistory {
+	history := NewHistory(10, 11)
+	history.includeTransactions = true
+	history.blocks = []model.EventDBBlock{
+		{Round: 10, MinerID: "miner1"},
+		{Round: 11, MinerID: "miner2"},
+	}
+	history.transactions = []model.EventDBTransaction{
+		{Round: 10, Fee: 600},
+		{Round: 10, Fee: 401},
+	}
+	history.providerRewards = []model.RewardProvider{
+		{BlockNumber: 10, ProviderId: "miner1", RewardType: model.BlockRewardMiner, Amount: 8e8},
+		{BlockNumber: 10, ProviderId: "sharder1", RewardType: model.BlockRewardSharder, Amount: 1e9},
+		{BlockNumber: 10, ProviderId: "sharder2", RewardType: model.BlockRewardSharder, Amount: 1e9},
+		{BlockNumber: 10, ProviderId: "miner1", RewardType: model.FeeRewardMiner, Amount: 800},
+		{BlockNumber: 10, ProviderId: "sharder1", RewardType: model.FeeRewardSharder, Amount: 100},
+		{BlockNumber: 10, ProviderId: "sharder2", RewardType: model.FeeRewardSharder, Amount: 100},
+		{BlockNumber: 10, ProviderId: "blobber1", RewardType: model.ChallengePassReward, Amount: 500},
+		{BlockNumber: 11, ProviderId: "miner2", RewardType: model.BlockRewardMiner, Amount: 8e9},
+		{BlockNumber: 11, ProviderId: "miner1", RewardType: model.BlockRewardMiner, Amount: 100},
+		{BlockNumber: 11, ProviderId: "sharder1", RewardType: model.BlockRewardSharder, Amount: 1e9},
+		{BlockNumber: 11, ProviderId: "sharder2", RewardType: model.BlockRewardSharder, Amount: 999_999_999},
+		{BlockNumber: 11, ProviderId: "blobber1", RewardType: model.NumOfRewards, Amount: 1},
+	}
+	history.DelegateRewards = []model.RewardDelegate{
+		{BlockNumber: 10, ProviderID: "miner1", PoolID: "delegate1", RewardType: model.BlockRewardMiner, Amount: 72e8},
+	}
+	history.setup(test.NewSystemTest(t))
+	return history
+}
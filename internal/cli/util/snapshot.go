@@ -0,0 +1,183 @@
+package cliutils
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/cli/model"
+)
+
+// Contains extensions of chain history snapshots
+const (
+	JSONSnapshot = ".json.gz"
+	GobSnapshot  = ".gob.gz"
+)
+
+var ErrSnapshotFormat = errors.New("unknown snapshot format, expected " + JSONSnapshot + " or " + GobSnapshot)
+
+// historySnapshot is the on-disk form of ChainHistory
+type historySnapshot struct {
+	From                int64
+	To                  int64
+	IncludeTransactions bool
+	Blocks              []model.EventDBBlock
+	DelegateRewards     []model.RewardDelegate
+	ProviderRewards     []model.RewardProvider
+	Transactions        []model.EventDBTransaction
+	RoundHistories      map[int64]RoundHistory
+}
+
+// Save writes history to a gzip compressed snapshot, the format is chosen by extension of path
+func (ch *ChainHistory) Save(path string) (err error) {
+	encode, err := snapshotEncoder(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	zw := gzip.NewWriter(file)
+	err = encode(zw, &historySnapshot{
+		From:                ch.from,
+		To:                  ch.to,
+		IncludeTransactions: ch.includeTransactions,
+		Blocks:              ch.blocks,
+		DelegateRewards:     ch.DelegateRewards,
+		ProviderRewards:     ch.providerRewards,
+		Transactions:        ch.transactions,
+		RoundHistories:      ch.roundHistories,
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// LoadHistory reads history saved by Save, it can be queried and extended as if it was read from a sharder
+func LoadHistory(path string) (*ChainHistory, error) {
+	decode, err := snapshotDecoder(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	defer zr.Close()
+
+	var snapshot historySnapshot
+	if err := decode(zr, &snapshot); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	ch := &ChainHistory{
+		from:                snapshot.From,
+		to:                  snapshot.To,
+		includeTransactions: snapshot.IncludeTransactions,
+		blocks:              snapshot.Blocks,
+		DelegateRewards:     snapshot.DelegateRewards,
+		providerRewards:     snapshot.ProviderRewards,
+		transactions:        snapshot.Transactions,
+		roundHistories:      snapshot.RoundHistories,
+	}
+	// blocks of round histories are decoded as copies, point them back to the blocks of the history
+	for i := range ch.blocks {
+		if rh, ok := ch.roundHistories[ch.blocks[i].Round]; ok {
+			rh.Block = &ch.blocks[i]
+			ch.roundHistories[ch.blocks[i].Round] = rh
+		}
+	}
+	return ch, nil
+}
+
+// SaveOnFailure saves history to TEST_REPORT_DIR when the test fails, so the failure can be debugged offline
+func (ch *ChainHistory) SaveOnFailure(t *test.SystemTest) {
+	dir := os.Getenv("TEST_REPORT_DIR")
+	if dir == "" {
+		return
+	}
+
+	t.Unwrap.Cleanup(func() {
+		if !t.Unwrap.Failed() {
+			return
+		}
+		name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Unwrap.Name())
+		path := filepath.Join(dir, fmt.Sprintf("%s_%d_%d%s", name, ch.from, ch.to, GobSnapshot))
+		if err := ch.Save(path); err != nil {
+			t.Logf("saving chain history: %v", err)
+			return
+		}
+		t.Logf("chain history of rounds %d to %d saved to %s", ch.from, ch.to, path)
+	})
+}
+
+// Extend reads rounds after the last one of history up to round to, rounds already read are not fetched again
+func (ch *ChainHistory) Extend(t *test.SystemTest, sharderBaseUrl string, to int64) {
+	require.GreaterOrEqual(t, to, ch.to, "cannot extend history to %d, it ends at %d", to, ch.to)
+	if to == ch.to {
+		return
+	}
+
+	next := NewHistory(ch.to+1, to)
+	next.read(t, sharderBaseUrl, ch.includeTransactions)
+
+	ch.blocks = append(ch.blocks, next.blocks...)
+	ch.DelegateRewards = append(ch.DelegateRewards, next.DelegateRewards...)
+	ch.providerRewards = append(ch.providerRewards, next.providerRewards...)
+	ch.transactions = append(ch.transactions, next.transactions...)
+	ch.to = to
+	ch.setup(t)
+}
+
+func snapshotEncoder(path string) (func(io.Writer, *historySnapshot) error, error) {
+	switch {
+	case strings.HasSuffix(path, JSONSnapshot):
+		return func(w io.Writer, snapshot *historySnapshot) error {
+			return json.NewEncoder(w).Encode(snapshot)
+		}, nil
+	case strings.HasSuffix(path, GobSnapshot):
+		return func(w io.Writer, snapshot *historySnapshot) error {
+			return gob.NewEncoder(w).Encode(snapshot)
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSnapshotFormat, path)
+}
+
+func snapshotDecoder(path string) (func(io.Reader, *historySnapshot) error, error) {
+	switch {
+	case strings.HasSuffix(path, JSONSnapshot):
+		return func(r io.Reader, snapshot *historySnapshot) error {
+			return json.NewDecoder(r).Decode(snapshot)
+		}, nil
+	case strings.HasSuffix(path, GobSnapshot):
+		return func(r io.Reader, snapshot *historySnapshot) error {
+			return gob.NewDecoder(r).Decode(snapshot)
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSnapshotFormat, path)
+}
@@ -0,0 +1,140 @@
+package cliutils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	history := testHistory(t)
+	config := rewards.Config{Epoch: 100, BlockReward: 1e10, ShareRatio: 0.8}
+
+	for _, format := range []string{JSONSnapshot, GobSnapshot} {
+		t.Run("history should be loaded from "+format+" snapshot", func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history"+format)
+			require.NoError(t, history.Save(path))
+
+			loaded, err := LoadHistory(path)
+			require.NoError(t, err)
+			require.Equal(t, history, loaded)
+			require.Same(t, &loaded.blocks[1], loaded.roundHistories[11].Block)
+			require.Equal(t, history.Reconcile(config), loaded.Reconcile(config))
+		})
+	}
+
+	t.Run("unknown format should not be saved", func(t *testing.T) {
+		err := history.Save(filepath.Join(t.TempDir(), "history.txt"))
+		require.ErrorIs(t, err, ErrSnapshotFormat)
+
+		_, err = LoadHistory("history.txt")
+		require.ErrorIs(t, err, ErrSnapshotFormat)
+	})
+}
+
+func TestExtend(t *testing.T) {
+	full := testHistory(t)
+	sharder := newHistorySharder(full)
+	defer sharder.Close()
+
+	st := test.NewSystemTest(t)
+	history := NewHistory(10, 10)
+	history.Read(st, sharder.URL, true)
+	require.Equal(t, []int64{10}, sharder.starts())
+
+	history.Extend(st, sharder.URL, 11)
+	require.Equal(t, []int64{10, 11}, sharder.starts(), "rounds already read should not be fetched again")
+	require.Equal(t, full, history)
+}
+
+// historySharder serves lists of a history the way sharder does, it records the first round of each list read
+type historySharder struct {
+	*httptest.Server
+	history *ChainHistory
+
+	mu     sync.Mutex
+	rounds map[string][]int64
+}
+
+func newHistorySharder(history *ChainHistory) *historySharder {
+	s := &historySharder{history: history, rounds: make(map[string][]int64)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// starts returns first rounds of block lists read, other lists are checked to be read in the same ranges
+func (s *historySharder) starts() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rounds := range s.rounds {
+		if len(rounds) != len(s.rounds["get_blocks"]) {
+			return nil
+		}
+	}
+	return append([]int64(nil), s.rounds["get_blocks"]...)
+}
+
+func (s *historySharder) serve(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	start, _ := strconv.ParseInt(query.Get("start"), 10, 64)
+	end, _ := strconv.ParseInt(query.Get("end"), 10, 64)
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	list := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	if offset == 0 {
+		s.mu.Lock()
+		s.rounds[list] = append(s.rounds[list], start)
+		s.mu.Unlock()
+	}
+
+	var items []any
+	inRange := func(round int64) bool {
+		return round >= start && round < end
+	}
+	switch list {
+	case "get_blocks":
+		for _, item := range s.history.blocks {
+			if inRange(item.Round) {
+				items = append(items, item)
+			}
+		}
+	case "delegate-rewards":
+		for _, item := range s.history.DelegateRewards {
+			if inRange(item.BlockNumber) {
+				items = append(items, item)
+			}
+		}
+	case "provider-rewards":
+		for _, item := range s.history.providerRewards {
+			if inRange(item.BlockNumber) {
+				items = append(items, item)
+			}
+		}
+	case "transactions":
+		for _, item := range s.history.transactions {
+			if inRange(item.Round) {
+				items = append(items, item)
+			}
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	items = items[min(offset, len(items)):]
+	items = items[:min(limit, len(items))]
+	if items == nil {
+		items = []any{}
+	}
+	_ = json.NewEncoder(w).Encode(items)
+}
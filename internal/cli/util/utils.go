@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0chain/system_test/internal/api/util/redact"
 	"github.com/0chain/system_test/internal/api/util/test"
 	"gopkg.in/yaml.v2"
 
@@ -54,22 +55,7 @@ func RunCommandWithoutRetry(commandString string) ([]string, error) {
 
 	sanitizedArgs := sanitizeArgs(args)
 	rawOutput, err := executeCommand(commandName, sanitizedArgs)
-	var commandStringForLog string
-
-	// Redact keys before logging
-	if strings.Contains(commandString, "--access-key") {
-		index := strings.Index(commandString, "--access-key")
-		// get next single word after "--access-key"
-		accessKey := strings.Fields(commandString[index:])[1]
-		commandStringForLog = strings.ReplaceAll(commandString, accessKey, "****")
-	}
-	if strings.Contains(commandString, "--secret-key") {
-		index := strings.Index(commandString, "--secret-key")
-		// get next single word after "--secret-key"
-		secretKey := strings.Fields(commandString[index:])[1]
-		commandStringForLog = strings.ReplaceAll(commandString, secretKey, "****")
-	}
-	Logger.Debugf("Command [%v] exited with error [%v] and output [%v]", commandStringForLog, err, sanitizeOutput(rawOutput))
+	Logger.Debugf("Command [%v] exited with error [%v] and output [%v]", commandString, err, sanitizeOutput(rawOutput))
 
 	return sanitizeOutput(rawOutput), err
 }
@@ -93,7 +79,6 @@ func RunCommand(t *test.SystemTest, commandString string, maxAttempts int, backo
 	red := "\033[31m"
 	yellow := "\033[33m"
 	green := "\033[32m"
-	var commandStringForLog string
 
 	var count int
 	for {
@@ -107,22 +92,10 @@ func RunCommand(t *test.SystemTest, commandString string, maxAttempts int, backo
 			return output, nil
 		} else if count < maxAttempts {
 			t.Logf("%sCommand failed on attempt [%v/%v] due to error [%v]. Output: [%v]\n", yellow, count, maxAttempts, err, strings.Join(output, " -<NEWLINE>- "))
+			t.AddRetry()
 			time.Sleep(backoff)
 		} else {
-			// Redact keys before logging
-			if strings.Contains(commandString, "--access-key") {
-				index := strings.Index(commandString, "--access-key")
-				// get next single word after "--access-key"
-				accessKey := strings.Fields(commandString[index:])[1]
-				commandStringForLog = strings.ReplaceAll(commandString, accessKey, "****")
-			}
-			if strings.Contains(commandString, "--secret-key") {
-				index := strings.Index(commandString, "--secret-key")
-				// get next single word after "--secret-key"
-				secretKey := strings.Fields(commandString[index:])[1]
-				commandStringForLog = strings.ReplaceAll(commandString, secretKey, "****")
-			}
-			t.Logf("%sCommand failed on final attempt [%v/%v] due to error [%v]. Command String: [%v] Output: [%v]\n", red, count, maxAttempts, err, commandStringForLog, strings.Join(output, " -<NEWLINE>- "))
+			t.Logf("%sCommand failed on final attempt [%v/%v] due to error [%v]. Command String: [%v] Output: [%v]\n", red, count, maxAttempts, err, commandString, strings.Join(output, " -<NEWLINE>- "))
 
 			if err != nil {
 				t.Logf("%sThe verbose output for the command is:", red)
@@ -152,6 +125,7 @@ func StartCommand(t *test.SystemTest, commandString string, maxAttempts int, bac
 		} else if count < maxAttempts {
 			t.Logf("Command failed on attempt [%v/%v] due to error [%v]\n", count, maxAttempts, err)
 			t.Logf("Sleeping for backoff duration: %v\n", backoff)
+			t.AddRetry()
 			_ = cmd.Process.Kill()
 			time.Sleep(backoff)
 		} else {
@@ -251,9 +225,9 @@ func getLogger() *logrus.Logger {
 	logger := logrus.New()
 	logger.Out = os.Stdout
 
-	logger.SetFormatter(&logrus.TextFormatter{
+	logger.SetFormatter(&redactingFormatter{&logrus.TextFormatter{
 		DisableQuote: true,
-	})
+	}})
 
 	if strings.EqualFold(strings.TrimSpace(os.Getenv("DEBUG")), "true") {
 		logger.SetLevel(logrus.DebugLevel)
@@ -262,6 +236,19 @@ func getLogger() *logrus.Logger {
 	return logger
 }
 
+// redactingFormatter removes secrets from formatted log entries, including their fields
+type redactingFormatter struct {
+	logrus.Formatter
+}
+
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	formatted, err := f.Formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(redact.Redact(string(formatted))), nil
+}
+
 func Contains(slice []string, val string) (int, bool) {
 	for i, item := range slice {
 		if item == val {
@@ -18,6 +18,7 @@ import (
 	"github.com/0chain/system_test/internal/api/util/config"
 	"github.com/0chain/system_test/internal/api/util/test"
 	"github.com/0chain/system_test/internal/api/util/wait"
+	"github.com/0chain/system_test/internal/api/util/wallets"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,13 +31,10 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 	t.Logf("ZboxOwner balance: %v", ownerBalance)
 	blobberOwnerBalance := apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
 	t.Logf("Blobber owner balance: %v", blobberOwnerBalance)
-	ownerWallet.Nonce = int(ownerBalance.Nonce)
-	blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+	apiClient.Nonces().Update(ownerWallet, int(ownerBalance.Nonce))
+	apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 
-	testWallet := initialisedWallets[walletIdx]
-	walletIdx++
-	balance := apiClient.GetWalletBalance(t, testWallet, client.HttpOkStatus)
-	testWallet.Nonce = int(balance.Nonce)
+	testWallet := createWallet(t)
 
 	// Stake 6 blobbers, each with 1 token
 	targetBlobbers, resp, err := apiClient.V1SCRestGetFirstBlobbers(t, 6, client.HttpOkStatus)
@@ -50,7 +48,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 
 	// Create the free allocation marker (ownerWallet -> sdkWallet)
 	apiClient.AddFreeStorageAssigner(t, ownerWallet, client.TxSuccessfulStatus) // 0.1 ZCN 1 ZCN = 1e10 from owner wallet
-	marker := config.CreateFreeStorageMarker(t, testWallet.ToSdkWallet(testWallet.Mnemonics), ownerWallet.ToSdkWallet(ownerWalletMnemonics))
+	marker := config.CreateFreeStorageMarker(t, wallets.FromModel(testWallet).ToSdk(), wallets.FromModel(ownerWallet).ToSdk())
 	t.Logf("Free allocation marker: %v", marker)
 
 	t.RunSequentiallyWithTimeout("test multi allocation overall graph data", 10*time.Minute, func(t *test.SystemTest) {
@@ -699,10 +697,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 		t.RunSequentially("endpoint parameters ( test /v2/graph-total-staked )", graphEndpointTestCases(zboxClient.GetGraphTotalStaked))
 
 		t.RunSequentiallyWithTimeout("test graph data ( test /v2/graph-total-staked )", 5*time.Minute, func(t *test.SystemTest) {
-			wallet := initialisedWallets[walletIdx]
-			walletIdx++
-			balance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-			wallet.Nonce = int(balance.Nonce)
+			wallet := createWallet(t)
 
 			PrintBalance(t, ownerWallet, blobberOwnerWallet, wallet)
 			data, resp, err := zboxClient.GetGraphTotalStaked(t, &model.ZboxGraphRequest{DataPoints: "1"})
@@ -949,10 +944,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 		})
 
 		t.RunSequentiallyWithTimeout("test graph data ( test /v2/graph-challenges )", 5*time.Minute, func(t *test.SystemTest) {
-			wallet := initialisedWallets[walletIdx]
-			walletIdx++
-			balance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-			wallet.Nonce = int(balance.Nonce)
+			wallet := createWallet(t)
 
 			sdkClient.SetWallet(t, wallet)
 
@@ -966,7 +958,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 
 			sdkWalletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
 			t.Logf("sdk wallet balance: %v", sdkWalletBalance.Balance)
-			wallet.Nonce = int(sdkWalletBalance.Nonce)
+			apiClient.Nonces().Update(wallet, int(sdkWalletBalance.Nonce))
 
 			// Create an allocation
 			blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
@@ -1047,7 +1039,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 
 		// Ensure blobberOwnerWallet has sufficient balance and updated nonce
 		blobberOwnerBalance := apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		require.GreaterOrEqual(t, blobberOwnerBalance.Balance, int64(200000000), "blobberOwnerWallet must have at least 0.2 ZCN to pay for update transactions (0.1 ZCN value + fees)")
 
 		targetBlobbers[0].Capacity += 10 * 1024 * 1024 * 1024
@@ -1056,7 +1048,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 
 		// Update nonce before second update
 		blobberOwnerBalance = apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		apiClient.UpdateBlobber(t, blobberOwnerWallet, targetBlobbers[1], client.TxSuccessfulStatus)
 
 		// Check increase
@@ -1073,7 +1065,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 		// Decrease them back
 		// Update nonce before first decrease
 		blobberOwnerBalance = apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		require.GreaterOrEqual(t, blobberOwnerBalance.Balance, int64(200000000), "blobberOwnerWallet must have at least 0.2 ZCN to pay for update transactions (0.1 ZCN value + fees)")
 
 		targetBlobbers[0].Capacity -= 10 * 1024 * 1024 * 1024
@@ -1082,7 +1074,7 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 
 		// Update nonce before second decrease
 		blobberOwnerBalance = apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		apiClient.UpdateBlobber(t, blobberOwnerWallet, targetBlobbers[1], client.TxSuccessfulStatus)
 
 		// Check decrease
@@ -1113,15 +1105,12 @@ func Test0boxGraphAndTotalEndpoints(testSetup *testing.T) {
 func Test0boxGraphBlobberEndpoints(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
 
-	testWallet := initialisedWallets[walletIdx]
-	walletIdx++
-	balance := apiClient.GetWalletBalance(t, testWallet, client.HttpOkStatus)
-	testWallet.Nonce = int(balance.Nonce)
+	testWallet := createWallet(t)
 
 	// Faucet the used initialisedWallets
 	blobberOwnerBalance := apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
 	t.Logf("Blobber owner balance: %v", blobberOwnerBalance)
-	blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+	apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 
 	// Stake 6 blobbers, each with 1 token
 	targetBlobbers, resp, err := apiClient.V1SCRestGetFirstBlobbers(t, 6, client.HttpOkStatus)
@@ -1222,7 +1211,7 @@ func Test0boxGraphBlobberEndpoints(testSetup *testing.T) {
 
 		// Ensure blobberOwnerWallet has sufficient balance and updated nonce
 		blobberOwnerBalance := apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		require.GreaterOrEqual(t, blobberOwnerBalance.Balance, int64(200000000), "blobberOwnerWallet must have at least 0.2 ZCN to pay for update transaction (0.1 ZCN value + fees)")
 
 		// Increase write price
@@ -1249,7 +1238,7 @@ func Test0boxGraphBlobberEndpoints(testSetup *testing.T) {
 		// Decrease write price
 		// Update nonce before second update
 		blobberOwnerBalance = apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		require.GreaterOrEqual(t, blobberOwnerBalance.Balance, int64(200000000), "blobberOwnerWallet must have at least 0.2 ZCN to pay for update transaction (0.1 ZCN value + fees)")
 
 		targetBlobber.Terms.WritePrice -= 1000000000
@@ -1286,7 +1275,7 @@ func Test0boxGraphBlobberEndpoints(testSetup *testing.T) {
 
 		// Ensure blobberOwnerWallet has sufficient balance and updated nonce
 		blobberOwnerBalance := apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		require.GreaterOrEqual(t, blobberOwnerBalance.Balance, int64(200000000), "blobberOwnerWallet must have at least 0.2 ZCN to pay for update transaction (0.1 ZCN value + fees)")
 
 		// Increase capacity
@@ -1310,7 +1299,7 @@ func Test0boxGraphBlobberEndpoints(testSetup *testing.T) {
 		// Decrease capacity
 		// Update nonce before second update
 		blobberOwnerBalance = apiClient.GetWalletBalance(t, blobberOwnerWallet, client.HttpOkStatus)
-		blobberOwnerWallet.Nonce = int(blobberOwnerBalance.Nonce)
+		apiClient.Nonces().Update(blobberOwnerWallet, int(blobberOwnerBalance.Nonce))
 		require.GreaterOrEqual(t, blobberOwnerBalance.Balance, int64(200000000), "blobberOwnerWallet must have at least 0.2 ZCN to pay for update transaction (0.1 ZCN value + fees)")
 
 		targetBlobber.Capacity -= 1000000000
@@ -56,7 +56,7 @@ func TestHashnodeRoot(testSetup *testing.T) {
 
 		allocationID := "badallocation"
 
-		blobberUrl := apiClient.HealthyServiceProviders.Blobbers[0]
+		blobberUrl := apiClient.HealthyNodes(client.BlobberServiceProvider)[0]
 
 		sign, err := crypto.SignHashUsingSignatureScheme(crypto.Sha3256([]byte(allocationID)), "bls0chain", []*model.KeyPair{wallet.Keys})
 		require.Nil(t, err)
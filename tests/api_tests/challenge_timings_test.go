@@ -2,6 +2,7 @@ package api_tests
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"math"
 	"net/http"
@@ -14,9 +15,11 @@ import (
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/sdk"
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/challenges"
 	"github.com/0chain/system_test/internal/api/util/client"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,6 +28,9 @@ const (
 	MB       = 1024 * KB // megabyte
 	GB       = 1024 * MB // gigabyte
 	waitTime = 20 * time.Minute
+
+	// minRespondedChallenges is the number of passed challenges timings are computed from
+	minRespondedChallenges = 10
 )
 
 func TestProtocolChallengeTimings(testSetup *testing.T) {
@@ -76,7 +82,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 
 	t.RunWithTimeout("1mb file", 1*time.Hour, func(t *test.SystemTest) {
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
 		t.Log("Blobber Requirements:", blobberRequirements)
@@ -87,7 +93,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 
 		// Update wallet nonce
 		walletBalance = apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 		allocationID := apiClient.CreateAllocationWithLockValue(t, wallet, allocationBlobbers, 10, client.TxSuccessfulStatus)
 
 		alloc, err := sdk.GetAllocation(allocationID)
@@ -97,7 +103,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 		uploadOp := sdkClient.AddUploadOperation(t, "", "", fileSize)
 		sdkClient.MultiOperation(t, allocationID, []sdk.OperationRequest{uploadOp})
 
-		time.Sleep(waitTime)
+		waitForChallengeResponses(t, allocationID)
 
 		result := getChallengeTimings(t, alloc.Blobbers, allocationID)
 
@@ -111,10 +117,8 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 	})
 
 	t.RunWithTimeout("10mb file", 1*time.Hour, func(t *test.SystemTest) {
-		time.Sleep(1 * time.Minute)
-
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
 		blobberRequirements.DataShards = 1
@@ -125,7 +129,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 
 		// Update wallet nonce
 		walletBalance = apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 		allocationBlobbers := apiClient.GetAllocationBlobbers(t, wallet, &blobberRequirements, client.HttpOkStatus)
 		allocationID := apiClient.CreateAllocationWithLockValue(t, wallet, allocationBlobbers, 10, client.TxSuccessfulStatus)
 
@@ -136,7 +140,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 		uploadOp := sdkClient.AddUploadOperation(t, "", "", fileSize)
 		sdkClient.MultiOperation(t, allocationID, []sdk.OperationRequest{uploadOp})
 
-		time.Sleep(waitTime)
+		waitForChallengeResponses(t, allocationID)
 
 		result := getChallengeTimings(t, alloc.Blobbers, allocationID)
 
@@ -150,9 +154,8 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 	})
 
 	t.RunWithTimeout("100mb file", 1*time.Hour, func(t *test.SystemTest) {
-		time.Sleep(2 * time.Minute)
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
 		blobberRequirements.DataShards = 1
@@ -163,7 +166,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 
 		// Update wallet nonce
 		walletBalance = apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 		allocationBlobbers := apiClient.GetAllocationBlobbers(t, wallet, &blobberRequirements, client.HttpOkStatus)
 		allocationID := apiClient.CreateAllocationWithLockValue(t, wallet, allocationBlobbers, 100, client.TxSuccessfulStatus)
 
@@ -174,7 +177,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 		uploadOp := sdkClient.AddUploadOperation(t, "", "", fileSize)
 		sdkClient.MultiOperation(t, allocationID, []sdk.OperationRequest{uploadOp})
 
-		time.Sleep(waitTime)
+		waitForChallengeResponses(t, allocationID)
 
 		result := getChallengeTimings(t, alloc.Blobbers, allocationID)
 
@@ -188,9 +191,8 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 	})
 
 	t.RunWithTimeout("1gb file", 1*time.Hour, func(t *test.SystemTest) {
-		time.Sleep(3 * time.Minute)
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
 		blobberRequirements.DataShards = 1
@@ -201,7 +203,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 
 		// Update wallet nonce
 		walletBalance = apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 		allocationBlobbers := apiClient.GetAllocationBlobbers(t, wallet, &blobberRequirements, client.HttpOkStatus)
 		allocationID := apiClient.CreateAllocationWithLockValue(t, wallet, allocationBlobbers, 500, client.TxSuccessfulStatus)
 
@@ -212,7 +214,7 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 		uploadOp := sdkClient.AddUploadOperation(t, "", "", fileSize)
 		sdkClient.MultiOperation(t, allocationID, []sdk.OperationRequest{uploadOp})
 
-		time.Sleep(waitTime)
+		waitForChallengeResponses(t, allocationID)
 
 		result := getChallengeTimings(t, alloc.Blobbers, allocationID)
 
@@ -226,6 +228,25 @@ func TestProtocolChallengeTimings(testSetup *testing.T) {
 	})
 }
 
+// waitForChallengeResponses waits until the allocation has enough passed challenges and none of its challenges is open,
+// so the next allocation is not challenged while blobbers still answer challenges of this one
+func waitForChallengeResponses(t *test.SystemTest, allocationID string) {
+	tracker := challenges.NewTracker(apiClient, 0, allocationID)
+	_, err := tracker.Wait(t, wait.Config{
+		Timeout:     waitTime,
+		Backoff:     wait.Constant(30 * time.Second),
+		Description: fmt.Sprintf("%d passed challenges of allocation %s", minRespondedChallenges, allocationID),
+	}, func(stats map[string]challenges.BlobberStats) bool {
+		var passed, open int
+		for _, blobberStats := range stats {
+			passed += blobberStats.Passed
+			open += blobberStats.Open
+		}
+		return passed >= minRespondedChallenges && open == 0
+	})
+	require.NoError(t, err)
+}
+
 func getChallengeTimings(t *test.SystemTest, blobbers []*blockchain.StorageNode, allocationID string) []int64 {
 	blobberUrls := make(map[string]string)
 
@@ -237,10 +258,10 @@ func getChallengeTimings(t *test.SystemTest, blobbers []*blockchain.StorageNode,
 	var proofGenTimes, txnSubmissions, txnVerifications []int64
 	var floatProofGenTimes, floatTxnSubmissions, floatTxnVerifications []float64
 
-	challenges := apiClient.GetAllChallengesForAllocation(t, allocationID, client.HttpOkStatus)
+	allocationChallenges := apiClient.GetAllChallengesForAllocation(t, allocationID, client.HttpOkStatus)
 
-	for i := 0; i < len(challenges); i++ {
-		challenge := challenges[i]
+	for i := 0; i < len(allocationChallenges); i++ {
+		challenge := allocationChallenges[i]
 		blobberUrl := blobberUrls[challenge.BlobberID]
 
 		url := blobberUrl + "/challenge-timings-by-challengeId?challenge_id=" + challenge.ChallengeID
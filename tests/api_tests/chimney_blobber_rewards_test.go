@@ -70,8 +70,7 @@ func Test1ChimneyBlobberRewards(testSetup *testing.T) {
 		actualBlockReward   float64
 	)
 
-	sdkWallet := initialisedWallets[walletIdx]
-	walletIdx++
+	sdkWallet := createWallet(t)
 
 	allBlobbers, resp, err := chimneyClient.V1SCRestGetAllBlobbers(t, client.HttpOkStatus)
 	require.NoError(t, err)
@@ -1,6 +1,7 @@
 package api_tests
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/0chain/system_test/internal/api/model"
 	"github.com/0chain/system_test/internal/api/util/client"
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wallets"
 	"github.com/gocolly/colly"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/errgo.v2/errors"
@@ -23,17 +25,15 @@ func TestClientSendNonceGreaterThanFutureNonceLimit(testSetup *testing.T) {
 	t.Skip()
 	t.Parallel()
 
-	wallet1 := initialisedWallets[walletIdx]
-	walletIdx++
+	wallet1 := createWallet(t)
 
-	coreClient.SetWallet(*wallet1.ToZCNCryptoWallet(wallet1.Mnemonics))
+	coreClient.SetWallet(*wallets.FromModel(wallet1).ToZCNCrypto())
 
 	faucetAmount := float64(9)
 	balResp := apiClient.GetWalletBalance(t, wallet1, client.HttpOkStatus)
 	require.EqualValues(t, zcncore.ConvertToValue(faucetAmount), balResp.Balance)
 
-	wallet2 := initialisedWallets[walletIdx]
-	walletIdx++
+	wallet2 := createWallet(t)
 	futureNonce := GetFutureNonceConfig(t)
 	currentNonce := balResp.Nonce
 
@@ -41,18 +41,11 @@ func TestClientSendNonceGreaterThanFutureNonceLimit(testSetup *testing.T) {
 	value := int64(zcncore.ConvertToValue(tokens))
 
 	// Add transactions with nonce + future nonce
-	_, resp, err := apiClient.V1TransactionPutWithNonceAndServiceProviders(
-		t,
-		model.InternalTransactionPutRequest{
-			Wallet:     wallet1,
-			ToClientID: wallet2.Id,
-			Value:      &value,
-			TxnType:    client.SendTxType,
-		},
-		client.HttpBadRequestStatus,
-		int(currentNonce)+futureNonce+1,
-		nil,
-	)
+	_, resp, err := apiClient.NewTransaction(wallet1).
+		To(wallet2.Id).
+		Value(value).
+		Nonce(int(currentNonce)+futureNonce+1).
+		Submit(t, client.HttpBadRequestStatus)
 
 	// Expect error in transaction put
 	require.NoError(t, err)
@@ -63,10 +56,9 @@ func TestClientSendSameNonceForDifferentTransactions(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
 	t.Skip()
 	t.Parallel()
-	wallet1 := initialisedWallets[walletIdx]
-	walletIdx++
+	wallet1 := createWallet(t)
 
-	coreClient.SetWallet(*wallet1.ToZCNCryptoWallet(wallet1.Mnemonics))
+	coreClient.SetWallet(*wallets.FromModel(wallet1).ToZCNCrypto())
 
 	faucetAmount := float64(9)
 	balResp := apiClient.GetWalletBalance(t, wallet1, client.HttpOkStatus)
@@ -79,21 +71,13 @@ func TestClientSendSameNonceForDifferentTransactions(testSetup *testing.T) {
 	transactions := make(map[string]struct{}, numSameTxns)
 	value := int64(1)
 	for i := 0; i < numSameTxns; i++ {
-		wallets[i] = initialisedWallets[walletIdx]
-		walletIdx++
+		wallets[i] = createWallet(t)
 
-		txnResp, _, err := apiClient.V1TransactionPutWithNonceAndServiceProviders(
-			t,
-			model.InternalTransactionPutRequest{
-				Wallet:     wallet1,
-				ToClientID: wallets[i].Id,
-				Value:      &value,
-				TxnType:    client.SendTxType,
-			},
-			client.HttpOkStatus,
-			int(sameNonce),
-			nil,
-		)
+		txnResp, _, err := apiClient.NewTransaction(wallet1).
+			To(wallets[i].Id).
+			Value(value).
+			Nonce(int(sameNonce)).
+			Submit(t, client.HttpOkStatus)
 
 		require.NoError(t, err)
 		transactions[txnResp.Request.Hash] = struct{}{}
@@ -110,47 +94,18 @@ func TestClientSendSameNonceForDifferentTransactions(testSetup *testing.T) {
 		require.True(t, ok, "hash: ", txn, " does not exist in extracted transaction list")
 	}
 
-	wallet2 := initialisedWallets[walletIdx]
-	walletIdx++
+	wallet2 := createWallet(t)
 
-	txnResp, _, err := apiClient.V1TransactionPutWithNonceAndServiceProviders(
-		t,
-		model.InternalTransactionPutRequest{
-			Wallet:     wallet1,
-			ToClientID: wallet2.Id,
-			Value:      &value,
-			TxnType:    client.SendTxType,
-		},
-		client.HttpOkStatus,
-		int(currentNonce+1),
-		nil,
-	)
+	txnResp, _, err := apiClient.NewTransaction(wallet1).
+		To(wallet2.Id).
+		Value(value).
+		Nonce(int(currentNonce+1)).
+		Submit(t, client.HttpOkStatus)
 	require.NoError(t, err)
 
-	var confirmationResp *model.TransactionGetConfirmationResponse
-	transactionTimeOut := GetTransactionTimeOut(t)
-	tm := time.NewTimer(transactionTimeOut)
-L1:
-	for {
-		select {
-		case <-tm.C:
-			break L1
-		default:
-		}
-		confirmationResp, _, err = apiClient.V1TransactionGetConfirmation(
-			t,
-			model.TransactionGetConfirmationRequest{
-				Hash: txnResp.Request.Hash,
-			},
-			client.HttpOkStatus)
-		if err == nil {
-			break
-		}
-	}
-
+	confirmations, err := apiClient.WaitForConfirmations(context.Background(), t, GetTransactionTimeOut(t), txnResp.Request.Hash)
 	require.NoError(t, err)
-	require.NotNil(t, confirmationResp)
-	require.Equal(t, txnResp.Request.Hash, confirmationResp.Transaction.Hash)
+	require.Equal(t, txnResp.Request.Hash, confirmations[0].Transaction.Hash)
 
 	var putError []error
 
@@ -181,33 +136,24 @@ func TestClientSendTransactionToOnlyOneMiner(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
 	t.Skip()
 	t.Parallel()
-	wallet1 := initialisedWallets[walletIdx]
-	walletIdx++
+	wallet1 := createWallet(t)
 
-	coreClient.SetWallet(*wallet1.ToZCNCryptoWallet(wallet1.Mnemonics))
+	coreClient.SetWallet(*wallets.FromModel(wallet1).ToZCNCrypto())
 
 	faucetAmount := float64(9)
 	balResp := apiClient.GetWalletBalance(t, wallet1, client.HttpOkStatus)
 	require.EqualValues(t, zcncore.ConvertToValue(faucetAmount), balResp.Balance)
 	require.GreaterOrEqual(t, len(apiClient.Miners), 1)
 
-	wallet2 := initialisedWallets[walletIdx]
-	walletIdx++
+	wallet2 := createWallet(t)
 
 	value := int64(1)
 	miner := apiClient.Miners[0]
-	txnResp, _, err := apiClient.V1TransactionPutWithNonceAndServiceProviders(
-		t,
-		model.InternalTransactionPutRequest{
-			Wallet:     wallet1,
-			ToClientID: wallet2.Id,
-			Value:      &value,
-			TxnType:    client.SendTxType,
-		},
-		client.HttpOkStatus,
-		0,
-		[]string{miner},
-	)
+	txnResp, _, err := apiClient.NewTransaction(wallet1).
+		To(wallet2.Id).
+		Value(value).
+		Miners(miner).
+		Submit(t, client.HttpOkStatus)
 
 	require.NoError(t, err)
 	time.Sleep(time.Second * 10) // Wait little optimistic time for transaction to get into pool/get-confirmation
@@ -320,10 +320,7 @@ func TestBlobberFileRefs(testSetup *testing.T) {
 	})
 
 	t.RunSequentially("Get file ref with invalid client key should fail", func(t *test.SystemTest) {
-		initialisedWallet := initialisedWallets[walletIdx]
-		walletIdx++
-		balance := apiClient.GetWalletBalance(t, initialisedWallet, client.HttpOkStatus)
-		initialisedWallet.Nonce = int(balance.Nonce)
+		initialisedWallet := createWallet(t)
 
 		sdkClient.SetWallet(t, initialisedWallet)
 
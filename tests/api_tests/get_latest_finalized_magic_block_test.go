@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/0chain/system_test/internal/api/util/client"
 	"github.com/0chain/system_test/internal/api/util/test"
 	"github.com/stretchr/testify/require"
 )
@@ -59,7 +60,7 @@ func TestGetLatestFinalizedMagicBlock(testSetup *testing.T) {
 }
 
 func getCurrentHash(t *test.SystemTest) (string, error) {
-	resp, err := http.Get(apiClient.HealthyServiceProviders.Sharders[0] + "/v1/block/get/latest_finalized_magic_block")
+	resp, err := http.Get(apiClient.HealthyNodes(client.SharderServiceProvider)[0] + "/v1/block/get/latest_finalized_magic_block")
 	require.Nil(t, err)
 	defer resp.Body.Close()
 
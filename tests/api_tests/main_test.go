@@ -2,11 +2,9 @@ package api_tests
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"os"
 	"strconv"
-	"sync"
 	"testing"
 	"time"
 
@@ -16,13 +14,17 @@ import (
 	"github.com/0chain/system_test/internal/api/model"
 	"github.com/0chain/system_test/internal/api/util/client"
 	"github.com/0chain/system_test/internal/api/util/config"
+	"github.com/0chain/system_test/internal/api/util/redact"
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/walletpool"
+	"github.com/0chain/system_test/internal/api/util/wallets"
+	"github.com/0chain/system_test/internal/api/util/zs3"
 	"github.com/stretchr/testify/require"
 )
 
 var (
 	apiClient *client.APIClient
-	zs3Client *client.ZS3Client
+	zs3Client *zs3.Client
 	// sdkClient        *client.SDKClient
 	zboxClient       *client.ZboxClient
 	zvaultClient     *client.ZvaultClient
@@ -36,10 +38,9 @@ var (
 	blobberOwnerWallet          *model.Wallet
 	blobberOwnerWalletMnemonics string
 	parsedConfig                *config.Config
+	healthMonitor               *client.HealthMonitor
 
-	initialisedWallets []*model.Wallet
-	walletIdx          int64
-	walletMutex        sync.Mutex
+	walletPool *walletpool.Pool
 )
 
 func TestMain(m *testing.M) {
@@ -50,8 +51,13 @@ func TestMain(m *testing.M) {
 	}
 
 	parsedConfig = config.Parse(configPath)
+	redact.AddSecret(parsedConfig.S3AccessKey, parsedConfig.S3SecretKey, parsedConfig.DropboxAccessToken, parsedConfig.GdriveAccessToken)
 	apiClient = client.NewAPIClient(parsedConfig.BlockWorker)
-	zs3Client = client.NewZS3Client(parsedConfig.ZS3ServerUrl)
+	var err error
+	zs3Client, err = zs3.NewClient(parsedConfig.ZS3ServerUrl, zs3.DefaultAccessKey, zs3.DefaultSecretKey)
+	if err != nil {
+		log.Fatalf("zs3 client could not be created: %v", err)
+	}
 	zboxClient = client.NewZboxClient(parsedConfig.ZboxUrl)
 	zvaultClient = client.NewZvaultClient(parsedConfig.ZvaultUrl)
 	zauthClient = client.NewZauthClient(parsedConfig.ZauthUrl)
@@ -69,6 +75,30 @@ func TestMain(m *testing.M) {
 		log.Printf("Default test case timeout is [%v]", test.DefaultTestTimeout)
 	}
 
+	if parsedConfig.NodeRequestTimeout != "" {
+		var nodeRequestTimeout time.Duration
+		nodeRequestTimeout, err = time.ParseDuration(parsedConfig.NodeRequestTimeout)
+		if err != nil {
+			log.Printf("Node request timeout could not be parsed so requests are not limited")
+		} else {
+			apiClient.NodeTimeout = nodeRequestTimeout
+			chimneyClient.NodeTimeout = nodeRequestTimeout
+		}
+	}
+	apiClient.Quorum = parsedConfig.ExecutionQuorum
+	chimneyClient.Quorum = parsedConfig.ExecutionQuorum
+
+	if parsedConfig.HealthCheckInterval != "" {
+		var healthCheckInterval time.Duration
+		healthCheckInterval, err = time.ParseDuration(parsedConfig.HealthCheckInterval)
+		if err != nil {
+			log.Printf("Health check interval could not be parsed so nodes are not re-checked")
+		} else {
+			healthMonitor = apiClient.StartHealthMonitor(healthCheckInterval)
+			log.Printf("Nodes are re-checked every [%v]", healthCheckInterval)
+		}
+	}
+
 	t := test.NewSystemTest(new(testing.T))
 
 	err = coreClient.Init(context.Background(), conf.Config{
@@ -88,109 +118,58 @@ func TestMain(m *testing.M) {
 	ownerWalletMnemonics = parsedConfig.OwnerWalletMnemonics
 	ownerWallet = apiClient.CreateWalletForMnemonic(t, ownerWalletMnemonics)
 
-	// Read the content of the file
-	fileContent, err := os.ReadFile("./config/wallets.json")
+	fileWallets, err := wallets.LoadList("./config/wallets.json")
 	if err != nil {
-		log.Println("Error reading file:", err)
+		log.Println("Error reading wallets:", err)
 		return
 	}
 
-	fileWallets := []WalletFile{}
-
-	// Parse the JSON data into a list of strings
-	err = json.Unmarshal(fileContent, &fileWallets)
-	if err != nil {
-		log.Println("Error decoding JSON:", err)
-		return
-	}
-
-	for i := range fileWallets {
-		wallet := fileWallets[i]
-		initialisedWallet := &model.Wallet{
-			Id:        wallet.ClientId,
-			Version:   wallet.Version,
-			PublicKey: wallet.Keys[0].PublicKey,
-			Nonce:     0,
-			Keys:      &model.KeyPair{},
-			Mnemonics: wallet.Mnemonics,
-		}
-
-		err := initialisedWallet.Keys.PublicKey.DeserializeHexStr(wallet.Keys[0].PublicKey)
-		if err != nil {
-			log.Println("Error decoding JSON:", err)
-		}
-		err = initialisedWallet.Keys.PrivateKey.DeserializeHexStr(wallet.Keys[0].PrivateKey)
+	initialisedWallets := make([]*model.Wallet, 0, len(fileWallets))
+	for _, fileWallet := range fileWallets {
+		initialisedWallet, err := fileWallet.ToModel()
 		if err != nil {
-			log.Println("Error decoding JSON:", err)
+			log.Println("Error loading wallet keys:", err)
+			continue
 		}
 
 		initialisedWallets = append(initialisedWallets, initialisedWallet)
 	}
+	walletPool = walletpool.New(t, &walletpool.APIFunder{Client: apiClient}, walletpool.Config{}, initialisedWallets...)
 
-	os.Exit(m.Run())
+	if err := test.NewReporterFromEnv("api_tests"); err != nil {
+		log.Printf("Test report could not be created: %v", err)
+	}
+
+	code := m.Run()
+	walletPool.Close()
+	if healthMonitor != nil {
+		healthMonitor.Stop()
+	}
+	if test.DefaultReporter != nil {
+		if err := test.DefaultReporter.Close(); err != nil {
+			log.Printf("Test report could not be written: %v", err)
+		}
+	}
+	os.Exit(code)
 }
 
 func initialiseSCWallet() *model.Wallet {
-	// read the file sc_owner_wallet.json
-	fileContent, err := os.ReadFile("./config/sc_owner_wallet.json")
+	fileWallet, err := wallets.Load("./config/sc_owner_wallet.json")
 	if err != nil {
-		log.Println("Error reading file:", err)
+		log.Println("Error reading wallet:", err)
 		return nil
 	}
 
-	fileWallet := WalletFile{}
-
-	// Parse the JSON data into a list of strings
-	err = json.Unmarshal(fileContent, &fileWallet)
-
+	wallet, err := fileWallet.ToModel()
 	if err != nil {
-		log.Println("Error decoding JSON:", err)
+		log.Println("Error loading wallet keys:", err)
 		return nil
 	}
 
-	wallet := &model.Wallet{
-		Id:        fileWallet.ClientId,
-		Version:   fileWallet.Version,
-		PublicKey: fileWallet.Keys[0].PublicKey,
-		Nonce:     0,
-		Keys:      &model.KeyPair{},
-		Mnemonics: fileWallet.Mnemonics,
-	}
-
-	err = wallet.Keys.PublicKey.DeserializeHexStr(fileWallet.Keys[0].PublicKey)
-	if err != nil {
-		log.Println("Error decoding JSON:", err)
-	}
-	err = wallet.Keys.PrivateKey.DeserializeHexStr(fileWallet.Keys[0].PrivateKey)
-	if err != nil {
-		log.Println("Error decoding JSON:", err)
-	}
-
 	return wallet
 }
 
-type WalletFile struct {
-	ClientId  string `json:"client_id"`
-	ClientKey string `json:"client_key"`
-	Keys      []struct {
-		PublicKey  string `json:"public_key"`
-		PrivateKey string `json:"private_key"`
-	} `json:"keys"`
-	Mnemonics       string      `json:"mnemonics"`
-	Version         string      `json:"version"`
-	DateCreated     time.Time   `json:"date_created"`
-	Nonce           int         `json:"nonce"`
-	ChainID         string      `json:"ChainID"`
-	SignatureScheme interface{} `json:"SignatureScheme"`
-}
-
+// createWallet leases a funded wallet with synced nonce, it is returned to the pool when the test finishes
 func createWallet(t *test.SystemTest) *model.Wallet {
-	walletMutex.Lock()
-	wallet := initialisedWallets[walletIdx]
-	walletIdx++
-	balance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-	wallet.Nonce = int(balance.Nonce)
-	walletMutex.Unlock()
-
-	return wallet
+	return walletPool.Lease(t)
 }
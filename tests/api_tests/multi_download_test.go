@@ -2,12 +2,15 @@ package api_tests
 
 import (
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/0chain/gosdk/zboxcore/sdk"
 	"github.com/0chain/system_test/internal/api/model"
 	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/datagen"
 	"github.com/0chain/system_test/internal/api/util/test"
 	"github.com/stretchr/testify/require"
 )
@@ -43,10 +46,11 @@ func TestMultiDownload(testSetup *testing.T) {
 			require.NoError(t, err, "error removing temp dir")
 		}()
 		wg := &sync.WaitGroup{}
+		statusBars := make([]*client.StatusCallback, 0, len(ops))
 		for i := 0; i < 9; i++ {
-			sdkClient.DownloadFileWithParam(t, alloc, ops[i].FileMeta.RemotePath, "temp_download/", wg, false)
+			statusBars = append(statusBars, sdkClient.DownloadFileWithParam(t, alloc, ops[i].FileMeta.RemotePath, "temp_download/", wg, false))
 		}
-		sdkClient.DownloadFileWithParam(t, alloc, ops[9].FileMeta.RemotePath, "temp_download/", wg, true)
+		statusBars = append(statusBars, sdkClient.DownloadFileWithParam(t, alloc, ops[9].FileMeta.RemotePath, "temp_download/", wg, true))
 		wg.Wait()
 		files, err := os.ReadDir("temp_download")
 		require.NoError(t, err, "error reading temp dir")
@@ -56,5 +60,23 @@ func TestMultiDownload(testSetup *testing.T) {
 			require.NoError(t, err, "error getting file info")
 			require.Equal(t, int64(1024), sz.Size(), "file size mismatch expected %v actual %v", 1024, sz.Size())
 		}
+		for _, op := range ops {
+			source := op.FileReader.(*datagen.Source)
+			require.NoError(t, source.VerifyFile(filepath.Join("temp_download", op.FileMeta.RemoteName)))
+		}
+
+		for i, statusBar := range statusBars {
+			metrics := statusBar.Metrics()
+			t.AddMetrics("download", metrics)
+			require.Empty(t, metrics.Errors)
+			require.Len(t, metrics.Operations, 1)
+
+			download := metrics.Operations[0]
+			require.Equal(t, ops[i].FileMeta.RemotePath, download.FilePath)
+			require.Equal(t, int64(1024), download.CompletedBytes)
+			require.LessOrEqual(t, download.TimeToFirstByte(), download.Duration())
+			require.Less(t, download.Duration(), time.Minute, "download of [%s] is too slow", download.FilePath)
+			t.Logf("Downloaded [%s] in %v, first byte after %v", download.FilePath, download.Duration(), download.TimeToFirstByte())
+		}
 	})
 }
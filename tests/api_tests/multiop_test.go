@@ -281,6 +281,58 @@ func TestMultiOperation(testSetup *testing.T) {
 		listResult = sdkClient.GetFileList(t, allocationID, "/rename/nested")
 		require.Equal(t, 1, len(listResult.Children), "files count mismatch expected %v actual %v", 1, len(listResult.Children))
 	})
+
+	t.RunSequentially("Rename onto an existing file should fail", func(t *test.SystemTest) {
+		wallet := createWallet(t)
+
+		sdkClient.SetWallet(t, wallet)
+
+		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
+		allocationBlobbers := apiClient.GetAllocationBlobbers(t, wallet, &blobberRequirements, client.HttpOkStatus)
+		allocationID := apiClient.CreateAllocation(t, wallet, allocationBlobbers, client.TxSuccessfulStatus)
+
+		first := sdkClient.AddUploadOperation(t, "", "")
+		second := sdkClient.AddUploadOperation(t, "", "")
+		sdkClient.MultiOperation(t, allocationID, []sdk.OperationRequest{first, second})
+
+		renameOp := sdkClient.AddRenameOperation(t, allocationID, first.FileMeta.RemotePath, second.FileMeta.RemoteName)
+		_, err := sdkClient.MultiOperationWithoutAssertion(t, allocationID, []sdk.OperationRequest{renameOp})
+
+		var sdkErr *client.SDKError
+		require.ErrorAs(t, err, &sdkErr)
+		require.Equal(t, allocationID, sdkErr.AllocationID)
+		require.Len(t, sdkErr.Blobbers, int(blobberRequirements.DataShards+blobberRequirements.ParityShards))
+		require.Equal(t, "rename_failed", sdkErr.Code)
+		// gosdk reports blobber status only for uploads
+		require.Empty(t, sdkErr.FailedBlobbers())
+
+		listResult := sdkClient.GetFileList(t, allocationID, "/")
+		require.Equal(t, 2, len(listResult.Children), "files count mismatch expected %v actual %v", 2, len(listResult.Children))
+	})
+
+	t.RunSequentially("Upload exceeding allocation size should fail", func(t *test.SystemTest) {
+		wallet := createWallet(t)
+
+		sdkClient.SetWallet(t, wallet)
+
+		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
+		allocationBlobbers := apiClient.GetAllocationBlobbers(t, wallet, &blobberRequirements, client.HttpOkStatus)
+		allocationID := apiClient.CreateAllocation(t, wallet, allocationBlobbers, client.TxSuccessfulStatus)
+
+		op := sdkClient.AddUploadOperation(t, "", "", 2*blobberRequirements.Size)
+		_, err := sdkClient.MultiOperationWithoutAssertion(t, allocationID, []sdk.OperationRequest{op})
+
+		var sdkErr *client.SDKError
+		require.ErrorAs(t, err, &sdkErr)
+		require.NotEmpty(t, sdkErr.FailedFiles())
+		for _, status := range sdkErr.FailedFiles() {
+			require.Equal(t, op.FileMeta.RemotePath, status.FilePath)
+			require.Error(t, status.Err)
+		}
+
+		listResult := sdkClient.GetFileList(t, allocationID, "/")
+		require.Empty(t, listResult.Children)
+	})
 }
 
 func randName() string {
@@ -295,10 +347,7 @@ func randName() string {
 }
 
 func createAllocationAndPerformMultiOperation(t *test.SystemTest, allocSize int64, filesCount, expectedFilesCount int, fileWithFormats bool, fileSizes []int64, secondaryOperation string) {
-	wallet := initialisedWallets[walletIdx]
-	walletIdx++
-	balance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-	wallet.Nonce = int(balance.Nonce)
+	wallet := createWallet(t)
 	sdkClient.SetWallet(t, wallet)
 
 	blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
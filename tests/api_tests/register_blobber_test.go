@@ -25,7 +25,7 @@ func TestRegisterBlobber(testSetup *testing.T) {
 
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
 		t.Logf("wallet balance: %v", wallet)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		sn := &model.StorageNode{}
 
@@ -52,7 +52,7 @@ func TestRegisterBlobber(testSetup *testing.T) {
 
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
 		t.Logf("wallet balance: %v", wallet)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		sn := &model.StorageNode{}
 		sn.ID = uuid.New().String()
@@ -74,7 +74,7 @@ func TestRegisterBlobber(testSetup *testing.T) {
 
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
 		t.Logf("wallet balance: %v", wallet)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		sn := &model.StorageNode{}
 		sn.ID = uuid.New().String()
@@ -96,7 +96,7 @@ func TestRegisterBlobber(testSetup *testing.T) {
 
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
 		t.Logf("wallet balance: %v", wallet)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		sn := &model.StorageNode{}
 		sn.ID = uuid.New().String()
@@ -118,7 +118,7 @@ func TestRegisterBlobber(testSetup *testing.T) {
 
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
 		t.Logf("wallet balance: %v", wallet)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		sn := &model.StorageNode{}
 		sn.ID = uuid.New().String()
@@ -142,7 +142,7 @@ func TestRegisterBlobber(testSetup *testing.T) {
 
 		walletBalance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
 		t.Logf("wallet balance: %v", wallet)
-		wallet.Nonce = int(walletBalance.Nonce)
+		apiClient.Nonces().Update(wallet, int(walletBalance.Nonce))
 
 		sn := &model.StorageNode{}
 		sn.ID = uuid.New().String()
@@ -187,7 +187,7 @@ func killBlobber(t *test.SystemTest, providerId string) {
 
 	// get wallet balance
 	walletBalance := apiClient.GetWalletBalance(t, scWallet, client.HttpOkStatus)
-	scWallet.Nonce = int(walletBalance.Nonce)
+	apiClient.Nonces().Update(scWallet, int(walletBalance.Nonce))
 
 	apiClient.KillBlobber(t, scWallet, killBlobberReq, 1)
 }
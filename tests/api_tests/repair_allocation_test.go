@@ -38,10 +38,7 @@ func filterValidBlobbers(blobbers []*blockchain.StorageNode, minRequired int) []
 func TestRepairAllocation(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
 
-	wallet := initialisedWallets[walletIdx]
-	walletIdx++
-	balance := apiClient.GetWalletBalance(t, wallet, client.HttpOkStatus)
-	wallet.Nonce = int(balance.Nonce)
+	wallet := createWallet(t)
 
 	sdkClient.SetWallet(t, wallet)
 
@@ -93,7 +90,9 @@ func TestRepairAllocation(testSetup *testing.T) {
 		validBlobbers := filterValidBlobbers(alloc.Blobbers, int(blobberRequirements.DataShards))
 		sdkClient.MultiOperation(t, allocationID, ops, client.WithRepair(validBlobbers))
 
-		sdkClient.RepairAllocation(t, allocationID)
+		metrics := sdkClient.RepairAllocation(t, allocationID)
+		require.GreaterOrEqual(t, metrics.FilesRepaired, len(ops))
+		require.Empty(t, metrics.Errors)
 		for _, op := range ops {
 			_, err = sdk.GetFileRefFromBlobber(allocationID, lastBlobber.ID, op.RemotePath)
 			require.Nil(t, err)
@@ -0,0 +1,51 @@
+package api_tests
+
+import (
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharderStateDivergence(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	t.SetSmokeTests("All sharders should agree on wallet balance")
+
+	t.Parallel()
+
+	t.Run("All sharders should agree on wallet balance", func(t *test.SystemTest) {
+		wallet := createWallet(t)
+
+		comparison, err := apiClient.CompareBalance(t, wallet.Id)
+		require.Nil(t, err)
+		require.Len(t, comparison.Sharders, len(apiClient.HealthyNodes(client.SharderServiceProvider)))
+		require.False(t, comparison.Diverged(), comparison.String())
+	})
+
+	t.Run("All sharders should agree on faucet smart contract state", func(t *test.SystemTest) {
+		// global node of the faucet is stored under its address repeated twice
+		globalNodeKey := client.FaucetSmartContractAddress + client.FaucetSmartContractAddress
+
+		comparison, err := apiClient.CompareSCState(t, client.FaucetSmartContractAddress, globalNodeKey)
+		require.Nil(t, err)
+		for _, sharder := range comparison.Sharders {
+			require.Equal(t, client.HttpOkStatus, sharder.StatusCode, "faucet global node is not served by %s", sharder.URL)
+		}
+		require.False(t, comparison.Diverged(), comparison.String())
+	})
+
+	t.Run("All sharders should agree on newly created allocation", func(t *test.SystemTest) {
+		wallet := createWallet(t)
+
+		blobberRequirements := model.DefaultBlobberRequirements(wallet.Id, wallet.PublicKey)
+		allocationBlobbers := apiClient.GetAllocationBlobbers(t, wallet, &blobberRequirements, client.HttpOkStatus)
+		allocationID := apiClient.CreateAllocation(t, wallet, allocationBlobbers, client.TxSuccessfulStatus)
+
+		comparison, err := apiClient.CompareAllocation(t, allocationID)
+		require.Nil(t, err)
+		require.Greater(t, comparison.Round, int64(0))
+		require.False(t, comparison.Diverged(), comparison.String())
+	})
+}
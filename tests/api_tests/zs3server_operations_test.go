@@ -1,239 +1,122 @@
 package api_tests
 
 import (
+	"bytes"
+	"net/http"
+	"os"
 	"testing"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/zs3"
 	"github.com/stretchr/testify/require"
 )
 
-const (
-	AccessKey       = "rootroot"
-	SecretAccessKey = "rootroot"
-)
+const zs3Bucket = "system-test"
 
 func TestZs3ServerOperations(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
 	t.Parallel()
-	// FIXME: we should never return a 500 to the end user
-
-	t.SetSmokeTests("CreateBucket should return 200 when all the parameters are correct",
-		"ListBucket should return 200 all the parameter are correct",
-		"ListObjects should return 200 all the parameter are correct",
-		"PutObjects should return 200 all the parameter are correct",
-		"GetObjects should return 200 all the parameter are correct",
-		"RemoveObject should return 200 all the parameter are correct")
-
-	t.RunSequentially("Zs3 server should return 500 when the action doesn't exist", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "random-action",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 500, resp.StatusCode())
-	})
 
-	t.RunSequentially("zs3 server should return 500 when the credentials aren't correct", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       "wrong-access-key",
-			"secretAccessKey": SecretAccessKey,
-			"action":          "createBucket",
-			"bucketName":      "test",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		// Nginx returns 401 for invalid credentials before the request reaches zs3server
-		// Accept both 401 (nginx rejection) and 500 (zs3server error) as valid responses
-		require.Contains(t, []int{401, 500}, resp.StatusCode(), "Expected 401 (nginx) or 500 (zs3server) for invalid credentials, got %d", resp.StatusCode())
+	t.SetSmokeTests("CreateBucket should succeed when the credentials are correct",
+		"ListBuckets should return the created bucket",
+		"PutObject should store the object",
+		"GetObject should return content of the object",
+		"ListObjects should return the stored objects",
+		"DeleteObject should remove the object")
+
+	content, err := os.ReadFile("test-file.txt")
+	require.NoError(t, err)
+
+	t.RunSequentially("Requests should be rejected when the credentials aren't correct", func(t *test.SystemTest) {
+		wrongKey, err := zs3.NewClient(parsedConfig.ZS3ServerUrl, "wrong-access-key", zs3.DefaultSecretKey)
+		require.NoError(t, err)
+		err = wrongKey.CreateBucket(t, zs3Bucket)
+		require.Error(t, err)
+		// Nginx may reject the request with 401 before it reaches zs3server
+		require.Contains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, zs3.StatusCode(err))
+
+		wrongSecret, err := zs3.NewClient(parsedConfig.ZS3ServerUrl, zs3.DefaultAccessKey, "wrong-secret-key")
+		require.NoError(t, err)
+		_, err = wrongSecret.ListBuckets(t)
+		require.Error(t, err)
+		require.Contains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, zs3.StatusCode(err))
 	})
 
-	t.RunSequentially("CreateBucket should return 200 when all the parameters are correct", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "createBucket",
-			"bucketName":      "system-test",
+	t.RunSequentially("CreateBucket should succeed when the credentials are correct", func(t *test.SystemTest) {
+		err := zs3Client.CreateBucket(t, zs3Bucket)
+		// the bucket is kept between runs
+		if err != nil {
+			require.Contains(t, []string{"BucketAlreadyOwnedByYou", "BucketAlreadyExists"}, zs3.ErrorCode(err))
 		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
 	})
 
-	t.RunSequentially("CreateBucket should not return error when bucket name already exist", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "createBucket",
-			"bucketName":      "system-test",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
+	t.RunSequentially("ListBuckets should return the created bucket", func(t *test.SystemTest) {
+		buckets, err := zs3Client.ListBuckets(t)
+		require.NoError(t, err)
+		require.Contains(t, buckets, zs3Bucket)
 	})
 
-	t.RunSequentially("ListBucket should return 200 all the parameter are correct", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "listBuckets",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
-	})
+	t.RunSequentially("PutObject should store the object", func(t *test.SystemTest) {
+		etag, err := zs3Client.PutObject(t, zs3Bucket, "test-file.txt", bytes.NewReader(content))
+		require.NoError(t, err)
+		require.NotEmpty(t, etag)
 
-	t.RunSequentially("ListObjects should return 200 all the parameter are correct", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "listObjects",
-			"bucketName":      "system-test",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
+		object, err := zs3Client.HeadObject(t, zs3Bucket, "test-file.txt")
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), object.Size)
 	})
 
-	t.RunSequentially("PutObjects should return 200 all the parameter are correct", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "createBucket",
-			"bucketName":      "system-test",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
-
-		queryParams = map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "putObject",
-			"bucketName":      "system-test",
-		}
-		formData := map[string]string{
-			"file": "@test-file.txt",
-		}
-		resp, err = zs3Client.Zs3ServerRequest(t, queryParams, formData)
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
+	t.RunSequentially("GetObject should return content of the object", func(t *test.SystemTest) {
+		got, err := zs3Client.GetObject(t, zs3Bucket, "test-file.txt")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
 	})
 
-	t.RunSequentially("GetObjects should return 200 all the parameter are correct", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "getObject",
-			"bucketName":      "system-test",
-			"objectName":      "test-file.txt",
-		}
-		formData := map[string]string{
-			"file": "@test-file.txt",
-		}
-		resp, err := zs3Client.Zs3ServerRequest(t, queryParams, formData)
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
+	t.RunSequentially("Ranged GetObject should return part of the object", func(t *test.SystemTest) {
+		got, err := zs3Client.GetObjectRange(t, zs3Bucket, "test-file.txt", 1, 2)
+		require.NoError(t, err)
+		require.Equal(t, content[1:3], got)
 	})
 
-	t.RunSequentially("PutObjects should return error when buckcet name does not exist", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "putObject",
-			"bucketName":      "This bucket doesnot exist",
-		}
-		formData := map[string]string{
-			"file": "@test-file.txt",
-		}
-		resp, err := zs3Client.Zs3ServerRequest(t, queryParams, formData)
-		require.Nil(t, err)
-		require.Equal(t, 500, resp.StatusCode())
-		require.Equal(t, `{"error":"Bucket name contains invalid characters"}`, resp.String())
+	t.RunSequentially("ListObjects should return the stored objects", func(t *test.SystemTest) {
+		objects, err := zs3Client.ListObjects(t, zs3Bucket, zs3.ListOptions{Prefix: "test-file", MaxKeys: 1})
+		require.NoError(t, err)
+		require.NotEmpty(t, objects.Objects)
+		require.Equal(t, "test-file.txt", objects.Objects[0].Key)
 	})
 
-	t.RunSequentially("RemoveObject should return 200 all the parameter are correct", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "createBucket",
-			"bucketName":      "system-test",
-			"objectName":      "bucket created as a part of " + t.Name(),
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
-		queryParams = map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "removeObject",
-			"bucketName":      "system-test",
-			"objectName":      "bucket created as a part of " + t.Name(),
-		}
-		resp, err = zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
+	t.RunSequentially("Multipart upload should store the object", func(t *test.SystemTest) {
+		multipartContent := bytes.Repeat(content, zs3.MinPartSize/len(content)+1)
+		_, err := zs3Client.MultipartUpload(t, zs3Bucket, "multipart-file.txt", bytes.NewReader(multipartContent), zs3.MinPartSize)
+		require.NoError(t, err)
+
+		object, err := zs3Client.HeadObject(t, zs3Bucket, "multipart-file.txt")
+		require.NoError(t, err)
+		require.Equal(t, int64(len(multipartContent)), object.Size)
+
+		require.NoError(t, zs3Client.DeleteObject(t, zs3Bucket, "multipart-file.txt"))
 	})
 
-	t.RunSequentially("RemoveObject should not return error if object doen't exist", func(t *test.SystemTest) {
-		// Ensure the bucket exists first
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "createBucket",
-			"bucketName":      "system-test",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
-
-		// Now try to remove a non-existent object
-		queryParams = map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "removeObject",
-			"bucketName":      "system-test",
-			"objectName":      "file name created as a part of " + t.Name(),
-		}
-		resp, err = zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 200, resp.StatusCode())
+	t.RunSequentially("PutObject should fail when the bucket does not exist", func(t *test.SystemTest) {
+		_, err := zs3Client.PutObject(t, "missing-"+zs3Bucket, "test-file.txt", bytes.NewReader(content))
+		require.Error(t, err)
+		require.Equal(t, "NoSuchBucket", zs3.ErrorCode(err))
 	})
 
-	// FIXME - this should be 400 not 500
-	t.Run("CreateBucket should return 500 when one of more required parameters are missing", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "createBucket",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 500, resp.StatusCode())
+	t.RunSequentially("DeleteObject should remove the object", func(t *test.SystemTest) {
+		require.NoError(t, zs3Client.DeleteObject(t, zs3Bucket, "test-file.txt"))
+
+		_, err := zs3Client.HeadObject(t, zs3Bucket, "test-file.txt")
+		require.Equal(t, http.StatusNotFound, zs3.StatusCode(err))
 	})
 
-	t.Run("ListBuckets should return 500 when one of more required parameters are missing", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"secretAccessKey": SecretAccessKey,
-			"action":          "listBucket",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		require.Equal(t, 500, resp.StatusCode())
+	t.RunSequentially("DeleteObject should not return error if object doesn't exist", func(t *test.SystemTest) {
+		require.NoError(t, zs3Client.DeleteObject(t, zs3Bucket, "file name created as a part of "+t.Name()))
 	})
 
-	t.Run("listObjects should return 500 when trying to list objects from un existing bucket", func(t *test.SystemTest) {
-		queryParams := map[string]string{
-			"accessKey":       AccessKey,
-			"secretAccessKey": SecretAccessKey,
-			"action":          "listObjects",
-			"bucketName":      "random-bucket",
-		}
-		resp, err := zs3Client.BucketOperation(t, queryParams, map[string]string{})
-		require.Nil(t, err)
-		// Nginx may return 401 for non-existent buckets before the request reaches zs3server
-		// Accept both 401 (nginx rejection) and 500 (zs3server error) as valid responses
-		require.Contains(t, []int{401, 500}, resp.StatusCode(), "Expected 401 (nginx) or 500 (zs3server) for non-existent bucket, got %d", resp.StatusCode())
+	t.RunSequentially("ListObjects should fail when the bucket does not exist", func(t *test.SystemTest) {
+		_, err := zs3Client.ListObjects(t, "random-bucket", zs3.ListOptions{})
+		require.Error(t, err)
+		require.Equal(t, "NoSuchBucket", zs3.ErrorCode(err))
 	})
 }
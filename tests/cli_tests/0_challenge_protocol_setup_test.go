@@ -0,0 +1,230 @@
+package cli_tests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/fixtures"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const challengeScenario = "challenge"
+
+// Contains files with fixture ids written by the CI action which provisions the challenge scenario
+const (
+	legacyChallengeAllocations = "challenge_allocations.txt"
+	legacyChallengeBlobbers    = "challenge_blobbers.txt"
+)
+
+// Contains names of fixtures of the challenge scenario
+const (
+	challengeOwnerWallet = "owner"
+
+	allocationWithWrites           = "writes"
+	allocationWithWritesAndDeletes = "writes_and_deletes"
+	emptyAllocation                = "empty"
+	allocationWithAddedBlobber     = "added_blobber"
+	allocationWithReplacedBlobber  = "replaced_blobber"
+	canceledAllocation             = "canceled"
+
+	addedBlobber       = "added"
+	replacementBlobber = "replacement"
+	replacedBlobber    = "replaced"
+)
+
+// challengeFixturesPath returns path of the challenge fixture registry, it can be set by CHALLENGE_FIXTURES
+func challengeFixturesPath() string {
+	if path := os.Getenv("CHALLENGE_FIXTURES"); path != "" {
+		return path
+	}
+	return "challenge_fixtures.json"
+}
+
+// TestProtocolChallengeSetup provisions allocations for TestProtocolChallenge, which needs them to be challenged
+// for some time before it runs. It only runs when CHALLENGE_FIXTURES_SETUP is true.
+func TestProtocolChallengeSetup(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	if !strings.EqualFold(strings.TrimSpace(os.Getenv("CHALLENGE_FIXTURES_SETUP")), "true") {
+		t.Skip("CHALLENGE_FIXTURES_SETUP is not set")
+	}
+
+	registry := fixtures.New(challengeScenario)
+	walletName := escapedTestName(t)
+	wd, _ := os.Getwd()
+	walletFile := filepath.Join(wd, "config", walletName+"_wallet.json")
+	configFile := filepath.Join(wd, "config", configPath)
+
+	newAllocation := func(name string) string {
+		allocationID := setupAllocation(t, configPath, map[string]interface{}{
+			"size": 10 * MB,
+			"lock": 9,
+		})
+		var blobbers []string
+		for _, blobber := range getAllocation(t, allocationID).Blobbers {
+			blobbers = append(blobbers, blobber.ID)
+		}
+		require.NoError(t, registry.AddAllocation(name, challengeOwnerWallet, allocationID, blobbers))
+		return allocationID
+	}
+
+	record := func(name string, operation fixtures.Operation) {
+		operation.Round = getLatestFinalizedBlock(t).Round
+		require.NoError(t, registry.Record(name, operation))
+	}
+
+	write := func(name, allocationID string) string {
+		filesize := int64(2 * MB)
+		filename := generateRandomTestFileName(t)
+		require.NoError(t, createFileWithSize(filename, filesize))
+
+		remotepath := "/dir/" + filepath.Base(filename)
+		output, err := uploadFile(t, configPath, map[string]interface{}{
+			"allocation": allocationID,
+			"remotepath": remotepath,
+			"localpath":  filename,
+		}, true)
+		require.Nil(t, err, "error uploading file", strings.Join(output, "\n"))
+
+		record(name, fixtures.Operation{Kind: fixtures.Write, Path: remotepath, Size: filesize})
+		return remotepath
+	}
+
+	updateBlobbers := func(name, allocationID, add, remove string) {
+		params := map[string]interface{}{
+			"allocation":  allocationID,
+			"add_blobber": add,
+		}
+		operation := fixtures.Operation{Kind: fixtures.AddBlobber, Blobber: add}
+		if remove != "" {
+			params["remove_blobber"] = remove
+			operation = fixtures.Operation{Kind: fixtures.ReplaceBlobber, Blobber: add, Replaced: remove}
+		}
+		output, err := updateAllocation(t, configPath, createParams(params), true)
+		require.Nil(t, err, "error updating allocation", strings.Join(output, "\n"))
+		record(name, operation)
+	}
+
+	// the wallet is created together with the first allocation
+	allocationID := newAllocation(allocationWithWrites)
+	wallet, err := getWalletForName(t, configPath, walletName)
+	require.Nil(t, err, "error getting wallet")
+	registry.Wallets[challengeOwnerWallet] = fixtures.Wallet{ClientID: wallet.ClientID, PublicKey: wallet.ClientPublicKey}
+	write(allocationWithWrites, allocationID)
+
+	allocationID = newAllocation(allocationWithWritesAndDeletes)
+	remotepath := write(allocationWithWritesAndDeletes, allocationID)
+	output, err := deleteFile(t, walletName, createParams(map[string]interface{}{
+		"allocation": allocationID,
+		"remotepath": remotepath,
+	}), true)
+	require.Nil(t, err, "error deleting file", strings.Join(output, "\n"))
+	record(allocationWithWritesAndDeletes, fixtures.Operation{Kind: fixtures.Delete, Path: remotepath})
+
+	newAllocation(emptyAllocation)
+
+	allocationID = newAllocation(allocationWithAddedBlobber)
+	write(allocationWithAddedBlobber, allocationID)
+	added, err := GetBlobberIDNotPartOfAllocation(walletFile, configFile, allocationID)
+	require.Nil(t, err, "error getting blobber to add")
+	updateBlobbers(allocationWithAddedBlobber, allocationID, added, "")
+	registry.Blobbers[addedBlobber] = fixtures.Blobber{ID: added}
+
+	allocationID = newAllocation(allocationWithReplacedBlobber)
+	write(allocationWithReplacedBlobber, allocationID)
+	replacement, err := GetBlobberIDNotPartOfAllocation(walletFile, configFile, allocationID)
+	require.Nil(t, err, "error getting blobber to add")
+	replaced, err := GetRandomBlobber(walletFile, configFile, allocationID, replacement)
+	require.Nil(t, err, "error getting blobber to replace")
+	updateBlobbers(allocationWithReplacedBlobber, allocationID, replacement, replaced)
+	registry.Blobbers[replacementBlobber] = fixtures.Blobber{ID: replacement}
+	registry.Blobbers[replacedBlobber] = fixtures.Blobber{ID: replaced}
+
+	allocationID = newAllocation(canceledAllocation)
+	write(canceledAllocation, allocationID)
+	output, err = cancelAllocation(t, configPath, allocationID, true)
+	require.Nil(t, err, "error canceling allocation", strings.Join(output, "\n"))
+	record(canceledAllocation, fixtures.Operation{Kind: fixtures.Cancel})
+
+	registry.Round = getLatestFinalizedBlock(t).Round
+	require.NoError(t, registry.Validate(t, client.NewAPIClient(viper.GetString("block_worker"))))
+	require.NoError(t, registry.Save(challengeFixturesPath()))
+	t.Logf("Challenge fixtures are saved to %s", challengeFixturesPath())
+}
+
+// loadChallengeFixtures reads fixtures provisioned by TestProtocolChallengeSetup, each test validates the fixtures it uses.
+// Without the registry it falls back to files written by the CI action provisioning the fixtures.
+func loadChallengeFixtures() (*fixtures.Registry, error) {
+	registry, err := fixtures.Load(challengeFixturesPath(), challengeScenario)
+	if errors.Is(err, os.ErrNotExist) {
+		return legacyChallengeFixtures()
+	}
+	return registry, err
+}
+
+// legacyChallengeFixtures reads ids of fixtures by line from challenge_allocations.txt and challenge_blobbers.txt,
+// the owner of the allocations is not known
+func legacyChallengeFixtures() (*fixtures.Registry, error) {
+	allocationNames := []string{
+		allocationWithWrites,
+		allocationWithWritesAndDeletes,
+		emptyAllocation,
+		allocationWithAddedBlobber,
+		allocationWithReplacedBlobber,
+		canceledAllocation,
+	}
+	blobberNames := []string{addedBlobber, replacementBlobber, replacedBlobber}
+
+	allocationIDs, err := readFixtureIDs(legacyChallengeAllocations, len(allocationNames))
+	if err != nil {
+		return nil, err
+	}
+	blobberIDs, err := readFixtureIDs(legacyChallengeBlobbers, len(blobberNames))
+	if err != nil {
+		return nil, err
+	}
+
+	registry := fixtures.New(challengeScenario)
+	registry.Wallets[challengeOwnerWallet] = fixtures.Wallet{}
+	for i, name := range blobberNames {
+		registry.Blobbers[name] = fixtures.Blobber{ID: blobberIDs[i]}
+	}
+	for i, name := range allocationNames {
+		var blobbers []string
+		if name == allocationWithReplacedBlobber {
+			blobbers = []string{blobberIDs[2]}
+		}
+		if err := registry.AddAllocation(name, challengeOwnerWallet, allocationIDs[i], blobbers); err != nil {
+			return nil, err
+		}
+	}
+	for name, operation := range map[string]fixtures.Operation{
+		allocationWithAddedBlobber:    {Kind: fixtures.AddBlobber, Blobber: blobberIDs[0]},
+		allocationWithReplacedBlobber: {Kind: fixtures.ReplaceBlobber, Blobber: blobberIDs[1], Replaced: blobberIDs[2]},
+		canceledAllocation:            {Kind: fixtures.Cancel},
+	} {
+		if err := registry.Record(name, operation); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// readFixtureIDs reads ids written one per line, the file has to contain at least count of them
+func readFixtureIDs(path string, count int) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(data))
+	if len(ids) < count {
+		return nil, fmt.Errorf("%w: %s has %d ids, expected %d", fixtures.ErrInvalid, path, len(ids), count)
+	}
+	return ids, nil
+}
@@ -4,59 +4,77 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math"
 	"math/big"
-	"net/http"
-	"net/url"
-	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/0chain/system_test/internal/api/util/challenges"
+	"github.com/0chain/system_test/internal/api/util/client"
+	"github.com/0chain/system_test/internal/api/util/fixtures"
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
 
+// challengeWindow is the time in which challenges generated by the network are counted. The window is
+// measured in wall clock time and converted to rounds with the tracker, as block rate differs between networks.
+const challengeWindow = 4 * time.Minute
+
 func TestProtocolChallenge(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
 	t.SetSmokeTests("Number of challenges between 2 blocks should be equal to the number of blocks (given that we have active allocations)")
 
 	var blobberList []climodel.BlobberInfo
-	var sharderBaseURLs []string
+	var challengeClient *client.APIClient
+	var tracker *challenges.Tracker
+	var registry *fixtures.Registry
+	var registryErr error
+
+	// challengeAllocation returns id of the allocation provisioned by TestProtocolChallengeSetup if it is still live
+	challengeAllocation := func(t *test.SystemTest, name string) string {
+		require.NoError(t, registryErr, "challenge fixtures are not usable, they are provisioned by TestProtocolChallengeSetup")
+		require.NoError(t, registry.ValidateAllocation(t, challengeClient, name))
+		allocation, err := registry.Allocation(name)
+		require.NoError(t, err)
+		return allocation.ID
+	}
+
+	challengeBlobber := func(t *test.SystemTest, name string) string {
+		require.NoError(t, registryErr, "challenge fixtures are not usable, they are provisioned by TestProtocolChallengeSetup")
+		require.NoError(t, registry.ValidateBlobber(t, challengeClient, name))
+		blobberID, err := registry.Blobber(name)
+		require.NoError(t, err)
+		return blobberID
+	}
 
 	// These tests are supposed to run on a network after atleast 1 hour of deployment and some writes.
-	// Setup related to these tests is done in `0chain/actions/run-system-tests/action.yml`.
-	// The 1 hour wait after setup is also handled in CI.
-	t.TestSetup("Get list of sharders and blobbers", func() {
+	// Allocations they check are provisioned by TestProtocolChallengeSetup.
+	// The 1 hour wait after setup is handled in CI.
+	t.TestSetup("Get list of blobbers", func() {
 		createWallet(t)
 
-		// Get sharder list.
-		output, err := getSharders(t, configPath)
-		require.Nil(t, err, "get sharders failed", strings.Join(output, "\n"))
-		require.Greater(t, len(output), 1)
-		require.Equal(t, "MagicBlock Sharders", output[0])
-
-		var sharders map[string]*climodel.Sharder
-		err = json.Unmarshal([]byte(strings.Join(output[1:], "")), &sharders)
-		require.Nil(t, err, "Error deserializing JSON string `%s`: %v", strings.Join(output[1:], "\n"), err)
-		require.NotEmpty(t, sharders, "No sharders found: %v", strings.Join(output[1:], "\n"))
-
-		// Get base URL for API calls.
-		sharderBaseURLs = getAllSharderBaseURLs(sharders)
-		require.Greater(t, len(sharderBaseURLs), 0, "No sharder URLs found.")
+		challengeClient = client.NewAPIClient(viper.GetString("block_worker"))
+		tracker = challenges.NewTracker(challengeClient, 0)
 
 		blobberList = []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err := listBlobbers(t, configPath, "--json")
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
 		err = json.Unmarshal([]byte(output[0]), &blobberList)
 		require.Nil(t, err, "Error unmarshalling blobber list", strings.Join(output, "\n"))
 		require.True(t, len(blobberList) > 0, "No blobbers found in blobber list")
+
+		registry, registryErr = loadChallengeFixtures()
+		if registryErr != nil {
+			t.Logf("Challenge fixtures are not usable: %v", registryErr)
+		}
 	})
 
 	t.RunWithTimeout("Number of challenges between 2 blocks should be equal to the number of blocks after challenge_generation_gap (given that we have active allocations)", 10*time.Minute, func(t *test.SystemTest) {
@@ -79,133 +97,132 @@ func TestProtocolChallenge(testSetup *testing.T) {
 		}, true)
 		require.Nil(t, err, "error uploading file", strings.Join(output, "\n"))
 
-		startBlock := getLatestFinalizedBlock(t)
-
-		time.Sleep(4 * time.Minute)
-
-		endBlock := getLatestFinalizedBlock(t)
-
-		time.Sleep(1 * time.Minute)
+		// Challenges of the new allocation show when challenges generated in the window are answered
+		allocationTracker := challenges.NewTracker(challengeClient, 0, allocationId)
+		require.NoError(t, allocationTracker.Poll(t))
+		startRound := allocationTracker.Round()
+		windowStart := time.Now()
+
+		_, err = allocationTracker.Wait(t, wait.Config{
+			Timeout:     challengeWindow + 2*time.Minute,
+			Backoff:     wait.Constant(10 * time.Second),
+			Description: fmt.Sprintf("%v of rounds after round %d", challengeWindow, startRound),
+		}, func(map[string]challenges.BlobberStats) bool {
+			return time.Since(windowStart) >= challengeWindow
+		})
+		require.NoError(t, err)
+		endRound := allocationTracker.Round()
+		require.Greater(t, endRound, startRound, "no round was finalized in %v", challengeWindow)
+
+		_, err = allocationTracker.Wait(t, wait.Config{
+			Timeout:     2 * time.Minute,
+			Backoff:     wait.Constant(10 * time.Second),
+			Description: fmt.Sprintf("responses to challenges created before round %d", endRound),
+		}, func(map[string]challenges.BlobberStats) bool {
+			for _, challenge := range allocationTracker.Challenges() {
+				if challenge.RoundCreatedAt <= endRound && challenge.Status == challenges.Open {
+					return false
+				}
+			}
+			return true
+		})
+		require.NoError(t, err)
 
-		challengesCountQuery := fmt.Sprintf("round_created_at >= %d AND round_created_at < %d", startBlock.Round, endBlock.Round)
-		challenges, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		challengesCountQuery := challenges.Query{FromRound: startRound, ToRound: endRound}
+		counts, err := tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
 		challengeGenerationGap := int64(4)
 
-		require.InEpsilon(t, (endBlock.Round-startBlock.Round)/challengeGenerationGap, challenges["total"], 0.05, "number of challenges should be equal to the number of blocks after challenge_generation_gap")
-		require.InEpsilon(t, challenges["total"], challenges["passed"]+challenges["open"], 0.05, "failure rate should not be more than 5 percent")
-		require.Less(t, challenges["open"], int64(720), "number of open challenges should be lesser than 720")
+		require.InEpsilon(t, (endRound-startRound)/challengeGenerationGap, counts.Total, 0.05, "number of challenges should be equal to the number of blocks after challenge_generation_gap")
+		require.InEpsilon(t, counts.Total, counts.Passed+counts.Open, 0.05, "failure rate should not be more than 5 percent")
+		require.Less(t, counts.Open, int64(720), "number of open challenges should be lesser than 720")
 	})
 
 	t.RunWithTimeout("Allocation with writes should get challenges", 4*time.Minute, func(t *test.SystemTest) {
-		// read allocation id in first line of challenge_allocations.txt
+		allocationId := challengeAllocation(t, allocationWithWrites)
 
-		file := "challenge_allocations.txt"
-		allocationId := readLineFromFile(t, file, 0)
-
-		challengesCountQuery := fmt.Sprintf("allocation_id='%s'", allocationId)
-		challenges, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		challengesCountQuery := challenges.Query{AllocationID: allocationId}
+		counts, err := tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
-		require.Greater(t, challenges["total"], int64(0), "number of challenges should be greater than 0")
-		require.InEpsilon(t, challenges["total"], challenges["passed"]+challenges["open"], 0.05, "failure rate should not be more than 5 percent")
+		require.Greater(t, counts.Total, int64(0), "number of challenges should be greater than 0")
+		require.InEpsilon(t, counts.Total, counts.Passed+counts.Open, 0.05, "failure rate should not be more than 5 percent")
 	})
 
 	t.RunWithTimeout("Allocation with writes and deletes should not get challenges", 4*time.Minute, func(t *test.SystemTest) {
-		// read allocation id in second line of challenge_allocations.txt
-
-		file := "challenge_allocations.txt"
-		allocationId := readLineFromFile(t, file, 1)
+		allocationId := challengeAllocation(t, allocationWithWritesAndDeletes)
 
-		challengesCountQuery := fmt.Sprintf("allocation_id = '%s'", allocationId)
-		challenges, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		challengesCountQuery := challenges.Query{AllocationID: allocationId}
+		counts, err := tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
-		require.Less(t, challenges["total"], int64(720), "number of challenges should not more increase after a threshold")
+		require.Less(t, counts.Total, int64(720), "number of challenges should not more increase after a threshold")
 	})
 
 	t.RunWithTimeout("Empty Allocation should not get challenges", 4*time.Minute, func(t *test.SystemTest) {
-		// read allocation id in third line of challenge_allocations.txt
+		allocationId := challengeAllocation(t, emptyAllocation)
 
-		file := "challenge_allocations.txt"
-		allocationId := readLineFromFile(t, file, 2)
-
-		challengesCountQuery := fmt.Sprintf("allocation_id = '%s'", allocationId)
-		challenges, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		challengesCountQuery := challenges.Query{AllocationID: allocationId}
+		counts, err := tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
-		require.Equal(t, int64(0), challenges["total"], "number of challenges should be 0")
+		require.Equal(t, int64(0), counts.Total, "number of challenges should be 0")
 	})
 
 	t.RunWithTimeout("Added blobber in an allocation should also be challenged for this blobber allocation", 4*time.Minute, func(t *test.SystemTest) {
-		challengeAllocationFile := "challenge_allocations.txt"
-		challengeBlobberFile := "challenge_blobbers.txt"
-
-		// read allocation id in fourth line of challenge_allocations.txt
-		allocationId := readLineFromFile(t, challengeAllocationFile, 3)
+		allocationId := challengeAllocation(t, allocationWithAddedBlobber)
+		blobberId := challengeBlobber(t, addedBlobber)
 
-		// read blobber id in first line of challenge_blobbers.txt
-		blobberId := readLineFromFile(t, challengeBlobberFile, 0)
+		challengesCountQuery := challenges.Query{AllocationID: allocationId, BlobberID: blobberId}
 
-		challengesCountQuery := fmt.Sprintf("allocation_id = '%s' AND blobber_id = '%s'", allocationId, blobberId)
-
-		challenges, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		counts, err := tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
-		require.Greater(t, challenges["total"], int64(0), "number of challenges should be greater than 0")
-		require.InEpsilon(t, challenges["total"], challenges["passed"]+challenges["open"], 0.05, "failure rate should not be more than 5 percent")
+		require.Greater(t, counts.Total, int64(0), "number of challenges should be greater than 0")
+		require.InEpsilon(t, counts.Total, counts.Passed+counts.Open, 0.05, "failure rate should not be more than 5 percent")
 	})
 
 	t.RunWithTimeout("Replaced blobber in an allocation should not be challenged for this blobber allocation", 4*time.Minute, func(t *test.SystemTest) {
-		challengeAllocationFile := "challenge_allocations.txt"
-		challengeBlobberFile := "challenge_blobbers.txt"
-
-		// read allocation id in fifth line of challenge_allocations.txt
-		allocationId := readLineFromFile(t, challengeAllocationFile, 4)
-
-		// read blobber id in second line of challenge_blobbers.txt
-		addedBlobberID := readLineFromFile(t, challengeBlobberFile, 1)
-		replacedBlobberID := readLineFromFile(t, challengeBlobberFile, 2)
+		allocationId := challengeAllocation(t, allocationWithReplacedBlobber)
+		addedBlobberID := challengeBlobber(t, replacementBlobber)
+		replacedBlobberID := challengeBlobber(t, replacedBlobber)
 
 		// Added Blobber should get challenges for this allocation
 
-		challengesCountQuery := fmt.Sprintf("allocation_id = '%s' AND blobber_id = '%s'", allocationId, addedBlobberID)
+		challengesCountQuery := challenges.Query{AllocationID: allocationId, BlobberID: addedBlobberID}
 
-		challenges, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		counts, err := tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
-		require.Greater(t, challenges["total"], int64(0), "number of challenges should be greater than 0")
-		require.InEpsilon(t, challenges["total"], challenges["passed"]+challenges["open"], 0.05, "failure rate should not be more than 5 percent")
+		require.Greater(t, counts.Total, int64(0), "number of challenges should be greater than 0")
+		require.InEpsilon(t, counts.Total, counts.Passed+counts.Open, 0.05, "failure rate should not be more than 5 percent")
 
 		// Replaced Blobber should not get challenges for this allocation
 
-		challengesCountQuery = fmt.Sprintf("allocation_id = '%s' AND blobber_id = '%s'", allocationId, replacedBlobberID)
+		challengesCountQuery = challenges.Query{AllocationID: allocationId, BlobberID: replacedBlobberID}
 
-		challenges, err = countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		counts, err = tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
-		require.Equal(t, int64(0), challenges["total"], "number of challenges should be 0")
+		require.Equal(t, int64(0), counts.Total, "number of challenges should be 0")
 	})
 
 	t.RunWithTimeout("Canceled allocation should no more get any challenges", 4*time.Minute, func(t *test.SystemTest) {
-		// read allocation id in sixth line of challenge_allocations.txt
+		allocationId := challengeAllocation(t, canceledAllocation)
 
-		file := "challenge_allocations.txt"
-		allocationId := readLineFromFile(t, file, 5)
-
-		challengesCountQuery := fmt.Sprintf("allocation_id = '%s'", allocationId)
-		challenges, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+		challengesCountQuery := challenges.Query{AllocationID: allocationId}
+		counts, err := tracker.Count(t, challengesCountQuery)
 		require.Nil(t, err, "error counting challenges")
 
-		require.Less(t, challenges["total"], int64(720), "number of challenges should not more increase after a threshold")
+		require.Less(t, counts.Total, int64(720), "number of challenges should not more increase after a threshold")
 	})
 
 	t.RunWithTimeout("Challenges success rate and blobber distribution should be good", 5*time.Minute, func(t *test.SystemTest) {
-		allChallengesCount, err := countChallengesByQuery(t, "", sharderBaseURLs)
+		allChallengesCount, err := tracker.Count(t, challenges.Query{})
 		require.Nil(t, err, "error counting challenges")
 
-		require.InEpsilonf(t, allChallengesCount["total"], allChallengesCount["passed"]+allChallengesCount["open"], 0.05, "Challenge Failure rate should not be more than 5%")
+		require.InEpsilonf(t, allChallengesCount.Total, allChallengesCount.Passed+allChallengesCount.Open, 0.05, "Challenge Failure rate should not be more than 5%")
 
 		totalWeight := float64(0)
 		for _, blobber := range blobberList {
@@ -226,7 +243,7 @@ func TestProtocolChallenge(testSetup *testing.T) {
 
 		expectedCounts := make(map[string]int64)
 
-		for i := int64(0); i < allChallengesCount["total"]; i++ {
+		for i := int64(0); i < allChallengesCount.Total; i++ {
 			randomWeight, err := secureRandomInt(int(totalWeight))
 			require.Nil(t, err, "error generating random number")
 
@@ -262,14 +279,14 @@ func TestProtocolChallenge(testSetup *testing.T) {
 				weight = uint64(weightFloat)
 			}
 
-			challengesCountQuery := fmt.Sprintf("blobber_id = '%s'", blobber.Id)
-			blobberChallengeCount, err := countChallengesByQuery(t, challengesCountQuery, sharderBaseURLs)
+			challengesCountQuery := challenges.Query{BlobberID: blobber.Id}
+			blobberChallengeCount, err := tracker.Count(t, challengesCountQuery)
 			require.Nil(t, err, "error counting challenges")
 
-			t.Log("Blobber weight : ", weight, " Expected Challenges : ", expectedCounts[blobber.Id], " Blobber Challenges : ", blobberChallengeCount["total"])
+			t.Log("Blobber weight : ", weight, " Expected Challenges : ", expectedCounts[blobber.Id], " Blobber Challenges : ", blobberChallengeCount.Total)
 
-			require.InEpsilon(t, blobberChallengeCount["total"], expectedCounts[blobber.Id], 0.25, "blobber distribution should within tolerance")
-			require.InEpsilon(t, blobberChallengeCount["total"], blobberChallengeCount["passed"]+blobberChallengeCount["open"], 0.05, "failure rate should not be more than 5 percent")
+			require.InEpsilon(t, blobberChallengeCount.Total, expectedCounts[blobber.Id], 0.25, "blobber distribution should within tolerance")
+			require.InEpsilon(t, blobberChallengeCount.Total, blobberChallengeCount.Passed+blobberChallengeCount.Open, 0.05, "failure rate should not be more than 5 percent")
 		}
 	})
 }
@@ -290,46 +307,3 @@ func getAllSharderBaseURLs(sharders map[string]*climodel.Sharder) []string {
 	}
 	return sharderURLs
 }
-
-func countChallengesByQuery(t *test.SystemTest, query string, sharderBaseURLs []string) (map[string]int64, error) {
-	for _, sharderBaseURL := range sharderBaseURLs {
-		encodedQuery := url.QueryEscape(query)
-		baseURL := fmt.Sprintf(sharderBaseURL + "/v1/screst/" + storageSmartContractAddress + "/count-challenges")
-		challengeCountURL := fmt.Sprintf("%s?query=%s", baseURL, encodedQuery)
-
-		res, err := http.Get(challengeCountURL) //nolint:gosec
-		if err != nil || res.StatusCode < 200 || res.StatusCode >= 300 {
-			continue
-		} //nolint:gosec
-
-		require.Nil(t, err, "error getting challenges count", res)
-		require.True(t, res.StatusCode >= 200 && res.StatusCode < 300, "Failed API request to get challenges count between blocks")
-		require.NotNil(t, res.Body, "get challenges count between blocks API response must not be nil")
-
-		resBody, err := io.ReadAll(res.Body)
-		func() { defer res.Body.Close() }()
-
-		require.Nil(t, err, "Error reading response body")
-
-		var challengesCount map[string]int64
-		err = json.Unmarshal(resBody, &challengesCount)
-		require.Nil(t, err, "error unmarshalling response body")
-
-		t.Logf("Challenges count: %v", challengesCount)
-
-		return challengesCount, nil
-	}
-	t.Errorf("all sharders gave an error at endpoint /count-challenges")
-
-	return nil, nil
-}
-
-func readLineFromFile(t *test.SystemTest, file string, line int) string {
-	output, err := os.ReadFile(file)
-	require.Nil(t, err, "error reading file", file)
-
-	lines := strings.Split(string(output), "\n")
-	require.Greater(t, len(lines), line, "file should have at least %d lines", line)
-
-	return lines[line]
-}
@@ -1,21 +1,24 @@
 package cli_tests
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"sync"
 	"testing"
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/tenderly"
 
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/client"
 	"github.com/0chain/system_test/internal/api/util/config"
+	"github.com/0chain/system_test/internal/api/util/redact"
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/walletpool"
+	"github.com/0chain/system_test/internal/api/util/wallets"
 
 	cliutils "github.com/0chain/system_test/internal/cli/util"
 	"github.com/spf13/viper"
@@ -60,6 +63,7 @@ func setupConfig() {
 	s3BucketNameAlternate = parsedConfig.S3BucketNameAlternate
 	dropboxAccessToken = parsedConfig.DropboxAccessToken
 	gdriveAccessToken = parsedConfig.GdriveAccessToken
+	redact.AddSecret(s3AccessKey, s3SecretKey, dropboxAccessToken, gdriveAccessToken)
 
 	if err != nil {
 		log.Printf("Default test case timeout could not be parsed so has defaulted to [%v]", test.DefaultTestTimeout)
@@ -117,9 +121,10 @@ var (
 	configPath string
 	configDir  string
 
-	wallets     []json.RawMessage
-	walletIdx   int64
-	walletMutex sync.Mutex
+	walletPool *walletpool.Pool
+
+	// poolTest logs background refills of the wallet pool
+	poolTest *test.SystemTest
 
 	tenderlyInitialized bool
 )
@@ -232,26 +237,42 @@ func TestMain(m *testing.M) { //nolint:gocyclo
 		S3Client = s3.New(sess)
 	}
 
-	walletMutex.Lock()
-	// Read the content of the file
-	fileContent, err := os.ReadFile("./config/wallets/wallets.json")
+	fileWallets, err := wallets.LoadList("./config/wallets/wallets.json")
 	if err != nil {
-		log.Println("Error reading file:", err)
+		log.Println("Error reading wallets:", err)
 		return
 	}
 
-	// Parse the JSON data into a list of strings
-	err = json.Unmarshal(fileContent, &wallets)
-	if err != nil {
-		log.Println("Error decoding JSON:", err)
-		return
+	// first wallets are used by api tests running against the same network
+	const firstCliWallet = 500
+	pooledWallets := make([]*model.Wallet, 0, len(fileWallets))
+	for _, fileWallet := range fileWallets[min(firstCliWallet, len(fileWallets)):] {
+		pooledWallet, err := fileWallet.ToModel()
+		if err != nil {
+			log.Println("Error loading wallet keys:", err)
+			continue
+		}
+		pooledWallets = append(pooledWallets, pooledWallet)
+	}
+	poolTest = test.NewSystemTest(new(testing.T))
+	blockWorker := viper.GetString("block_worker")
+	funder := &walletpool.APIFunder{NewClient: func() *client.APIClient {
+		return client.NewAPIClient(blockWorker)
+	}}
+	// cli tests leave stake pools and allocations on their wallets, so every wallet is used once
+	walletPool = walletpool.New(poolTest, funder, walletpool.Config{SingleUse: true}, pooledWallets...)
+
+	if err := test.NewReporterFromEnv("cli_tests"); err != nil {
+		log.Printf("Test report could not be created: %v", err)
 	}
-
-	walletIdx = 500
-
-	walletMutex.Unlock()
 
 	exitRun := m.Run()
+	walletPool.Close()
+	if test.DefaultReporter != nil {
+		if err := test.DefaultReporter.Close(); err != nil {
+			log.Printf("Test report could not be written: %v", err)
+		}
+	}
 
 	os.Exit(exitRun)
 }
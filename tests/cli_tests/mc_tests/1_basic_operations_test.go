@@ -1,92 +1,80 @@
 package cli_tests
 
 import (
-	"os"
+	"bytes"
+	"net/http"
 	"testing"
-	"time"
 
 	test "github.com/0chain/system_test/internal/api/util/test"
-	cli_utils "github.com/0chain/system_test/internal/cli/util"
-	"github.com/stretchr/testify/assert"
+	"github.com/0chain/system_test/internal/api/util/zs3"
+	"github.com/stretchr/testify/require"
 )
 
 func TestZs3Server(testSetup *testing.T) {
+	client, _ := newClients(testSetup)
 	t := test.NewSystemTest(testSetup)
 
-	if _, err := os.Stat("../mc"); os.IsNotExist(err) {
-		t.Fatalf("../mc is not installed")
-	} else {
-		t.Logf("../mc is installed")
-	}
+	const bucket = "custombucket"
+	content := []byte("test")
 
-	defer func() {
-		_, err := cli_utils.RunCommand(t, "rm -rf a.txt", 1, time.Hour*2)
-		if err != nil {
-			t.Logf("Error while deferring command: %v", err)
-		}
-	}()
+	t.Cleanup(func() {
+		purgeBucket(t, client, bucket)
+	})
 
-	// listing the buckets in the command
 	t.RunSequentially("Should list the buckets", func(t *test.SystemTest) {
-		output, _ := cli_utils.RunCommand(t, "../mc ls play", 1, time.Hour*2)
-		assert.NotContains(t, output, "error")
+		_, err := client.ListBuckets(t)
+		require.NoError(t, err)
 	})
 
 	t.RunSequentially("Test Bucket Creation", func(t *test.SystemTest) {
-		output, _ := cli_utils.RunCommand(t, "../mc mb custombucket", 1, time.Hour*2)
-		assert.Contains(t, output, "Bucket created successfully `custombucket`.")
+		createBucket(t, client, bucket)
+
+		buckets, err := client.ListBuckets(t)
+		require.NoError(t, err)
+		require.Contains(t, buckets, bucket)
 	})
 
 	t.RunSequentially("Test Copying File Upload", func(t *test.SystemTest) {
-		// create a file with content
-		_, _ = cli_utils.RunCommand(t, "../mc mb custombucket", 1, time.Hour*2)
-
-		file, err := os.Create("a.txt")
-		if err != nil {
-			t.Fatalf("Error creating file: %v", err)
-		}
-		defer file.Close()
-
-		_, err = file.WriteString("test")
-		if err != nil {
-			t.Fatalf("Error writing to file: %v", err)
-		}
-
-		output, _ := cli_utils.RunCommand(t, "../mc cp a.txt custombucket", 1, time.Hour*2)
+		createBucket(t, client, bucket)
 
-		assert.NotContains(t, output, "../mc: <ERROR>")
+		_, err := client.PutObject(t, bucket, "a.txt", bytes.NewReader(content))
+		require.NoError(t, err)
 
-		os.Remove("a.txt")
+		got, err := client.GetObject(t, bucket, "a.txt")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
 	})
 
 	t.RunSequentially("Test for moving file", func(t *test.SystemTest) {
-		_, _ = cli_utils.RunCommand(t, "../mc mb custombucket", 1, time.Hour*2)
+		createBucket(t, client, bucket)
+		_, err := client.PutObject(t, bucket, "a.txt", bytes.NewReader(content))
+		require.NoError(t, err)
 
-		file, err := os.Create("a.txt")
-		if err != nil {
-			t.Fatalf("Error creating file: %v", err)
-		}
-		defer file.Close()
+		moveObject(t, client, bucket, "a.txt", bucket, "b")
 
-		_, err = file.WriteString("test")
-		if err != nil {
-			t.Fatalf("Error writing to file: %v", err)
-		}
-
-		_, _ = cli_utils.RunCommand(t, "../mc cp a.txt custombucket", 1, time.Hour*2)
-
-		output, _ := cli_utils.RunCommand(t, "../mc mv custombucket/a.txt custombucket/b", 1, time.Hour*2)
-		assert.NotContains(t, output, "../mc: <ERROR>")
+		got, err := client.GetObject(t, bucket, "b")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+		_, err = client.HeadObject(t, bucket, "a.txt")
+		require.Equal(t, http.StatusNotFound, zs3.StatusCode(err))
 	})
 
 	t.RunSequentially("Test for copying file ", func(t *test.SystemTest) {
-		output, _ := cli_utils.RunCommand(t, "../mc cp a.txt custombucket", 1, time.Hour*2)
+		_, err := client.PutObject(t, bucket, "a.txt", bytes.NewReader(content))
+		require.NoError(t, err)
 
-		assert.NotContains(t, output, "../mc: <ERROR>")
+		require.NoError(t, client.CopyObject(t, bucket, "a.txt", bucket, "copy-of-a.txt"))
+
+		objects, err := client.ListObjects(t, bucket, zs3.ListOptions{})
+		require.NoError(t, err)
+		require.Subset(t, keys(objects.Objects), []string{"a.txt", "copy-of-a.txt"})
 	})
 
 	t.RunSequentially("Test for removing file", func(t *test.SystemTest) {
-		output, _ := cli_utils.RunCommand(t, "../mc rm custombucket/a.txt", 1, time.Hour*2)
-		assert.Contains(t, output, "Removed `custombucket/a.txt`.")
+		require.NoError(t, client.DeleteObject(t, bucket, "a.txt"))
+
+		objects, err := client.ListObjects(t, bucket, zs3.ListOptions{Prefix: "a.txt"})
+		require.NoError(t, err)
+		require.Empty(t, objects.Objects)
 	})
 }
@@ -1,56 +1,39 @@
 package cli_tests
 
 import (
-	"fmt"
-	"os"
+	"bytes"
 	"testing"
-	"time"
 
 	test "github.com/0chain/system_test/internal/api/util/test"
-	cli_utils "github.com/0chain/system_test/internal/cli/util"
-
-	"github.com/stretchr/testify/assert"
+	"github.com/0chain/system_test/internal/api/util/zs3"
+	"github.com/stretchr/testify/require"
 )
 
 func TestZs3ServerBucket(testSetup *testing.T) {
+	client, _ := newClients(testSetup)
 	t := test.NewSystemTest(testSetup)
 
 	// test for moving the file from testbucket to testbucket2
 	t.RunSequentially("Test for moving file from testbucket to testbucket2", func(t *test.SystemTest) {
-		_, err := cli_utils.RunCommand(t, "../mc mb testbucket", 1, time.Hour*2)
-
-		if err != nil {
-			t.Fatalf("Error creating bucket: %v", err)
-		}
-
-		_, err = cli_utils.RunCommand(t, "../mc mb testbucket2", 1, time.Hour*2)
-		if err != nil {
-			t.Fatalf("Error creating bucket: %v", err)
-		}
-
-		file, err := os.Create("a.txt")
-		if err != nil {
-			t.Fatalf("Error creating file: %v", err)
-		}
-		defer file.Close()
-
-		_, err = file.WriteString("test")
-		if err != nil {
-			t.Fatalf("Error writing to file: %v", err)
-		}
-
-		output_ls, _ := cli_utils.RunCommand(t, "../mc mv a.txt testbucket", 1, time.Hour*2)
-
-		assert.NotContains(t, output_ls, "../mc: <ERROR>")
-		output, _ := cli_utils.RunCommand(t, "../mc mv testbucket/a.txt  testbucket2 ", 1, time.Hour*2)
-		// output is in format ...sts/./mc_tests/testbucket/a.txt: 4 B / 4 B  ▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓  707 B/s 0s
-
-		assert.NotContains(t, output, "../mc: <ERROR>")
-		if _, err := os.Stat("testbucket2/a.txt"); err != nil {
-			t.Errorf("Local file %s not found: %v", "testbucket2/a.txt", err)
-		}
-
-		// Assert that the local file exists
-		assert.NoError(t, err, fmt.Sprintf("Local file %s should exist", "testbucket2/a.txt"))
+		createBucket(t, client, "testbucket")
+		createBucket(t, client, "testbucket2")
+		t.Cleanup(func() {
+			purgeBucket(t, client, "testbucket")
+			purgeBucket(t, client, "testbucket2")
+		})
+
+		content := []byte("test")
+		_, err := client.PutObject(t, "testbucket", "a.txt", bytes.NewReader(content))
+		require.NoError(t, err)
+
+		moveObject(t, client, "testbucket", "a.txt", "testbucket2", "a.txt")
+
+		got, err := client.GetObject(t, "testbucket2", "a.txt")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+
+		objects, err := client.ListObjects(t, "testbucket", zs3.ListOptions{Prefix: "a.txt"})
+		require.NoError(t, err)
+		require.Empty(t, objects.Objects)
 	})
 }
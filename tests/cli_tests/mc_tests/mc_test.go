@@ -0,0 +1,55 @@
+package cli_tests
+
+import (
+	"testing"
+
+	test "github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/zs3"
+	cli_utils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/stretchr/testify/require"
+)
+
+// newClients creates clients of primary and secondary zs3server from mc_hosts.yaml
+func newClients(testSetup *testing.T) (primary, secondary *zs3.Client) {
+	config := cli_utils.ReadFileMC(testSetup)
+
+	primary, err := zs3.NewClient("http://"+config.Server+":"+config.HostPort, config.AccessKey, config.SecretKey)
+	require.NoError(testSetup, err)
+	secondary, err = zs3.NewClient("http://"+config.SecondaryServer+":"+config.SecondaryPort, config.AccessKey, config.SecretKey)
+	require.NoError(testSetup, err)
+	return primary, secondary
+}
+
+// createBucket creates the bucket unless it exists already
+func createBucket(t *test.SystemTest, client *zs3.Client, bucket string) {
+	err := client.CreateBucket(t, bucket)
+	if err != nil {
+		require.Contains(t, []string{"BucketAlreadyOwnedByYou", "BucketAlreadyExists"}, zs3.ErrorCode(err), err)
+	}
+}
+
+// moveObject copies the object on the server and removes the source, the same way as mc mv does
+func moveObject(t *test.SystemTest, client *zs3.Client, sourceBucket, sourceKey, bucket, key string) {
+	require.NoError(t, client.CopyObject(t, sourceBucket, sourceKey, bucket, key))
+	require.NoError(t, client.DeleteObject(t, sourceBucket, sourceKey))
+}
+
+// purgeBucket removes every object of the bucket
+func purgeBucket(t *test.SystemTest, client *zs3.Client, bucket string) {
+	objects, err := client.ListObjects(t, bucket, zs3.ListOptions{})
+	if zs3.ErrorCode(err) == "NoSuchBucket" {
+		return
+	}
+	require.NoError(t, err)
+	for _, object := range objects.Objects {
+		require.NoError(t, client.DeleteObject(t, bucket, object.Key))
+	}
+}
+
+func keys(objects []zs3.Object) []string {
+	result := make([]string, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, object.Key)
+	}
+	return result
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"reflect"
 	"sort"
@@ -16,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
+	"github.com/0chain/system_test/internal/currency"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutil "github.com/0chain/system_test/internal/cli/util"
@@ -69,6 +70,7 @@ func TestMinerBlockRewards(testSetup *testing.T) { // nolint:gocyclo // team pre
 		time.Sleep(time.Second) // give time for last round to be saved
 		history := cliutil.NewHistory(startRound, endRound)
 		history.Read(t, sharderUrl, true)
+		history.SaveOnFailure(t)
 
 		balanceMinerRewards(
 			t, startRound, endRound, minerIds, beforeMiners.Nodes, afterMiners.Nodes, history,
@@ -88,12 +90,12 @@ func balanceMinerRewards(
 		"epoch changed during test, start %v finish %v",
 		startRound/int64(minerScConfig["epoch"]), endRound/int64(minerScConfig["epoch"]))
 
-	minerBlockReward, _ := blockRewards(startRound, minerScConfig)
+	config := minerRewardsConfig(t, minerScConfig)
 
 	checkMinerBlockRewards(
 		t,
 		minerIds,
-		minerBlockReward,
+		config,
 		beforeMiners, afterMiners,
 		history,
 	)
@@ -105,8 +107,7 @@ func balanceMinerRewards(
 	checkMinerDelegatePoolBlockRewards(
 		t,
 		minerIds,
-		int(minerScConfig["num_miner_delegates_rewarded"]),
-		minerBlockReward,
+		config,
 		beforeMiners, afterMiners,
 		history,
 	)
@@ -119,6 +120,7 @@ func balanceMinerRewards(
 // The amount of the reward is a fraction of the block reward allocated to miners each
 // round. The fraction is the miner's service charge. If the miner has
 // no stake pools then the reward becomes the full block reward.
+// The payment must equal the one replayed by the rewards model exactly.
 //
 // Firstly we confirm the self-consistency of the block and reward tables.
 // We calculate the change in the miner rewards during and confirm that this
@@ -126,7 +128,7 @@ func balanceMinerRewards(
 func checkMinerBlockRewards(
 	t *test.SystemTest,
 	minerIds []string,
-	minerBlockReward int64,
+	config rewards.Config,
 	beforeMiners, afterMiners []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
@@ -142,19 +144,10 @@ func checkMinerBlockRewards(
 				case climodel.BlockRewardMiner:
 					require.Falsef(t, beforeMiners[i].IsKilled,
 						"killed miners cannot receive rewards, %s is killed", id)
-					var expectedServiceCharge int64
-					if len(beforeMiners[i].StakePool.Pools) > 0 {
-						expectedServiceCharge = int64(float64(minerBlockReward) * beforeMiners[i].Settings.ServiceCharge)
-					} else {
-						expectedServiceCharge = minerBlockReward
-					}
-					require.InDeltaf(t, expectedServiceCharge, pReward.Amount, delta,
-						"incorrect service charge %v for round %d"+
-							" service charge should be block reward %v multiplied by service ratio %v."+
-							"length stake pools %d",
-						pReward.Amount, round,
-						minerBlockReward, beforeMiners[i].Settings.ServiceCharge,
-						len(beforeMiners[i].StakePool.Pools))
+					payout := expectedPayout(t, config, round, 0, beforeMiners[i], rewards.Miner, climodel.BlockRewardMiner, roundHistory)
+					require.EqualValuesf(t, payout.Provider, pReward.Amount,
+						"incorrect service charge for round %d, service charge %v, length stake pools %d",
+						round, beforeMiners[i].Settings.ServiceCharge, len(beforeMiners[i].StakePool.Pools))
 					blockRewards += pReward.Amount
 				case climodel.FeeRewardMiner:
 					feeRewards += pReward.Amount
@@ -201,25 +194,23 @@ func countMinerBlockRewards(
 // or all delegates if less.
 //
 // Delegates should be rewarded in proportional to their locked tokens.
-// We check the self-consistency of the reward payments each round using
-// the delegate reward table.
+// Block rewards of the delegates must equal the ones replayed by the rewards model within a unit,
+// see confirmDelegatePayout.
 //
 // Next we compare the actual change in rewards to each miner delegate, with the
 // change expected from the delegate reward table.
 func checkMinerDelegatePoolBlockRewards(
 	t *test.SystemTest,
 	minerIds []string,
-	numMinerDelegatesRewarded int,
-	minerBlockReward int64,
+	config rewards.Config,
 	beforeMiners, afterMiners []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
 	for i, id := range minerIds {
-		delegateBlockReward := int64(float64(minerBlockReward) * (1 - beforeMiners[i].Settings.ServiceCharge))
 		numPools := len(afterMiners[i].StakePool.Pools)
-		rewards := make(map[string]int64, numPools)
+		poolRewards := make(map[string]int64, numPools)
 		for poolId := range afterMiners[i].StakePool.Pools {
-			rewards[poolId] = 0
+			poolRewards[poolId] = 0
 		}
 		for round := beforeMiners[i].RoundServiceChargeLastUpdated + 1; round <= afterMiners[i].RoundServiceChargeLastUpdated; round++ {
 			poolsBlockRewarded := make(map[string]int64)
@@ -228,7 +219,7 @@ func checkMinerDelegatePoolBlockRewards(
 				if dReward.ProviderID != id {
 					continue
 				}
-				_, isMinerPool := rewards[dReward.PoolID]
+				_, isMinerPool := poolRewards[dReward.PoolID]
 				require.Truef(t, isMinerPool, "round %d, invalid pool id, reward %v", round, dReward)
 				switch dReward.RewardType {
 				case climodel.BlockRewardMiner:
@@ -236,9 +227,9 @@ func checkMinerDelegatePoolBlockRewards(
 					require.False(t, found, "delegate pool %s paid a block reward more than once on round %d",
 						dReward.PoolID, round)
 					poolsBlockRewarded[dReward.PoolID] = dReward.Amount
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				case climodel.FeeRewardMiner:
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				default:
 					require.Failf(t, "mismatched reward", "round %d, %s not available for miner", round, dReward.RewardType)
 				}
@@ -246,44 +237,39 @@ func checkMinerDelegatePoolBlockRewards(
 			if roundHistory.Block.MinerID != id {
 				require.Len(t, poolsBlockRewarded, 0,
 					"delegate pools should not get a block reward unless their parent miner won the round lottery")
+				continue
 			}
-			confirmPoolPayments(
-				t, delegateBlockReward, poolsBlockRewarded, afterMiners[i].StakePool.Pools, numMinerDelegatesRewarded,
-			)
+			payout := expectedPayout(t, config, round, 0, beforeMiners[i], rewards.Miner, climodel.BlockRewardMiner, roundHistory)
+			confirmDelegatePayout(t, round, id, payout, poolsBlockRewarded)
 		}
 		for poolId := range afterMiners[i].StakePool.Pools {
 			actualReward := afterMiners[i].StakePool.Pools[poolId].Reward - beforeMiners[i].StakePool.Pools[poolId].Reward
-			require.InDeltaf(t, actualReward, rewards[poolId], delta,
-				"poolID %s, rewards expected %v change in pools reward during test", poolId, rewards[poolId],
+			require.InDeltaf(t, actualReward, poolRewards[poolId], delta,
+				"poolID %s, rewards expected %v change in pools reward during test", poolId, poolRewards[poolId],
 			)
 		}
 	}
 }
 
-func confirmPoolPayments(
-	t *test.SystemTest,
-	totalReward int64,
-	poolsBlockRewarded map[string]int64,
-	pools map[string]*climodel.DelegatePool,
-	numRewards int,
-) {
-	if len(poolsBlockRewarded) == 0 {
-		return
-	}
-	if numRewards > len(pools) {
-		numRewards = len(pools)
+// confirmDelegatePayout checks that the pools rewarded on round are the ones of the payout and that their
+// rewards differ by at most a unit. The model gives the remainder of a split to the pool with the lowest id,
+// which is not verified against the chain.
+func confirmDelegatePayout(t *test.SystemTest, round int64, providerID string, payout rewards.Payout, poolsRewarded map[string]int64) {
+	var expected []string
+	for poolID, reward := range payout.Delegates {
+		if reward > 0 {
+			expected = append(expected, poolID)
+		}
 	}
-	require.Equal(t, len(poolsBlockRewarded), numRewards,
-		"expected reward payments %d does not equal actual payment count %d", numRewards, len(poolsBlockRewarded))
-	var total float64
-	for id := range poolsBlockRewarded {
-		total += float64(pools[id].Balance)
+	actual := make([]string, 0, len(poolsRewarded))
+	for poolID := range poolsRewarded {
+		actual = append(actual, poolID)
 	}
-	for id, reward := range poolsBlockRewarded {
-		expectedReward := (float64(pools[id].Balance) / total) * float64(totalReward)
-		require.InDeltaf(t, expectedReward, float64(reward), 1,
-			"delegate rewards. delegates should be rewarded in proportion to their stake."+
-				"total reward %d stake pools %v", totalReward, pools)
+	require.ElementsMatchf(t, expected, actual, "round %d, rewarded delegate pools of %s", round, providerID)
+
+	for _, poolID := range expected {
+		require.InDeltaf(t, int64(payout.Delegates[poolID]), poolsRewarded[poolID], delta,
+			"round %d, reward of delegate pool %s of %s", round, poolID, providerID)
 	}
 }
 
@@ -400,14 +386,89 @@ func getMinerScMap(t *test.SystemTest) map[string]float64 {
 	return floatMap
 }
 
-func blockRewards(round int64, minerScConfig map[string]float64) (minerReward, sharderReward int64) {
-	epoch := round / int64(minerScConfig["epoch"])
-	epochDecline := 1.0 - minerScConfig["reward_decline_rate"]
-	declineRate := math.Pow(epochDecline, float64(epoch))
-	blockReward := (minerScConfig["block_reward"] * float64(TOKEN_UNIT)) * declineRate
-	minerReward = int64(blockReward * minerScConfig["share_ratio"])
-	sharderReward = int64(blockReward) - minerReward
-	return minerReward, sharderReward
+// minerRewardsConfig returns rewards model config of the miner smart contract settings
+func minerRewardsConfig(t *test.SystemTest, minerScConfig map[string]float64) rewards.Config {
+	blockReward, err := currency.ParseZCN(minerScConfig["block_reward"])
+	require.NoError(t, err, "invalid block reward")
+	return rewards.Config{
+		Epoch:                       int64(minerScConfig["epoch"]),
+		BlockReward:                 blockReward,
+		RewardDeclineRate:           minerScConfig["reward_decline_rate"],
+		ShareRatio:                  minerScConfig["share_ratio"],
+		NumMinerDelegatesRewarded:   int(minerScConfig["num_miner_delegates_rewarded"]),
+		NumShardersRewarded:         int(minerScConfig["num_sharders_rewarded"]),
+		NumSharderDelegatesRewarded: int(minerScConfig["num_sharder_delegates_rewarded"]),
+	}
+}
+
+// rewardedOnRound reports whether provider received a reward of rewardType on the round
+func rewardedOnRound(roundHistory cliutil.RoundHistory, providerID string, rewardType climodel.Reward) bool {
+	for _, pReward := range roundHistory.ProviderRewards {
+		if pReward.ProviderId == providerID && pReward.RewardType == rewardType {
+			return true
+		}
+	}
+	return false
+}
+
+// feeRewardsConfig returns config of the rewards model which replays only fees of a block
+func feeRewardsConfig(config rewards.Config) rewards.Config {
+	config.BlockReward = 0
+	return config
+}
+
+// expectedPayout replays the rewards of rewardType paid to node on round in the rewards model.
+// Other providers rewarded on the round are registered without stake. Sharders and delegate pools
+// picked by the chain are read from the rewards of the round.
+func expectedPayout(
+	t *test.SystemTest,
+	config rewards.Config,
+	round, fees int64,
+	node climodel.Node,
+	providerType rewards.ProviderType,
+	rewardType climodel.Reward,
+	roundHistory cliutil.RoundHistory,
+) rewards.Payout {
+	block := rewards.Block{Round: round, Miner: roundHistory.Block.MinerID, Fees: currency.Coin(fees)}
+	providers := map[string]rewards.ProviderType{block.Miner: rewards.Miner}
+	if providerType == rewards.Sharder {
+		for _, pReward := range roundHistory.ProviderRewards {
+			if _, found := providers[pReward.ProviderId]; !found && pReward.RewardType == rewardType {
+				block.Sharders = append(block.Sharders, pReward.ProviderId)
+				providers[pReward.ProviderId] = rewards.Sharder
+			}
+		}
+	}
+	require.Equalf(t, providerType, providers[node.ID], "round %d, %s %s is not rewarded", round, providerType, node.ID)
+
+	model := rewards.New(config)
+	for id, providerType := range providers {
+		provider := rewards.Provider{ID: id, Type: providerType}
+		if id == node.ID {
+			provider.ServiceCharge = node.Settings.ServiceCharge
+		}
+		require.NoError(t, model.AddProvider(provider))
+	}
+	for poolID, pool := range node.StakePool.Pools {
+		if pool.Balance > 0 {
+			require.NoError(t, model.Apply(rewards.StakeChange{Provider: node.ID, Delegate: poolID, Amount: pool.Balance}))
+		}
+	}
+
+	var picked []string
+	for _, dReward := range roundHistory.DelegateRewards {
+		if dReward.ProviderID == node.ID && dReward.RewardType == rewardType {
+			picked = append(picked, dReward.PoolID)
+		}
+	}
+	if len(picked) > 0 {
+		block.Picked = map[string][]string{node.ID: picked}
+	}
+	require.NoError(t, model.Apply(block), "round %d", round)
+
+	payout, err := model.Rewards(node.ID)
+	require.NoError(t, err)
+	return payout
 }
 
 func getSharderUrl(t *test.SystemTest) string {
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutil "github.com/0chain/system_test/internal/cli/util"
 )
@@ -68,6 +69,7 @@ func TestMinerFeeRewards(testSetup *testing.T) { // nolint:gocyclo // team prefe
 
 		history := cliutil.NewHistory(startRound, endRound)
 		history.Read(t, sharderUrl, true)
+		history.SaveOnFailure(t)
 
 		balanceMinerIncome(
 			t, startRound, endRound, minerIds, beforeMiners.Nodes, afterMiners.Nodes, history,
@@ -82,11 +84,11 @@ func balanceMinerIncome(
 	beforeMiners, afterMiners []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
-	minerScConfig := getMinerScMap(t)
+	config := feeRewardsConfig(minerRewardsConfig(t, getMinerScMap(t)))
 	checkMinerFeeAmounts(
 		t,
 		minerIds,
-		minerScConfig["share_ratio"],
+		config,
 		beforeMiners, afterMiners,
 		history,
 	)
@@ -96,8 +98,7 @@ func balanceMinerIncome(
 	checkMinerDelegatePoolFeeAmounts(
 		t,
 		minerIds,
-		int(minerScConfig["num_miner_delegates_rewarded"]),
-		minerScConfig["share_ratio"],
+		config,
 		beforeMiners, afterMiners,
 		history,
 	)
@@ -110,6 +111,7 @@ func balanceMinerIncome(
 // The amount of the reward is a fraction of the rewards allocated to miners each
 // round. The fraction is the miner's service charge. If the miner has
 // no stake pools then the reward becomes the full block reward.
+// The payment is compared with the one replayed by the rewards model.
 //
 // Firstly we confirm the self-consistency of the block and reward payments.
 // We calculate the change in each miner's rewards during and confirm that this
@@ -117,7 +119,7 @@ func balanceMinerIncome(
 func checkMinerFeeAmounts(
 	t *test.SystemTest,
 	minerIds []string,
-	minerShare float64,
+	config rewards.Config,
 	beforeMiners, afterMiners []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
@@ -126,14 +128,7 @@ func checkMinerFeeAmounts(
 		var blockRewards, feeRewards int64
 		for round := beforeMiners[i].RoundServiceChargeLastUpdated + 1; round <= afterMiners[i].RoundServiceChargeLastUpdated; round++ {
 			var recordedRoundRewards int64
-			var roundFees = history.FeesForRound(t, round)
 			roundHistory := history.RoundHistory(t, round)
-			var fees int64
-			if len(beforeMiners[i].StakePool.Pools) > 0 {
-				fees = int64(float64(roundFees) * beforeMiners[i].Settings.ServiceCharge * minerShare)
-			} else {
-				fees = int64(float64(roundFees) * minerShare)
-			}
 			for _, pReward := range roundHistory.ProviderRewards {
 				if pReward.ProviderId != id {
 					continue
@@ -152,10 +147,12 @@ func checkMinerFeeAmounts(
 			}
 			// if this miner is the round miner check fees add up
 			if id == roundHistory.Block.MinerID {
+				fees := history.FeesForRound(t, round)
+				payout := expectedPayout(t, config, round, fees, beforeMiners[i], rewards.Miner, climodel.FeeRewardMiner, roundHistory)
 				require.InDeltaf(
-					t, fees, recordedRoundRewards, delta,
+					t, int64(payout.Provider), recordedRoundRewards, delta,
 					"incorrect service charge %v for round %d"+
-						" service charge should be fees %d multiplied by service ratio %v."+
+						" of fees %d with service charge %v."+
 						"length stake pools %d, round history %v",
 					recordedRoundRewards, round, fees, beforeMiners[i].Settings.ServiceCharge,
 					len(beforeMiners[i].StakePool.Pools), roundHistory)
@@ -197,25 +194,23 @@ func checkMinerFeeRewardFrequency(
 // or all delegates if less.
 //
 // Delegates should be rewarded in proportional to their locked tokens.
-// We check the self-consistency of the reward payments each round using
-// the delegate reward table.
+// Fee rewards of the delegates are compared with the ones replayed by the rewards model.
 //
 // Next we compare the actual change in rewards to each miner delegate, with the
 // change expected from the delegate reward table.
 func checkMinerDelegatePoolFeeAmounts(
 	t *test.SystemTest,
 	minerIds []string,
-	numMinerDelegatesRewarded int,
-	minerShare float64,
+	config rewards.Config,
 	beforeMiners, afterMiners []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
 	t.Log("checking delegate pool fee payment amounts...")
 	for i, id := range minerIds {
 		numPools := len(afterMiners[i].StakePool.Pools)
-		rewards := make(map[string]int64, numPools)
+		poolRewards := make(map[string]int64, numPools)
 		for poolId := range afterMiners[i].StakePool.Pools {
-			rewards[poolId] = 0
+			poolRewards[poolId] = 0
 		}
 		for round := beforeMiners[i].RoundServiceChargeLastUpdated + 1; round <= afterMiners[i].RoundServiceChargeLastUpdated; round++ {
 			poolsBlockRewarded := make(map[string]int64)
@@ -224,7 +219,7 @@ func checkMinerDelegatePoolFeeAmounts(
 				if dReward.ProviderID != id {
 					continue
 				}
-				_, isMinerPool := rewards[dReward.PoolID]
+				_, isMinerPool := poolRewards[dReward.PoolID]
 				require.Truef(t, isMinerPool, "round %d, invalid pool id, reward %v", round, dReward)
 				switch dReward.RewardType {
 				case climodel.FeeRewardMiner:
@@ -232,9 +227,9 @@ func checkMinerDelegatePoolFeeAmounts(
 					require.False(t, found, "delegate pool %s paid a fee reward more than once on round %d",
 						dReward.PoolID, round)
 					poolsBlockRewarded[dReward.PoolID] = dReward.Amount
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				case climodel.BlockRewardMiner:
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				default:
 					require.Failf(t, "", "reward type %s not paid to miner delegate pools", dReward.RewardType.String())
 				}
@@ -242,38 +237,25 @@ func checkMinerDelegatePoolFeeAmounts(
 			if roundHistory.Block.MinerID != id {
 				require.Len(t, poolsBlockRewarded, 0,
 					"delegate pools should not get a block reward unless their parent miner won the round lottery")
+				continue
 			}
 			fees := history.FeesForRound(t, round)
 			if fees == 0 {
 				require.Equal(t, len(poolsBlockRewarded), 0)
 			} else {
-				confirmPoolPayments(
-					t,
-					delegateFeeRewards(
-						fees,
-						minerShare,
-						beforeMiners[i].Settings.ServiceCharge,
-						1,
-					),
-					poolsBlockRewarded,
-					afterMiners[i].StakePool.Pools,
-					numMinerDelegatesRewarded,
-				)
+				payout := expectedPayout(t, config, round, fees, beforeMiners[i], rewards.Miner, climodel.FeeRewardMiner, roundHistory)
+				confirmDelegatePayout(t, round, id, payout, poolsBlockRewarded)
 			}
 		}
 		for poolId := range afterMiners[i].StakePool.Pools {
 			actualReward := afterMiners[i].StakePool.Pools[poolId].Reward - beforeMiners[i].StakePool.Pools[poolId].Reward
-			require.InDeltaf(t, actualReward, rewards[poolId], delta,
-				"poolID %s, rewards expected %v change in pools reward during test", poolId, rewards[poolId],
+			require.InDeltaf(t, actualReward, poolRewards[poolId], delta,
+				"poolID %s, rewards expected %v change in pools reward during test", poolId, poolRewards[poolId],
 			)
 		}
 	}
 }
 
-func delegateFeeRewards(total int64, share, serviceCharge float64, numProvidersPaid int) int64 {
-	return int64(float64(total) * share * (1 - serviceCharge) / float64(numProvidersPaid))
-}
-
 func apiGetLatestFinalized(sharderBaseURL string) (*http.Response, error) {
 	return http.Get(sharderBaseURL + "/v1/block/get/latest_finalized")
 }
@@ -7,6 +7,7 @@ import (
 	climodel "github.com/0chain/system_test/internal/cli/model"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
 	cliutil "github.com/0chain/system_test/internal/cli/util"
 	"github.com/stretchr/testify/require"
 )
@@ -53,6 +54,7 @@ func TestSharderBlockRewards(testSetup *testing.T) { // nolint:gocyclo // team p
 
 		history := cliutil.NewHistory(startRound, endRound)
 		history.Read(t, sharderUrl, false)
+		history.SaveOnFailure(t)
 
 		balanceSharderRewards(
 			t, startRound, endRound, sharderIds, beforeSharders.Nodes, afterSharders.Nodes, history,
@@ -80,14 +82,12 @@ func balanceSharderRewards(
 		"epoch changed during test, start %v finish %v",
 		startRound/int64(minerScConfig["epoch"]), endRound/int64(minerScConfig["epoch"]))
 
-	_, sharderBlockReward := blockRewards(startRound, minerScConfig)
-	bwPerSharder := int64(float64(sharderBlockReward) / float64(numShardersRewarded))
+	config := minerRewardsConfig(t, minerScConfig)
 
 	checkSharderBlockRewards(
 		t,
 		sharderIds,
-		bwPerSharder,
-		numSharderDelegatesRewarded,
+		config,
 		beforeSharders, afterSharders,
 		history,
 	)
@@ -101,7 +101,7 @@ func balanceSharderRewards(
 	)
 
 	balanceSharderDelegatePoolBlockRewards(
-		t, sharderIds, numSharderDelegatesRewarded, bwPerSharder, beforeSharders, afterSharders, history,
+		t, sharderIds, config, beforeSharders, afterSharders, history,
 	)
 }
 
@@ -112,6 +112,7 @@ func balanceSharderRewards(
 // The amount of the reward is a fraction of the block reward allocated to sharders each
 // round. The fraction is the sharder's service charge. If the sharder has
 // no stake pools then the reward becomes the full block reward.
+// The payment is compared with the one replayed by the rewards model.
 //
 // If a selected sharder has delegate pools, we reward num_sharder_delegates_rewarded
 // of them proportionate with their balance, or all delegate pools if
@@ -120,13 +121,12 @@ func balanceSharderRewards(
 func checkSharderBlockRewards(
 	t *test.SystemTest,
 	sharderIds []string,
-	bwPerSharder int64,
-	numSharderDelegatesRewarded int,
+	config rewards.Config,
 	beforeSharders, afterSharders []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
 	for i, id := range sharderIds {
-		var rewards int64
+		var providerRewards int64
 		for round := beforeSharders[i].RoundServiceChargeLastUpdated + 1; round <= afterSharders[i].RoundServiceChargeLastUpdated; round++ {
 			roundHistory := history.RoundHistory(t, round)
 			for _, pReward := range roundHistory.ProviderRewards {
@@ -137,26 +137,20 @@ func checkSharderBlockRewards(
 				case climodel.BlockRewardSharder:
 					require.Falsef(t, beforeSharders[i].IsKilled,
 						"killed sharders cannot receive rewards, %s is killed", id)
-					var expectedServiceCharge int64
-					payAllToSharder := len(beforeSharders[i].StakePool.Pools) == 0 || numSharderDelegatesRewarded == 0
-					if payAllToSharder {
-						expectedServiceCharge = bwPerSharder
-					} else {
-						expectedServiceCharge = int64(float64(bwPerSharder) * beforeSharders[i].Settings.ServiceCharge)
-					}
-					require.InDeltaf(t, expectedServiceCharge, pReward.Amount, delta, "sharder service charge incorrect value on round %d", round)
-					rewards += pReward.Amount
+					payout := expectedPayout(t, config, round, 0, beforeSharders[i], rewards.Sharder, climodel.BlockRewardSharder, roundHistory)
+					require.InDeltaf(t, int64(payout.Provider), pReward.Amount, delta, "sharder service charge incorrect value on round %d", round)
+					providerRewards += pReward.Amount
 				case climodel.FeeRewardSharder:
-					rewards += pReward.Amount
+					providerRewards += pReward.Amount
 				default:
 					require.Failf(t, "", "reward type %s not available to sharders", pReward.RewardType.String())
 				}
 			}
 		}
 		actualReward := afterSharders[i].Reward - beforeSharders[i].Reward
-		if actualReward != rewards {
-			require.InDeltaf(t, actualReward, rewards, delta,
-				"rewards expected %v change in sharders reward during test %v", actualReward, rewards)
+		if actualReward != providerRewards {
+			require.InDeltaf(t, actualReward, providerRewards, delta,
+				"rewards expected %v change in sharders reward during test %v", actualReward, providerRewards)
 		}
 	}
 }
@@ -244,22 +238,21 @@ func countDelegatesRewarded(
 }
 
 // balanceSharderDelegatePoolBlockRewards
+// Block rewards of the delegates are compared with the ones replayed by the rewards model.
 // Compare the actual change in rewards to each sharder delegate, with the
 // change expected from the delegate reward table.
 func balanceSharderDelegatePoolBlockRewards(
 	t *test.SystemTest,
 	sharderIds []string,
-	numSharderDelegatesRewarded int,
-	bwPerSharder int64,
+	config rewards.Config,
 	beforeSharders, afterSharders []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
 	for i, id := range sharderIds {
-		delegateBlockReward := int64(float64(bwPerSharder) * (1 - beforeSharders[i].Settings.ServiceCharge))
 		numPools := len(afterSharders[i].StakePool.Pools)
-		rewards := make(map[string]int64, numPools)
+		poolRewards := make(map[string]int64, numPools)
 		for poolId := range afterSharders[i].StakePool.Pools {
-			rewards[poolId] = 0
+			poolRewards[poolId] = 0
 		}
 		for round := beforeSharders[i].RoundServiceChargeLastUpdated + 1; round <= afterSharders[i].RoundServiceChargeLastUpdated; round++ {
 			poolsBlockRewards := make(map[string]int64)
@@ -268,7 +261,7 @@ func balanceSharderDelegatePoolBlockRewards(
 				if dReward.ProviderID != id {
 					continue
 				}
-				_, isSharderPool := rewards[dReward.PoolID]
+				_, isSharderPool := poolRewards[dReward.PoolID]
 				require.Truef(t, isSharderPool, "round %d, invalid pool id, reward %v", round, dReward)
 				switch dReward.RewardType {
 				case climodel.BlockRewardSharder:
@@ -276,22 +269,25 @@ func balanceSharderDelegatePoolBlockRewards(
 					require.False(t, found, "pool %s gets more than one block reward on round %d",
 						dReward.PoolID, round)
 					poolsBlockRewards[dReward.PoolID] = dReward.Amount
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				case climodel.FeeRewardSharder:
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				default:
 					require.Failf(t, "", "reward type %s not available to sharders stake pools;"+
 						" received by sharder %s on round %d", dReward.RewardType.String(), round)
 				}
 			}
-			confirmPoolPayments(
-				t, delegateBlockReward, poolsBlockRewards, afterSharders[i].StakePool.Pools, numSharderDelegatesRewarded,
-			)
+			if rewardedOnRound(roundHistory, id, climodel.BlockRewardSharder) {
+				payout := expectedPayout(t, config, round, 0, beforeSharders[i], rewards.Sharder, climodel.BlockRewardSharder, roundHistory)
+				confirmDelegatePayout(t, round, id, payout, poolsBlockRewards)
+			} else {
+				require.Emptyf(t, poolsBlockRewards, "round %d, delegates of sharder %s rewarded without the sharder", round, id)
+			}
 		}
 		for poolId := range afterSharders[i].StakePool.Pools {
 			actualReward := afterSharders[i].StakePool.Pools[poolId].Reward - beforeSharders[i].StakePool.Pools[poolId].Reward
-			require.InDeltaf(t, actualReward, rewards[poolId], delta,
-				"rewards expected %v, change in rewards during test %v", actualReward, rewards[poolId])
+			require.InDeltaf(t, actualReward, poolRewards[poolId], delta,
+				"rewards expected %v, change in rewards during test %v", actualReward, poolRewards[poolId])
 		}
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/tokenomics/rewards"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutil "github.com/0chain/system_test/internal/cli/util"
 	"github.com/stretchr/testify/require"
@@ -60,6 +61,7 @@ func TestSharderFeeRewards(testSetup *testing.T) { // nolint:gocyclo // team pre
 
 		history := cliutil.NewHistory(startRound, endRound)
 		history.Read(t, sharderUrl, true)
+		history.SaveOnFailure(t)
 
 		balanceSharderIncome(
 			t, startRound, endRound, sharderIds, beforeSharders.Nodes, afterSharders.Nodes, history,
@@ -83,13 +85,12 @@ func balanceSharderIncome(
 		numShardersRewarded = len(sharderIds)
 	}
 
-	minerShare := minerScConfig["share_ratio"]
+	config := feeRewardsConfig(minerRewardsConfig(t, minerScConfig))
 
 	checkSharderFeeAmounts(
 		t,
 		sharderIds,
-		minerShare,
-		numShardersRewarded,
+		config,
 		beforeSharders, afterSharders,
 		history,
 	)
@@ -106,8 +107,7 @@ func balanceSharderIncome(
 	checkSharderDelegatePoolFeeAmounts(
 		t,
 		sharderIds,
-		minerShare,
-		numShardersRewarded, numSharderDelegatesRewarded,
+		config,
 		beforeSharders, afterSharders,
 		history,
 	)
@@ -121,6 +121,7 @@ func balanceSharderIncome(
 // round. Each round each sharder receiving a reward gets a fraction
 // determined by that sharder's service charge. If the sharder has
 // no stake pools then the reward becomes the full block reward.
+// The payment is compared with the one replayed by the rewards model.
 //
 // Firstly we confirm the self-consistency of the reward tables.
 // We calculate the change in each sharder's rewards during and confirm that this
@@ -128,8 +129,7 @@ func balanceSharderIncome(
 func checkSharderFeeAmounts(
 	t *test.SystemTest,
 	sharderIds []string,
-	minerShare float64,
-	numShardersRewarded int,
+	config rewards.Config,
 	beforeSharders, afterSharders []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
@@ -144,14 +144,8 @@ func checkSharderFeeAmounts(
 		}
 		for round := startRound; round <= afterSharders[i].RoundServiceChargeLastUpdated; round++ {
 			var recordedRoundRewards int64
-			fees := int64(float64(history.FeesForRound(t, round)) / float64(numShardersRewarded))
+			fees := history.FeesForRound(t, round)
 			roundHistory := history.RoundHistory(t, round)
-			var feesForSharder int64
-			if len(beforeSharders[i].StakePool.Pools) > 0 {
-				feesForSharder = int64(float64(fees) * beforeSharders[i].Settings.ServiceCharge * (1 - minerShare))
-			} else {
-				feesForSharder = int64(float64(fees) * (1 - minerShare))
-			}
 			for _, pReward := range roundHistory.ProviderRewards {
 				if pReward.ProviderId != id {
 					continue
@@ -160,7 +154,7 @@ func checkSharderFeeAmounts(
 				case climodel.FeeRewardSharder:
 					require.Falsef(t, beforeSharders[i].IsKilled,
 						"killed sharders cannot receive fees, %s is killed", id)
-					require.Greaterf(t, feesForSharder, int64(0), "fee reward with no fees, reward %v", pReward)
+					require.Greaterf(t, fees, int64(0), "fee reward with no fees, reward %v", pReward)
 					feeRewards += pReward.Amount
 					recordedRoundRewards += pReward.Amount
 				case climodel.BlockRewardSharder:
@@ -171,9 +165,10 @@ func checkSharderFeeAmounts(
 			}
 			// If sharder is one of the chosen sharders, check fee payment is correct
 			if recordedRoundRewards > 0 {
-				require.InDeltaf(t, feesForSharder, recordedRoundRewards, delta,
+				payout := expectedPayout(t, config, round, fees, beforeSharders[i], rewards.Sharder, climodel.FeeRewardSharder, roundHistory)
+				require.InDeltaf(t, int64(payout.Provider), recordedRoundRewards, delta,
 					"incorrect service charge %v for round %d"+
-						" service charge should be fees %d multiplied by service ratio %v."+
+						" of fees %d with service charge %v."+
 						"length stake pools %d",
 					recordedRoundRewards, round, fees, beforeSharders[i].Settings.ServiceCharge,
 					len(beforeSharders[i].StakePool.Pools))
@@ -258,25 +253,23 @@ func checkSharderDelegatePoolFeeRewardFrequency(
 // or all delegates if less.
 //
 // Delegates should be rewarded in proportional to their locked tokens.
-// We check the self-consistency of the reward payments each round using
-// the delegate reward table.
+// Fee rewards of the delegates are compared with the ones replayed by the rewards model.
 //
 // Next we compare the actual change in rewards to each sharder's delegates, with the
 // change as read from the delegate reward table.
 func checkSharderDelegatePoolFeeAmounts(
 	t *test.SystemTest,
 	sharderIds []string,
-	minerShare float64,
-	numShardersRewarded, numSharderDelegatesRewarded int,
+	config rewards.Config,
 	beforeSharders, afterSharders []climodel.Node,
 	history *cliutil.ChainHistory,
 ) {
 	t.Log("checking sharder delegate pools fee rewards")
 	for i, id := range sharderIds {
 		numPools := len(afterSharders[i].StakePool.Pools)
-		rewards := make(map[string]int64, numPools)
+		poolRewards := make(map[string]int64, numPools)
 		for poolId := range afterSharders[i].StakePool.Pools {
-			rewards[poolId] = 0
+			poolRewards[poolId] = 0
 		}
 		for round := beforeSharders[i].RoundServiceChargeLastUpdated + 1; round <= afterSharders[i].RoundServiceChargeLastUpdated; round++ {
 			fees := history.FeesForRound(t, round)
@@ -286,7 +279,7 @@ func checkSharderDelegatePoolFeeAmounts(
 				if dReward.ProviderID != id {
 					continue
 				}
-				_, isSharderPool := rewards[dReward.PoolID]
+				_, isSharderPool := poolRewards[dReward.PoolID]
 				require.Truef(t, isSharderPool, "round %d, invalid pool id, reward %v", round, dReward)
 				switch dReward.RewardType {
 				case climodel.FeeRewardSharder:
@@ -295,33 +288,23 @@ func checkSharderDelegatePoolFeeAmounts(
 					require.False(t, found, "delegate pool %s paid a fee reward more than once on round %d",
 						dReward.PoolID, round)
 					poolsBlockRewarded[dReward.PoolID] = dReward.Amount
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				case climodel.BlockRewardSharder:
-					rewards[dReward.PoolID] += dReward.Amount
+					poolRewards[dReward.PoolID] += dReward.Amount
 				default:
 					require.Failf(t, "mismatched reward type",
 						"", "reward type %s not paid to sharder delegate pools", dReward.RewardType)
 				}
 			}
-			if fees > 0 {
-				confirmPoolPayments(
-					t,
-					delegateFeeRewards(
-						fees,
-						1-minerShare,
-						beforeSharders[i].Settings.ServiceCharge,
-						numShardersRewarded,
-					),
-					poolsBlockRewarded,
-					afterSharders[i].StakePool.Pools,
-					numSharderDelegatesRewarded,
-				)
+			if fees > 0 && rewardedOnRound(roundHistory, id, climodel.FeeRewardSharder) {
+				payout := expectedPayout(t, config, round, fees, beforeSharders[i], rewards.Sharder, climodel.FeeRewardSharder, roundHistory)
+				confirmDelegatePayout(t, round, id, payout, poolsBlockRewarded)
 			}
 		}
 		for poolId := range afterSharders[i].StakePool.Pools {
 			actualReward := afterSharders[i].StakePool.Pools[poolId].Reward - beforeSharders[i].StakePool.Pools[poolId].Reward
-			require.InDeltaf(t, actualReward, rewards[poolId], delta,
-				"poolID %s, rewards expected %v change in pools reward during test", poolId, rewards[poolId],
+			require.InDeltaf(t, actualReward, poolRewards[poolId], delta,
+				"poolID %s, rewards expected %v change in pools reward during test", poolId, poolRewards[poolId],
 			)
 		}
 	}
@@ -1,107 +1,23 @@
 package zs3servertests
 
 import (
-	"log"
-	"os"
-	"strings"
 	"testing"
 	"time"
 
 	test "github.com/0chain/system_test/internal/api/util/test"
-	cliutils "github.com/0chain/system_test/internal/cli/util"
 )
 
-func TestZs3serverFanoutTests(testSetup *testing.T) {
-	timeout := time.Duration(200 * time.Minute)
-	err := os.Setenv("GO_TEST_TIMEOUT", timeout.String())
-	if err != nil {
-		log.Printf("Error setting environment variable: %v", err)
-	}
-
-	config := cliutils.ReadFile(testSetup)
+func TestZs3serverFanoutLoad(testSetup *testing.T) {
+	client, config := newLoad(testSetup)
 	t := test.NewSystemTest(testSetup)
 
-	// Check if mc is available - it's required for this test
-	if _, err := os.Stat("../mc"); os.IsNotExist(err) {
-		testSetup.Fatalf("mc is not installed at ../mc, which is required for this test")
-	}
-
-	// Remove alias if it exists (ignore errors)
-	_, _ = cliutils.RunCommand(t, "../mc alias rm warp-test", 1, time.Second*5)
-	
-	// Set up mc alias for the S3 server
-	aliasCommand := "../mc alias set warp-test http://" + config.Server + ":" + config.HostPort + " " + config.AccessKey + " " + config.SecretKey + " --api S3v2"
-	output, err := cliutils.RunCommand(t, aliasCommand, 1, time.Minute*2)
-	if err != nil {
-		testSetup.Fatalf("Failed to set mc alias: %v\nOutput: %s", err, output)
-	}
-
-	// Verify alias was set correctly by listing it
-	aliasListOutput, aliasListErr := cliutils.RunCommand(t, "../mc alias list", 1, time.Minute*2)
-	if aliasListErr != nil {
-		testSetup.Fatalf("Failed to list mc aliases: %v\nOutput: %s", aliasListErr, aliasListOutput)
-	}
-	aliasListStr := strings.Join(aliasListOutput, "\n")
-	if !strings.Contains(aliasListStr, "warp-test") {
-		testSetup.Fatalf("Alias 'warp-test' was not found in alias list. Output: %s", aliasListStr)
-	}
-
-	// Create the bucket that warp expects (warp-benchmark-bucket)
-	bucketCommand := "../mc mb warp-test/warp-benchmark-bucket"
-	output, err = cliutils.RunCommand(t, bucketCommand, 1, time.Minute*2)
-	if err != nil {
-		// Check if the error is because the bucket already exists (which is fine)
-		outputStr := strings.Join(output, "\n")
-		if strings.Contains(outputStr, "already exists") || strings.Contains(outputStr, "BucketAlreadyExists") {
-			// Bucket already exists, which is fine - continue
-			t.Logf("Bucket already exists, continuing...")
-		} else if strings.Contains(outputStr, "does not exist") || strings.Contains(outputStr, "Unable to make bucket") {
-			// Alias might not be properly configured, try to recreate it
-			t.Logf("Alias issue detected (error: %s), retrying alias setup...", outputStr)
-			_, aliasErr := cliutils.RunCommand(t, aliasCommand, 1, time.Minute*2)
-			if aliasErr != nil {
-				testSetup.Fatalf("Failed to recreate mc alias: %v", aliasErr)
-			}
-			// Verify alias again after recreation
-			aliasListOutput, aliasListErr = cliutils.RunCommand(t, "../mc alias list", 1, time.Minute*2)
-			if aliasListErr == nil {
-				aliasListStr = strings.Join(aliasListOutput, "\n")
-				if !strings.Contains(aliasListStr, "warp-test") {
-					testSetup.Fatalf("Alias 'warp-test' still not found after retry. Output: %s", aliasListStr)
-				}
-			}
-			// Retry bucket creation
-			output, err = cliutils.RunCommand(t, bucketCommand, 1, time.Minute*2)
-			if err != nil {
-				outputStr = strings.Join(output, "\n")
-				if !strings.Contains(outputStr, "already exists") && !strings.Contains(outputStr, "BucketAlreadyExists") {
-					testSetup.Fatalf("Failed to create bucket after retry: %v\nOutput: %s", err, outputStr)
-				}
-			} else {
-				t.Logf("Bucket created successfully after retry")
-			}
-		} else {
-			testSetup.Fatalf("Failed to create bucket: %v\nOutput: %s", err, outputStr)
-		}
-	} else {
-		t.Logf("Bucket created successfully")
-	}
-
-	commandGenerated := "../warp fanout --copies=50 --obj.size=512KiB --host=" + config.Server + ":" + config.HostPort + " --access-key=" + config.AccessKey + " --secret-key=" + config.SecretKey + "  --concurrent " + config.Concurrent + " --duration 30s" + " --obj.size " + config.ObjectSize
-
-	output, err = cliutils.RunCommand(t, commandGenerated, 1, time.Hour*3)
-
-	if err != nil {
-		testSetup.Fatalf("Error running warp multipart: %v\nOutput: %s", err, output)
-	}
-	output_string := strings.Join(output, "\n")
-	output_string = strings.Split(output_string, "----------------------------------------")[1]
-	output_string = strings.Split(output_string, "warp: Starting cleanup")[0]
+	t.RunSequentiallyWithTimeout("Fanout load should not fail any request", 200*time.Minute, func(t *test.SystemTest) {
+		createLoadBucket(t, client)
 
-	output_string = "Condition 1: Retention : objects: 1 \n--------\n" + output_string
-	err = cliutils.AppendToFile("warp-put_output.txt", output_string)
+		fanout := config
+		fanout.Copies = 50
 
-	if err != nil {
-		testSetup.Fatalf("Error appending to file: %v\n", err)
-	}
+		result, err := client.RunFanout(t, fanout)
+		reportLoad(t, result, err)
+	})
 }
@@ -0,0 +1,61 @@
+package zs3servertests
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	test "github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/zs3"
+	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	loadBucket   = "zs3-load-bucket"
+	loadDuration = 30 * time.Second
+	loadOutput   = "zs3server-load_output.json"
+)
+
+// newLoad creates client of zs3server from hosts.yaml together with load configured there
+func newLoad(testSetup *testing.T) (*zs3.Client, zs3.LoadConfig) {
+	config := cliutils.ReadFile(testSetup)
+
+	client, err := zs3.NewClient("http://"+config.Server+":"+config.HostPort, config.AccessKey, config.SecretKey)
+	require.NoError(testSetup, err)
+
+	objectSize, err := zs3.ParseSize(config.ObjectSize)
+	require.NoError(testSetup, err)
+	concurrency, err := strconv.Atoi(config.Concurrent)
+	require.NoError(testSetup, err)
+	objects, err := strconv.Atoi(config.ObjectCount)
+	require.NoError(testSetup, err)
+
+	return client, zs3.LoadConfig{
+		Bucket:      loadBucket,
+		ObjectSize:  objectSize,
+		Concurrency: concurrency,
+		Objects:     objects,
+		Duration:    loadDuration,
+	}
+}
+
+// createLoadBucket creates bucket of the load, the bucket is kept between runs
+func createLoadBucket(t *test.SystemTest, client *zs3.Client) {
+	err := client.CreateBucket(t, loadBucket)
+	if err != nil {
+		require.Contains(t, []string{"BucketAlreadyOwnedByYou", "BucketAlreadyExists"}, zs3.ErrorCode(err), err)
+	}
+}
+
+// reportLoad checks that no request failed and appends the result to the output file
+func reportLoad(t *test.SystemTest, result *zs3.LoadResult, err error) {
+	require.NoError(t, err)
+
+	output, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.NoError(t, cliutils.AppendToFile(loadOutput, string(output)+"\n"))
+
+	require.Zero(t, result.Errors(), "last error: %s", result.LastError)
+}
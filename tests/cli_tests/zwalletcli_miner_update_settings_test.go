@@ -1,6 +1,7 @@
 package cli_tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
@@ -73,14 +75,7 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 	})
 
 	t.RunSequentiallyWithTimeout("Miner update num_delegates by delegate wallet should work", 60*time.Second, func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 		output, err := minerSharderUpdateSettings(t, configPath, miner01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id":            miner.ID,
 			"num_delegates": 5,
@@ -105,14 +100,7 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 	})
 
 	t.RunSequentiallyWithTimeout("Miner update num_delegates greater than global max_delegates should fail", 60*time.Second, func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, miner01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id":            miner.ID,
@@ -127,14 +115,7 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 	})
 
 	t.RunSequentially("Miner update num_delegate negative value should fail", func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, miner01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id":            miner.ID,
@@ -149,14 +130,7 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 	})
 
 	t.RunSequentially("Miner update without miner id flag should fail", func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, miner01NodeDelegateWalletName, "", false)
 		require.NotNil(t, err, "expected error trying to update miner node settings without id, but got output:", strings.Join(output, "\n"))
@@ -167,14 +141,7 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 	})
 
 	t.RunSequentially("Miner update with nothing to update should fail", func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, miner01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id": miner.ID,
@@ -215,3 +182,18 @@ func minerInfo(t *test.SystemTest, cliConfigFilename, params string, retry bool)
 func getCurrentRound(t *test.SystemTest) int64 {
 	return getLatestFinalizedBlock(t).Round
 }
+
+// waitForCooldown waits until node settings can be updated again
+func waitForCooldown(t *test.SystemTest, lastRoundOfSettingUpdate, cooldownPeriod int64) {
+	_, err := wait.UntilRound(context.Background(), t,
+		wait.Config{
+			Timeout:     20 * time.Minute,
+			Backoff:     wait.Jittered(wait.Constant(10*time.Second), 0.2),
+			Description: "cooldown of node settings update",
+		},
+		lastRoundOfSettingUpdate+cooldownPeriod,
+		func(context.Context) (int64, error) {
+			return getCurrentRound(t), nil
+		})
+	require.NoError(t, err)
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/0chain/system_test/internal/api/model"
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wallets"
 
 	"github.com/stretchr/testify/require"
 
@@ -17,10 +19,25 @@ import (
 	cliutils "github.com/0chain/system_test/internal/cli/util"
 )
 
+// createWallet writes a wallet leased from the pool for the test, the wallet file is removed and the wallet is
+// returned to the pool when the test finishes
 func createWallet(t *test.SystemTest) {
-	createWalletForName(escapedTestName(t))
+	name := escapedTestName(t)
+	walletPath := fmt.Sprintf("./config/%s_wallet.json", name)
+
+	// check if wallet already exists
+	if _, err := os.Stat(walletPath); err == nil {
+		return
+	}
+
+	wallet := walletPool.Lease(t)
+	writeWallet(walletPath, wallet)
+	t.Cleanup(func() {
+		_ = os.Remove(walletPath)
+	})
 }
 
+// createWalletForName writes a wallet taken from the pool for the rest of the run
 func createWalletForName(name string) {
 	walletPath := fmt.Sprintf("./config/%s_wallet.json", name)
 
@@ -29,13 +46,17 @@ func createWalletForName(name string) {
 		return
 	}
 
-	walletMutex.Lock()
-	wallet := wallets[walletIdx]
-	walletIdx++
-	log.Println("Creating wallet for", name, "with walletIdx", walletIdx)
-	walletMutex.Unlock()
+	wallet, err := walletPool.Acquire(poolTest)
+	if err != nil {
+		log.Println("Error taking wallet for", name, "from pool:", err)
+		return
+	}
+	writeWallet(walletPath, wallet)
+}
 
-	err := os.WriteFile(walletPath, wallet, 0600)
+func writeWallet(walletPath string, wallet *model.Wallet) {
+	log.Println("Writing wallet", wallet.Id, "to", walletPath)
+	err := wallets.FromModel(wallet).Save(walletPath)
 	if err != nil {
 		fmt.Printf("Error writing file %s: %v\n", walletPath, err)
 	} else {
@@ -74,14 +74,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 
 		// revert sharder node settings after test
 		t.Cleanup(func() {
-			currRound := getCurrentRound(t)
-
-			if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-					time.Sleep(10 * time.Second)
-					currRound = getCurrentRound(t)
-				}
-			}
+			waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 			output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, createParams(map[string]interface{}{
 				"id":            selectedSharderID,
@@ -101,14 +94,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 		require.NoError(t, err, "Error fetching balance for sharder delegate wallet")
 		require.GreaterOrEqual(t, balance, 0.2, "Sharder delegate wallet must have at least 0.2 ZCN to pay for transaction fees")
 
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id":            selectedSharderID,
@@ -138,14 +124,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 		require.NoError(t, err, "Error fetching balance for sharder delegate wallet")
 		require.GreaterOrEqual(t, balance, 0.2, "Sharder delegate wallet must have at least 0.2 ZCN to pay for transaction fees")
 
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id":            selectedSharderID,
@@ -164,14 +143,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 		require.NoError(t, err, "Error fetching balance for sharder delegate wallet")
 		require.GreaterOrEqual(t, balance, 0.2, "Sharder delegate wallet must have at least 0.2 ZCN to pay for transaction fees")
 
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id":            selectedSharderID,
@@ -185,14 +157,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 	})
 
 	t.RunSequentially("Sharder update without sharder id flag should fail", func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, "--sharder", false)
 		require.NotNil(t, err, "expected error trying to update sharder node without id, but got output:", strings.Join(output, "\n"))
@@ -201,14 +166,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 	})
 
 	t.RunSequentially("Sharder update with nothing to update should fail", func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, createParams(map[string]interface{}{
 			"id":      selectedSharderID,
@@ -222,14 +180,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 	})
 
 	t.RunSequentially("Sharder update settings from non-delegate wallet should fail", func(t *test.SystemTest) {
-		currRound := getCurrentRound(t)
-
-		if (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-			for (currRound - lastRoundOfSettingUpdate) < cooldownPeriod {
-				time.Sleep(10 * time.Second)
-				currRound = getCurrentRound(t)
-			}
-		}
+		waitForCooldown(t, lastRoundOfSettingUpdate, cooldownPeriod)
 
 		createWallet(t)
 
@@ -135,10 +135,7 @@ func TestAllocationRewards(testSetup *testing.T) {
 		}), true)
 		require.Nil(t, err, "Error updating allocation", strings.Join(output, "\n"))
 
-		// sleep for 10 minutes
-		time.Sleep(10 * time.Minute)
-
-		alloc = utils.GetAllocation(t, allocationId)
+		alloc = utils.WaitForAllocationFinalized(t, allocationId)
 		require.Equal(t, true, alloc.Finalized, "Allocation should be finalized : ", alloc.ExpirationDate)
 		require.Greater(t, alloc.MovedToChallenge, movedToChallengePool, "MovedToChallenge should not change")
 
@@ -326,10 +323,7 @@ func TestAllocationRewards(testSetup *testing.T) {
 		err = os.Remove(filename)
 		require.Nil(t, err)
 
-		// sleep for 5 minutes
-		time.Sleep(10 * time.Minute)
-
-		alloc = utils.GetAllocation(t, allocationId)
+		alloc = utils.WaitForAllocationFinalized(t, allocationId)
 		require.Greater(t, alloc.MovedToChallenge, movedToChallengePool, "MovedToChallenge should increase")
 		require.Equal(t, true, alloc.Finalized, "Allocation should be finalized : ", alloc.ExpirationDate)
 
@@ -464,7 +458,7 @@ func TestAddOrReplaceBlobberAllocationRewards(testSetup *testing.T) {
 		require.Nil(t, err, "error uploading file", strings.Join(output, "\n"))
 
 		// Challenge Rewards
-		time.Sleep(10 * time.Minute)
+		utils.WaitForChallengeCompletion(t)
 		blobberRewards := getAllocationChallengeRewards(t, allocationId)
 
 		require.Equal(t, 3, len(blobberRewards), "All 3 blobber should get the rewards")
@@ -575,7 +569,7 @@ func TestAddOrReplaceBlobberAllocationRewards(testSetup *testing.T) {
 		}, true)
 		require.Nil(t, err, "error uploading file", strings.Join(output, "\n"))
 
-		time.Sleep(12 * time.Minute)
+		utils.WaitForChallengeCompletion(t)
 
 		// Challenge Rewards
 		blobberRewards := getAllocationChallengeRewards(t, allocationId)
@@ -385,8 +385,7 @@ func assertChallengeRewardsForOneDelegateEach(t *test.SystemTest, allocationId s
 		require.Nil(t, err, "error deleting file", strings.Join(output, "\n"))
 	}
 
-	// sleep for 10 minutes
-	time.Sleep(10 * time.Minute)
+	utils.WaitForChallengeCompletion(t)
 
 	allocation := utils.GetAllocation(t, allocationId)
 
@@ -464,8 +463,7 @@ func assertChallengeRewardsForTwoDelegatesEach(t *test.SystemTest, allocationId
 	}, true)
 	require.Nil(t, err, "error uploading file", strings.Join(output, "\n"))
 
-	// sleep for 10 minutes
-	time.Sleep(10 * time.Minute)
+	utils.WaitForChallengeCompletion(t)
 
 	allocation := utils.GetAllocation(t, allocationId)
 
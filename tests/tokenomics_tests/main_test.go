@@ -151,7 +151,16 @@ func TestMain(m *testing.M) {
 
 	setupConfig()
 
+	if err := test.NewReporterFromEnv("tokenomics_tests"); err != nil {
+		log.Printf("Test report could not be created: %v", err)
+	}
+
 	exitRun := m.Run()
+	if test.DefaultReporter != nil {
+		if err := test.DefaultReporter.Close(); err != nil {
+			log.Printf("Test report could not be written: %v", err)
+		}
+	}
 
 	os.Exit(exitRun)
 }
@@ -289,7 +289,7 @@ func TestMinStakeForProviders(testSetup *testing.T) {
 		}, true)
 		require.Nil(t, err, "error uploading file", strings.Join(output, "\n"))
 
-		time.Sleep(3 * time.Minute)
+		utils.WaitForChallengeCompletion(t)
 
 		// When there are stakes less than min stakes per delegate pool
 		for _, blobberId := range blobberListString {
@@ -316,7 +316,7 @@ func TestMinStakeForProviders(testSetup *testing.T) {
 			require.Nil(t, err, "Error staking tokens")
 		}
 
-		time.Sleep(5 * time.Minute)
+		utils.WaitForChallengeCompletion(t)
 
 		for _, blobberId := range blobberListString {
 			challengeRewardQuery := fmt.Sprintf("provider_id = '%s' AND reward_type = %d", blobberId, ChallengePassReward)
@@ -20,6 +20,7 @@ import (
 	"github.com/0chain/gosdk/core/conf"
 	"github.com/0chain/gosdk/zboxcore/sdk"
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,8 @@ var (
 	createAllocationRegex = regexp.MustCompile(`^Allocation created: (.+)$`)
 )
 
+const allocationFinalizeTimeout = 15 * time.Minute
+
 func SetupEnterpriseAllocationAndReadLock(t *test.SystemTest, cliConfigFilename string, extraParam map[string]interface{}) string {
 	allocationID := SetupEnterpriseAllocation(t, cliConfigFilename, extraParam)
 	return allocationID
@@ -228,6 +231,30 @@ func GetAllocation(t *test.SystemTest, allocationID string) (allocation climodel
 	return
 }
 
+// WaitForAllocationFinalized waits until the allocation is finalized after its expiration
+func WaitForAllocationFinalized(t *test.SystemTest, allocationID string) climodel.Allocation {
+	allocation, err := wait.Poll(context.Background(), t, wait.Config{
+		Timeout:     allocationFinalizeTimeout,
+		Backoff:     wait.Constant(30 * time.Second),
+		Description: "allocation " + allocationID + " to be finalized",
+	}, func(ctx context.Context) (climodel.Allocation, bool, error) {
+		var allocation climodel.Allocation
+		output, err := getAllocationWithRetry(t, configPath, allocationID, 1)
+		if err != nil {
+			return allocation, false, err
+		}
+		if len(output) == 0 {
+			return allocation, false, errors.New("getting allocation - output is empty")
+		}
+		if err := json.Unmarshal([]byte(output[0]), &allocation); err != nil {
+			return allocation, false, err
+		}
+		return allocation, allocation.Finalized, nil
+	})
+	require.NoError(t, err)
+	return allocation
+}
+
 func getAllocationWithRetry(t *test.SystemTest, cliConfigFilename, allocationID string, retry int) ([]string, error) {
 	t.Logf("Get Allocation...")
 	output, err := cliutils.RunCommand(t, fmt.Sprintf(
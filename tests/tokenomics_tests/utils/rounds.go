@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+	cliutil "github.com/0chain/system_test/internal/cli/util"
+	"github.com/stretchr/testify/require"
+)
+
+// Contains settings of round based waits
+const (
+	roundWaitTimeout = 30 * time.Minute
+	roundWaitBackoff = 10 * time.Second
+)
+
+type storageSCConfig struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// latestFinalizedRound returns source of round based waits reading the given sharder
+func latestFinalizedRound(sharderBaseURL string) func(ctx context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, sharderBaseURL+"/v1/block/get/latest_finalized", http.NoBody)
+		if err != nil {
+			return 0, err
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return 0, fmt.Errorf("latest finalized block: status %d", res.StatusCode)
+		}
+
+		var block climodel.LatestFinalizedBlock
+		if err := json.NewDecoder(res.Body).Decode(&block); err != nil {
+			return 0, err
+		}
+		return block.Round, nil
+	}
+}
+
+// WaitForRounds waits until the given number of rounds is finalized after the current one
+func WaitForRounds(t *test.SystemTest, rounds int64) {
+	waitForRounds(t, GetSharderUrl(t), rounds)
+}
+
+func waitForRounds(t *test.SystemTest, sharderBaseURL string, rounds int64) {
+	_, err := wait.ForRounds(context.Background(), t, wait.Config{
+		Timeout:     roundWaitTimeout,
+		Backoff:     wait.Constant(roundWaitBackoff),
+		Description: fmt.Sprintf("%d rounds to be finalized", rounds),
+	}, rounds, latestFinalizedRound(sharderBaseURL))
+	require.NoError(t, err)
+}
+
+// WaitForChallengeCompletion waits until every challenge generated so far is either answered or expired,
+// which takes max_challenge_completion_rounds of the storage smart contract
+func WaitForChallengeCompletion(t *test.SystemTest) {
+	sharderBaseURL := GetSharderUrl(t)
+
+	config := cliutil.ApiGetRetries[storageSCConfig](t, sharderBaseURL+"/v1/screst/"+StorageSmartContractAddress+"/storage-config", nil, 3)
+	rounds, err := strconv.ParseInt(config.Fields["max_challenge_completion_rounds"], 10, 64)
+	require.NoError(t, err, "max_challenge_completion_rounds is missing in storage config")
+
+	waitForRounds(t, sharderBaseURL, rounds)
+}